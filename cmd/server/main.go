@@ -2,6 +2,10 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -13,6 +17,8 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/config"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/adapters/listener"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/adapters/rest"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/adapters/web"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
@@ -20,169 +26,411 @@ import (
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/drivers/inmemory"
 	basketdrivermongodb "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/drivers/mongodb"
+	basketdriversqlite "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/drivers/sqlite"
+	identityauth "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/adapters/auth"
+	identityrest "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/adapters/rest"
+	identityweb "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/adapters/web"
+	identity "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+	identityusecases "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/usecases"
+	identitydriverhmac "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/drivers/hmac"
+	identitydriverinmemory "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/drivers/inmemory"
+	orderrest "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/adapters/rest"
+	order "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	orderusecases "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases"
+	orderhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/helper"
+	orderdriverinmemory "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/drivers/inmemory"
+	orderdrivermongodb "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/drivers/mongodb"
+	promotion "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	promotionhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/usecases/helper"
+	promotiondriverinmemory "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/drivers/inmemory"
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	taxhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/usecases/helper"
+	warehouserest "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/adapters/rest"
 	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehouseusecases "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases"
 	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
 	warehousedriverinmemory "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/inmemory"
+	warehousedrivermongodb "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/mongodb"
+	warehousedriversqlite "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/sqlite"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/httptimeout"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/sqlite"
 )
 
 func main() {
-	err := startHTTPServer()
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		err := runSeedCommand(os.Args[2:])
+		if err != nil {
+			log.Fatalf("Failed to seed: %v", err)
+		}
+		return
+	}
+
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path of the YAML or JSON config file, the environment variables override it")
+	printConfig := flag.Bool("print-config", false, "print the configuration with masked secrets and exit")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile, os.LookupEnv)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	if *printConfig {
+		err = cfg.Print(os.Stdout)
+		if err != nil {
+			log.Fatalf("Failed to print config: %v", err)
+		}
+		return
+	}
+
+	err = startHTTPServer(cfg)
 	if err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
 
-func startHTTPServer() error {
+func startHTTPServer(cfg *config.Config) error {
 	fmt.Println("Starting server...")
 
+	ctx := context.Background()
+
 	// create drivers
 
 	var basketRepository entities.BasketRepository
+	var orderRepository order.OrderRepository
+	var productRepository warehouse.ProductRepository
+	var stockMovementRepository warehouse.StockMovementRepository
+	var reservationRepository warehouse.ReservationRepository
 
-	switch os.Getenv("DRIVER") {
-	case "mongodb":
+	switch cfg.Driver {
+	case config.DriverMongoDB:
 		fmt.Printf("Driver: MongoDB\n")
 
-		clientOpts := options.Client().ApplyURI("mongodb://localhost:27017")
-		mongoClient, mongoClientErr := mongo.Connect(clientOpts)
+		mongoClient, mongoClientErr := connectMongoDB(cfg)
 		if mongoClientErr != nil {
 			panic(mongoClientErr)
 		}
 		defer func() {
-			if mongoClientErr = mongoClient.Disconnect(context.TODO()); mongoClientErr != nil {
+			if mongoClientErr = mongoClient.Disconnect(ctx); mongoClientErr != nil {
 				panic(mongoClientErr)
 			}
 		}()
 
-		basketsCollection := mongoClient.Database(basketdrivermongodb.DatabaseName).Collection(basketdrivermongodb.BasketsCollectionName)
+		database := mongoClient.Database(cfg.MongoDB.Database)
+
+		basketsCollection := database.Collection(basketdrivermongodb.BasketsCollectionName)
+
+		ordersCollection := database.Collection(orderdrivermongodb.OrdersCollectionName)
+
+		productsCollection := database.Collection(warehousedrivermongodb.ProductsCollectionName)
+
+		stockMovementsCollection := database.Collection(warehousedrivermongodb.StockMovementsCollectionName)
+
+		reservationsCollection := database.Collection(warehousedrivermongodb.ReservationsCollectionName)
+
+		orderRepository = orderdrivermongodb.NewMongoOrderRepository(ordersCollection)
+
+		var basketRepositoryErr error
+		basketRepository, basketRepositoryErr = basketdrivermongodb.NewMongoBasketRepository(ctx, basketsCollection)
+		if basketRepositoryErr != nil {
+			return basketRepositoryErr
+		}
+
+		var productRepositoryErr error
+		productRepository, productRepositoryErr = warehousedrivermongodb.NewMongoProductRepository(ctx, productsCollection)
+		if productRepositoryErr != nil {
+			return productRepositoryErr
+		}
+
+		var stockMovementRepositoryErr error
+		stockMovementRepository, stockMovementRepositoryErr = warehousedrivermongodb.NewMongoStockMovementRepository(ctx, stockMovementsCollection)
+		if stockMovementRepositoryErr != nil {
+			return stockMovementRepositoryErr
+		}
+
+		var reservationRepositoryErr error
+		reservationRepository, reservationRepositoryErr = warehousedrivermongodb.NewMongoReservationRepository(ctx, reservationsCollection)
+		if reservationRepositoryErr != nil {
+			return reservationRepositoryErr
+		}
+	case config.DriverSQLite:
+		fmt.Printf("Driver: SQLite\n")
 
-		basketRepository = basketdrivermongodb.NewMongoBasketRepository(basketsCollection)
+		db, dbErr := openSQLite(ctx, cfg)
+		if dbErr != nil {
+			return dbErr
+		}
+		defer func() {
+			if dbErr = db.Close(); dbErr != nil {
+				log.Printf("Failed to close database: %v", dbErr)
+			}
+		}()
+
+		basketRepository = basketdriversqlite.NewSQLiteBasketRepository(db)
+		// there is no sqlite driver for the orders yet
+		orderRepository = orderdriverinmemory.NewInMemoryOrderRepository()
+		productRepository = warehousedriversqlite.NewSQLiteProductRepository(db)
+		stockMovementRepository = warehousedriversqlite.NewSQLiteStockMovementRepository(db)
+		reservationRepository = warehousedriversqlite.NewSQLiteReservationRepository(db)
 	default:
 		fmt.Printf("Driver: InMemory\n")
 
 		basketRepository = inmemory.NewInMemoryBasketRepository()
+		orderRepository = orderdriverinmemory.NewInMemoryOrderRepository()
+		productRepository = warehousedriverinmemory.NewInMemoryProductRepository()
+		stockMovementRepository = warehousedriverinmemory.NewInMemoryStockMovementRepository()
+		reservationRepository = warehousedriverinmemory.NewInMemoryReservationRepository()
 	}
 
-	productRepository := warehousedriverinmemory.NewInMemoryProductRepository()
-	productRepository.Save(
-		&warehouse.Product{
-			ID:   "A12341",
-			Name: "Product 1",
-			Price: &warehouse.ProductPrice{
-				Value:    11.99,
-				Currency: "EUR",
-			},
-			Stock: 10,
-		},
-	)
-	productRepository.Save(
-		&warehouse.Product{
-			ID:   "A12342",
-			Name: "Product 2",
-			Price: &warehouse.ProductPrice{
-				Value:    12.99,
-				Currency: "EUR",
-			},
-			Stock: 20,
-		},
-	)
-	productRepository.Save(
-		&warehouse.Product{
-			ID:   "A12343",
-			Name: "Product 3",
-			Price: &warehouse.ProductPrice{
-				Value:    13.99,
-				Currency: "EUR",
-			},
-			Stock: 30,
+	locationRepository, locationRepositoryErr := newLocationRepository(ctx, cfg)
+	if locationRepositoryErr != nil {
+		return locationRepositoryErr
+	}
+
+	stockLedgerService, stockLedgerServiceErr := warehousehelper.NewStockLedgerService(productRepository, locationRepository, stockMovementRepository)
+	if stockLedgerServiceErr != nil {
+		return stockLedgerServiceErr
+	}
+
+	// the startup seeding only creates the missing products, so the stored stock and prices are kept on a restart
+	seedErr := seedProducts(ctx, productRepository, stockLedgerService, cfg.Seed.Files, true)
+	if seedErr != nil {
+		return seedErr
+	}
+
+	// products stored before the ledger existed get their stock as opening balance
+	openingBalances, openingBalancesErr := stockLedgerService.RecordOpeningBalances(ctx)
+	if openingBalancesErr != nil {
+		return openingBalancesErr
+	}
+	if openingBalances > 0 {
+		fmt.Printf("Recorded %d opening balances in the stock ledger\n", openingBalances)
+	}
+
+	promotionRepository := promotiondriverinmemory.NewInMemoryPromotionRepository()
+	for _, demoPromotion := range []*promotion.Promotion{
+		{
+			Code:       "TEN",
+			Type:       promotion.PromotionTypePercentage,
+			Percentage: 10,
 		},
-	)
-	productRepository.Save(
-		&warehouse.Product{
-			ID:   "A12344",
-			Name: "Product 4",
-			Price: &warehouse.ProductPrice{
-				Value:    14.99,
-				Currency: "EUR",
-			},
-			Stock: 0,
+		{
+			Code:         "FIVE",
+			Type:         promotion.PromotionTypeFixedAmount,
+			Amount:       money.New(500, "EUR"),
+			MinimumTotal: money.New(5000, "EUR"),
 		},
-	)
-	productRepository.Save(
-		&warehouse.Product{
-			ID:   "A12345",
-			Name: "Product 5",
-			Price: &warehouse.ProductPrice{
-				Value:    15.99,
-				Currency: "EUR",
-			},
-			Stock: 50,
+		{
+			Code:      "B2G1",
+			Type:      promotion.PromotionTypeBuyXGetY,
+			ProductID: "A12345",
+			BuyCount:  2,
+			FreeCount: 1,
 		},
-	)
+	} {
+		promotionErr := promotionRepository.Save(ctx, demoPromotion)
+		if promotionErr != nil {
+			return promotionErr
+		}
+	}
+
+	userFactory := identity.NewUserFactory()
+	userRepository := identitydriverinmemory.NewInMemoryUserRepository()
+	for _, demoUser := range []struct{ id, username, password string }{
+		{"1337", "demo", "demo"},
+		{"1338", "demo2", "demo2"},
+	} {
+		user, userErr := userFactory.NewUser(demoUser.id, demoUser.username, demoUser.password)
+		if userErr != nil {
+			return userErr
+		}
+		userErr = userRepository.Save(ctx, user)
+		if userErr != nil {
+			return userErr
+		}
+	}
+
+	// the admin manages the products, it only exists with a configured password
+	if cfg.Auth.AdminPassword != "" {
+		adminUser, adminUserErr := userFactory.NewUser("admin", "admin", cfg.Auth.AdminPassword, identity.RoleAdmin)
+		if adminUserErr != nil {
+			return adminUserErr
+		}
+		adminUserErr = userRepository.Save(ctx, adminUser)
+		if adminUserErr != nil {
+			return adminUserErr
+		}
+	}
+
+	authSecret, authSecretErr := getAuthSecret(cfg.Auth.Secret)
+	if authSecretErr != nil {
+		return authSecretErr
+	}
+
+	tokenService, tokenServiceErr := identitydriverhmac.NewHMACTokenService(authSecret, identitydriverhmac.DefaultTokenLifetime)
+	if tokenServiceErr != nil {
+		return tokenServiceErr
+	}
 
 	// create business logic and inject drivers
 
+	stockReservationService, stockReservationServiceErr := warehousehelper.NewStockReservationService(productRepository, reservationRepository, stockLedgerService, cfg.Reservation.Lifetime)
+	if stockReservationServiceErr != nil {
+		return stockReservationServiceErr
+	}
+
+	allocationStrategy, allocationStrategyErr := warehouse.NewAllocationStrategy(cfg.Warehouse.Allocation)
+	if allocationStrategyErr != nil {
+		return allocationStrategyErr
+	}
+
+	stockAllocationService, stockAllocationServiceErr := warehousehelper.NewStockAllocationService(locationRepository, stockLedgerService, allocationStrategy)
+	if stockAllocationServiceErr != nil {
+		return stockAllocationServiceErr
+	}
+
+	promotionEngine := promotionhelper.NewPromotionEngine(promotionRepository)
+
+	taxCalculationService, taxCalculationServiceErr := taxhelper.NewTaxCalculationService(cfg.Tax.Country, taxRates)
+	if taxCalculationServiceErr != nil {
+		return taxCalculationServiceErr
+	}
+
 	basketFactory := entities.NewBasketFactory()
 
 	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepository)
-	basketOutputService := helper.NewBasketOutputService(productRepository)
+	basketOutputService := helper.NewBasketOutputService(productRepository, stockReservationService, promotionEngine, taxCalculationService)
 
 	showBasketUseCase := usecases.NewShowBasketUseCaseImpl(basketCreatorService, basketOutputService)
-	clearBasketUseCase := usecases.NewClearBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepository)
-	addProductUseCase := usecases.NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepository, productRepository)
-	updateProductCountUseCase := usecases.NewUpdateProductCountImpl(basketCreatorService, basketOutputService, basketRepository, productRepository)
-	removeProductUseCase := usecases.NewRemoveProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepository, productRepository)
+	clearBasketUseCase := usecases.NewClearBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepository, stockReservationService)
+	addProductUseCase := usecases.NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepository, productRepository, stockReservationService)
+	updateProductCountUseCase := usecases.NewUpdateProductCountImpl(basketCreatorService, basketOutputService, basketRepository, productRepository, stockReservationService)
+	removeProductUseCase := usecases.NewRemoveProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepository, productRepository, stockReservationService)
+	mergeBasketUseCase := usecases.NewMergeBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepository, stockReservationService)
+	applyCouponUseCase := usecases.NewApplyCouponUseCaseImpl(basketCreatorService, basketOutputService, basketRepository, promotionRepository)
+	removeCouponUseCase := usecases.NewRemoveCouponUseCaseImpl(basketCreatorService, basketOutputService, basketRepository)
+
+	orderFactory := order.NewOrderFactory()
+	orderOutputService := orderhelper.NewOrderOutputService()
+
+	checkoutUseCase := orderusecases.NewCheckoutUseCaseImpl(orderFactory, orderOutputService, orderRepository, basketRepository, productRepository, stockReservationService, stockLedgerService, stockAllocationService, promotionEngine, taxCalculationService)
+	listOrdersUseCase := orderusecases.NewListOrdersUseCaseImpl(orderOutputService, orderRepository)
+	showOrderUseCase := orderusecases.NewShowOrderUseCaseImpl(orderOutputService, orderRepository)
+	cancelOrderUseCase := orderusecases.NewCancelOrderUseCaseImpl(orderOutputService, orderRepository, stockLedgerService)
+
+	productOutputService := warehousehelper.NewProductOutputService()
+
+	listProductsUseCase := warehouseusecases.NewListProductsUseCaseImpl(productOutputService, productRepository)
+	showProductUseCase := warehouseusecases.NewShowProductUseCaseImpl(productOutputService, productRepository)
+
+	createProductUseCase := warehouseusecases.NewCreateProductUseCaseImpl(productOutputService, warehouse.NewProductFactory(), productRepository, stockLedgerService)
+	showAdminProductUseCase := warehouseusecases.NewShowAdminProductUseCaseImpl(productOutputService, productRepository)
+	updateProductUseCase := warehouseusecases.NewUpdateProductUseCaseImpl(productOutputService, stockLedgerService)
+	setProductPriceUseCase := warehouseusecases.NewSetProductPriceUseCaseImpl(productOutputService, stockLedgerService)
+	setProductStockPolicyUseCase := warehouseusecases.NewSetProductStockPolicyUseCaseImpl(productOutputService, stockLedgerService)
+	adjustProductStockUseCase := warehouseusecases.NewAdjustProductStockUseCaseImpl(productOutputService, productRepository, stockLedgerService)
+	deactivateProductUseCase := warehouseusecases.NewDeactivateProductUseCaseImpl(productOutputService, productRepository, stockLedgerService)
+	reportStockMovementsUseCase := warehouseusecases.NewReportStockMovementsUseCaseImpl(productRepository, stockMovementRepository)
+
+	loginUseCase := identityusecases.NewLoginUseCaseImpl(userRepository, tokenService, listener.NewGuestLoginListener(mergeBasketUseCase))
+	createGuestUseCase := identityusecases.NewCreateGuestUseCaseImpl(tokenService)
 
 	// simulate price changes
 
-	productPriceSimulatorService, productPriceSimulatorServiceErr := warehousehelper.NewProductPriceSimulatorService(productRepository)
-	if productPriceSimulatorServiceErr != nil {
-		return productPriceSimulatorServiceErr
+	if cfg.Simulator.Enabled {
+		productPriceSimulatorService, productPriceSimulatorServiceErr := warehousehelper.NewProductPriceSimulatorService(productRepository, stockLedgerService)
+		if productPriceSimulatorServiceErr != nil {
+			return productPriceSimulatorServiceErr
+		}
+
+		productPriceSimulatorBackgroundService, productPriceSimulatorBackgroundServiceErr := warehousehelper.NewProductPriceSimulatorBackgroundService(productPriceSimulatorService, cfg.Simulator.Interval)
+		if productPriceSimulatorBackgroundServiceErr != nil {
+			return productPriceSimulatorBackgroundServiceErr
+		}
+
+		productPriceSimulatorBackgroundService.Start()
+		defer productPriceSimulatorBackgroundService.Stop()
 	}
 
-	productPriceSimulatorBackgroundService, productPriceSimulatorBackgroundServiceErr := warehousehelper.NewProductPriceSimulatorBackgroundService(productPriceSimulatorService)
-	if productPriceSimulatorBackgroundServiceErr != nil {
-		return productPriceSimulatorBackgroundServiceErr
+	// release expired stock reservations
+
+	reservationCleanupBackgroundService, reservationCleanupBackgroundServiceErr := warehousehelper.NewReservationCleanupBackgroundService(stockReservationService, cfg.Reservation.CleanupInterval)
+	if reservationCleanupBackgroundServiceErr != nil {
+		return reservationCleanupBackgroundServiceErr
 	}
 
-	productPriceSimulatorBackgroundService.Start()
-	defer productPriceSimulatorBackgroundService.Stop()
+	reservationCleanupBackgroundService.Start()
+	defer reservationCleanupBackgroundService.Stop()
 
 	// create interface adapters
 
 	router := gin.Default()
+	router.Use(httptimeout.NewRequestTimeout(cfg.HTTP.RequestTimeout))
 	router.GET("/ping", func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"message": "pong",
 		})
 	})
 
+	webLoginController := identityweb.NewLoginController(loginUseCase, createGuestUseCase)
+	webLoginControllerRouter := identityweb.NewLoginControllerRouter(webLoginController)
+	webLoginControllerRouterErr := webLoginControllerRouter.RegisterRoutes(router)
+	if webLoginControllerRouterErr != nil {
+		return webLoginControllerRouterErr
+	}
+
 	webBasketController := web.NewBasketController(showBasketUseCase)
-	webBasketControllerRouter := web.NewBasketControllerRouter(webBasketController)
+	webBasketControllerRouter := web.NewBasketControllerRouter(webBasketController, identityauth.NewSessionCookieAuthenticator(tokenService))
 	webBasketControllerRouterErr := webBasketControllerRouter.RegisterRoutes(router)
 	if webBasketControllerRouterErr != nil {
 		return webBasketControllerRouterErr
 	}
 
-	restBasketController := rest.NewBasketController(showBasketUseCase, clearBasketUseCase, addProductUseCase, updateProductCountUseCase, removeProductUseCase)
+	restLoginController := identityrest.NewLoginController(loginUseCase, createGuestUseCase)
+	restLoginControllerRouter := identityrest.NewLoginControllerRouter(restLoginController)
+	restLoginControllerRouterErr := restLoginControllerRouter.RegisterRoutes(router)
+	if restLoginControllerRouterErr != nil {
+		return restLoginControllerRouterErr
+	}
+
+	restBasketController := rest.NewBasketController(showBasketUseCase, clearBasketUseCase, addProductUseCase, updateProductCountUseCase, removeProductUseCase, applyCouponUseCase, removeCouponUseCase)
 	restBasketControllerRouter := rest.NewBasketControllerRouter(restBasketController)
-	restBasketControllerRouterErr := restBasketControllerRouter.RegisterRoutes(router)
+	restBasketControllerRouterErr := restBasketControllerRouter.RegisterRoutes(router.Group("", identityauth.NewBearerTokenAuthenticator(tokenService)))
 	if restBasketControllerRouterErr != nil {
 		return restBasketControllerRouterErr
 	}
 
-	// start http server
+	restOrderController := orderrest.NewOrderController(checkoutUseCase, listOrdersUseCase, showOrderUseCase, cancelOrderUseCase)
+	restOrderControllerRouter := orderrest.NewOrderControllerRouter(restOrderController)
+	restOrderControllerRouterErr := restOrderControllerRouter.RegisterRoutes(router.Group("", identityauth.NewBearerTokenAuthenticator(tokenService)))
+	if restOrderControllerRouterErr != nil {
+		return restOrderControllerRouterErr
+	}
+
+	// the catalog is public, so guests can find products before they get a token
+	restProductController := warehouserest.NewProductController(listProductsUseCase, showProductUseCase)
+	restProductControllerRouter := warehouserest.NewProductControllerRouter(restProductController)
+	restProductControllerRouterErr := restProductControllerRouter.RegisterRoutes(router)
+	if restProductControllerRouterErr != nil {
+		return restProductControllerRouterErr
+	}
 
-	addr := os.Getenv("HTTP_ADDR")
-	if addr == "" {
-		addr = "localhost:8080"
+	adminProductController := warehouserest.NewAdminProductController(createProductUseCase, showAdminProductUseCase, updateProductUseCase, setProductPriceUseCase, setProductStockPolicyUseCase, adjustProductStockUseCase, deactivateProductUseCase, reportStockMovementsUseCase)
+	adminProductControllerRouter := warehouserest.NewAdminProductControllerRouter(adminProductController)
+	adminProductControllerRouterErr := adminProductControllerRouter.RegisterRoutes(router.Group("/admin", identityauth.NewBearerTokenAuthenticator(tokenService), identityauth.NewRoleAuthorizer(identity.RoleAdmin)))
+	if adminProductControllerRouterErr != nil {
+		return adminProductControllerRouterErr
 	}
 
-	fmt.Printf("Starting server on %s\n", addr)
+	// start http server
+
+	fmt.Printf("Starting server on %s\n", cfg.HTTP.Addr)
 
 	httpServer := &http.Server{
-		Addr:    addr,
-		Handler: router,
+		Addr:         cfg.HTTP.Addr,
+		Handler:      router,
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
 	}
 
 	go func() {
@@ -206,3 +454,67 @@ func startHTTPServer() error {
 
 	return nil
 }
+
+// taxRates contains the VAT rates of the supported countries
+var taxRates = []*tax.TaxRate{
+	{Country: "DE", Class: tax.TaxClassStandard, BasisPoints: 1900},
+	{Country: "DE", Class: tax.TaxClassReduced, BasisPoints: 700},
+	{Country: "DE", Class: tax.TaxClassZero, BasisPoints: 0},
+	{Country: "AT", Class: tax.TaxClassStandard, BasisPoints: 2000},
+	{Country: "AT", Class: tax.TaxClassReduced, BasisPoints: 1000},
+	{Country: "AT", Class: tax.TaxClassZero, BasisPoints: 0},
+	{Country: "FR", Class: tax.TaxClassStandard, BasisPoints: 2000},
+	{Country: "FR", Class: tax.TaxClassReduced, BasisPoints: 550},
+	{Country: "FR", Class: tax.TaxClassZero, BasisPoints: 0},
+}
+
+// connectMongoDB creates the client of the mongodb driver, the credentials are optional
+func connectMongoDB(cfg *config.Config) (*mongo.Client, error) {
+	clientOpts := options.Client().ApplyURI(cfg.MongoDB.URI)
+	if cfg.MongoDB.Username != "" {
+		clientOpts.SetAuth(options.Credential{
+			Username: cfg.MongoDB.Username,
+			Password: cfg.MongoDB.Password,
+		})
+	}
+
+	return mongo.Connect(clientOpts)
+}
+
+// openSQLite opens the database of the sqlite driver and applies the migrations of all sqlite drivers
+func openSQLite(ctx context.Context, cfg *config.Config) (*sql.DB, error) {
+	db, err := sqlite.Open(cfg.SQLite.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, migrate := range []func(ctx context.Context, db *sql.DB) error{
+		basketdriversqlite.Migrate,
+		warehousedriversqlite.Migrate,
+	} {
+		err = migrate(ctx, db)
+		if err != nil {
+			return nil, errors.Join(err, db.Close())
+		}
+	}
+
+	return db, nil
+}
+
+// getAuthSecret returns the secret used to sign tokens and session cookies.
+// Without a configured secret a random secret is generated, so all tokens become invalid after a restart.
+func getAuthSecret(secret string) ([]byte, error) {
+	if secret != "" {
+		return []byte(secret), nil
+	}
+
+	fmt.Println("auth.secret is not set, generating a random secret")
+
+	randomSecret := make([]byte, 32)
+	_, err := rand.Read(randomSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return randomSecret, nil
+}
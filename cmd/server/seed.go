@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/config"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehouseusecases "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/fixture"
+	warehousedriverinmemory "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/inmemory"
+	warehousedrivermongodb "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/mongodb"
+	warehousedriversqlite "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/sqlite"
+)
+
+// runSeedCommand seeds the product fixture files of the arguments, or of seed.files without arguments
+func runSeedCommand(args []string) error {
+	flagSet := flag.NewFlagSet("seed", flag.ExitOnError)
+	configFile := flagSet.String("config", os.Getenv("CONFIG_FILE"), "path of the YAML or JSON config file, the environment variables override it")
+	flagSet.Usage = func() {
+		fmt.Fprintf(flagSet.Output(), "Usage: %s seed [--config file] [fixture files...]\n", os.Args[0])
+		flagSet.PrintDefaults()
+	}
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(*configFile, os.LookupEnv)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	files := cfg.Seed.Files
+	if flagSet.NArg() > 0 {
+		files = flagSet.Args()
+	}
+
+	ctx := context.Background()
+
+	var productRepository warehouse.ProductRepository
+	var stockMovementRepository warehouse.StockMovementRepository
+
+	switch cfg.Driver {
+	case config.DriverMongoDB:
+		mongoClient, mongoClientErr := connectMongoDB(cfg)
+		if mongoClientErr != nil {
+			return mongoClientErr
+		}
+		defer func() {
+			if mongoClientErr = mongoClient.Disconnect(ctx); mongoClientErr != nil {
+				log.Printf("Failed to disconnect from MongoDB: %v", mongoClientErr)
+			}
+		}()
+
+		database := mongoClient.Database(cfg.MongoDB.Database)
+
+		productRepository, err = warehousedrivermongodb.NewMongoProductRepository(ctx, database.Collection(warehousedrivermongodb.ProductsCollectionName))
+		if err != nil {
+			return err
+		}
+
+		stockMovementRepository, err = warehousedrivermongodb.NewMongoStockMovementRepository(ctx, database.Collection(warehousedrivermongodb.StockMovementsCollectionName))
+		if err != nil {
+			return err
+		}
+	case config.DriverSQLite:
+		db, dbErr := openSQLite(ctx, cfg)
+		if dbErr != nil {
+			return dbErr
+		}
+		defer func() {
+			if dbErr = db.Close(); dbErr != nil {
+				log.Printf("Failed to close database: %v", dbErr)
+			}
+		}()
+
+		productRepository = warehousedriversqlite.NewSQLiteProductRepository(db)
+		stockMovementRepository = warehousedriversqlite.NewSQLiteStockMovementRepository(db)
+	default:
+		// the in-memory products are lost when the command exits, so it only validates the files and reports the changes
+		productRepository = warehousedriverinmemory.NewInMemoryProductRepository()
+		stockMovementRepository = warehousedriverinmemory.NewInMemoryStockMovementRepository()
+	}
+
+	locationRepository, err := newLocationRepository(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	stockLedgerService, err := warehousehelper.NewStockLedgerService(productRepository, locationRepository, stockMovementRepository)
+	if err != nil {
+		return err
+	}
+
+	return seedProducts(ctx, productRepository, stockLedgerService, files, false)
+}
+
+// seedProducts upserts the products of the fixture files in order, a product of a later file overrides an earlier one.
+// The stock changes are recorded in the stock ledger. With skipExisting only the missing products are created.
+func seedProducts(ctx context.Context, productRepository warehouse.ProductRepository, stockLedgerService warehousehelper.StockLedgerService, files []string, skipExisting bool) error {
+	seedProductsUseCase := warehouseusecases.NewSeedProductsUseCaseImpl(warehouse.NewProductFactory(), productRepository, stockLedgerService)
+
+	for _, file := range files {
+		records, err := fixture.LoadProductRecords(file)
+		if err != nil {
+			return err
+		}
+
+		output, err := seedProductsUseCase.Execute(ctx, &warehouseusecases.SeedProductsUseCaseInput{
+			Products:     records,
+			SkipExisting: skipExisting,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to seed %s: %w", file, err)
+		}
+
+		fmt.Printf("Seeded %s: %d created, %d updated, %d unchanged, %d skipped\n", file, output.Created, output.Updated, output.Unchanged, output.Skipped)
+	}
+
+	return nil
+}
+
+// newLocationRepository saves the configured warehouse locations,
+// they are not stored in the database, because the configuration is their only source
+func newLocationRepository(ctx context.Context, cfg *config.Config) (warehouse.LocationRepository, error) {
+	locationRepository := warehousedriverinmemory.NewInMemoryLocationRepository()
+	for _, locationConfig := range cfg.Warehouse.Locations {
+		err := locationRepository.Save(ctx, locationConfig.Location())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return locationRepository, nil
+}
@@ -0,0 +1,31 @@
+// Removes the duplicate baskets stored before the unique indexes on "id" and "userid" of the baskets collection existed.
+// The server does not start with the mongodb driver as long as there are duplicates, because it cannot create the indexes.
+//
+// Run it once against the database of the server before starting the new version:
+//
+//   mongosh "mongodb://localhost:27017/ecommerce" docs/migrations/mongodb/0001_deduplicate_baskets.js
+//
+// Set DRY_RUN=1 to only print the duplicates. The oldest document of every id and userid is kept,
+// because the old Save replaced and the old FindByUserId returned the first document in insertion order.
+
+const dryRun = process.env.DRY_RUN === "1";
+const baskets = db.getCollection("baskets");
+
+for (const key of ["id", "userid"]) {
+  const duplicates = baskets.aggregate([
+    { $sort: { _id: 1 } },
+    { $group: { _id: "$" + key, documentIds: { $push: "$_id" }, count: { $sum: 1 } } },
+    { $match: { count: { $gt: 1 } } },
+  ], { allowDiskUse: true });
+
+  for (const duplicate of duplicates) {
+    const removedDocumentIds = duplicate.documentIds.slice(1);
+    if (dryRun) {
+      print(`${key} ${duplicate._id}: would remove ${removedDocumentIds.length} duplicate baskets`);
+      continue;
+    }
+
+    const result = baskets.deleteMany({ _id: { $in: removedDocumentIds } });
+    print(`${key} ${duplicate._id}: removed ${result.deletedCount} duplicate baskets`);
+  }
+}
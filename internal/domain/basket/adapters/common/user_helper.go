@@ -1,10 +1,26 @@
 package common
 
-const (
-	DEFAULT_USER_ID = "1337"
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/adapters/auth"
 )
 
-// GetUserID returns a static user id
-func GetUserID() string {
-	return DEFAULT_USER_ID
+// GetUserID returns the user id of the identity stored by the authenticator middleware
+func GetUserID(c *gin.Context) (string, error) {
+	identity, exists := auth.GetIdentity(c)
+	if !exists {
+		return "", fmt.Errorf("unauthorized")
+	}
+
+	return identity.GetUserID(), nil
+}
+
+// IsGuest returns true if the identity stored by the authenticator middleware is an anonymous guest
+func IsGuest(c *gin.Context) bool {
+	identity, exists := auth.GetIdentity(c)
+
+	return exists && identity.IsGuest()
 }
@@ -0,0 +1,36 @@
+package listener
+
+import (
+	"context"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases"
+	identityusecases "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/usecases"
+)
+
+var _ identityusecases.GuestLoginListener = (*GuestLoginListenerImpl)(nil)
+
+// GuestLoginListenerImpl merges the guest basket into the user basket when a guest logs in
+type GuestLoginListenerImpl struct {
+	mergeBasketUseCase usecases.MergeBasketUseCase
+}
+
+func NewGuestLoginListener(mergeBasketUseCase usecases.MergeBasketUseCase) identityusecases.GuestLoginListener {
+	return &GuestLoginListenerImpl{
+		mergeBasketUseCase: mergeBasketUseCase,
+	}
+}
+
+func (listener *GuestLoginListenerImpl) OnGuestLogin(ctx context.Context, guestUserID string, userID string) (map[string]string, error) {
+	output, err := listener.mergeBasketUseCase.Execute(
+		ctx,
+		&usecases.MergeBasketUseCaseInput{
+			GuestUserID: guestUserID,
+			UserID:      userID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Actions, nil
+}
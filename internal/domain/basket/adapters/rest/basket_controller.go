@@ -1,12 +1,16 @@
 package rest
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/adapters/common"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/etag"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/httperror"
 )
 
 type BasketController interface {
@@ -15,6 +19,8 @@ type BasketController interface {
 	AddProduct(c *gin.Context)
 	RemoveProduct(c *gin.Context)
 	UpdateProductCount(c *gin.Context)
+	ApplyCoupon(c *gin.Context)
+	RemoveCoupon(c *gin.Context)
 }
 
 var _ BasketController = (*BasketControllerImpl)(nil)
@@ -25,6 +31,8 @@ type BasketControllerImpl struct {
 	usecases.AddProductUseCase
 	usecases.UpdateProductCountUseCase
 	usecases.RemoveProductUseCase
+	usecases.ApplyCouponUseCase
+	usecases.RemoveCouponUseCase
 }
 
 func NewBasketController(
@@ -33,6 +41,8 @@ func NewBasketController(
 	addProductUseCase usecases.AddProductUseCase,
 	updateProductCountUseCase usecases.UpdateProductCountUseCase,
 	removeProductUseCase usecases.RemoveProductUseCase,
+	applyCouponUseCase usecases.ApplyCouponUseCase,
+	removeCouponUseCase usecases.RemoveCouponUseCase,
 ) *BasketControllerImpl {
 	return &BasketControllerImpl{
 		ShowBasketUseCase:         showBasketUseCase,
@@ -40,47 +50,73 @@ func NewBasketController(
 		AddProductUseCase:         addProductUseCase,
 		UpdateProductCountUseCase: updateProductCountUseCase,
 		RemoveProductUseCase:      removeProductUseCase,
+		ApplyCouponUseCase:        applyCouponUseCase,
+		RemoveCouponUseCase:       removeCouponUseCase,
 	}
 }
 
 func (controller *BasketControllerImpl) ShowBasket(c *gin.Context) {
-	userID := common.GetUserID()
+	userID, err := common.GetUserID(c)
+	if err != nil {
+		httperror.AbortUnauthorized(c, err.Error())
+		return
+	}
 
 	output, err := controller.ShowBasketUseCase.Execute(
+		c.Request.Context(),
 		&usecases.ShowBasketUseCaseInput{
 			UserID: userID,
 		},
 	)
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
-		})
+		httperror.Write(c, err)
 		return
 	}
 
-	c.JSON(200, output.UserBasket)
+	writeBasket(c, output.UserBasket, output)
 }
 
 func (controller *BasketControllerImpl) ClearBasket(c *gin.Context) {
-	userID := common.GetUserID()
+	userID, err := common.GetUserID(c)
+	if err != nil {
+		httperror.AbortUnauthorized(c, err.Error())
+		return
+	}
+
+	expectedVersion, err := etag.ParseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
 
 	output, err := controller.ClearBasketUseCase.Execute(
+		c.Request.Context(),
 		&usecases.ClearBasketUseCaseInput{
-			UserID: userID,
+			UserID:          userID,
+			ExpectedVersion: expectedVersion,
 		},
 	)
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
-		})
+		httperror.WriteConditional(c, err, expectedVersion != nil)
 		return
 	}
 
-	c.JSON(200, output.UserBasket)
+	writeBasket(c, output.UserBasket, output)
 }
 
 func (controller *BasketControllerImpl) AddProduct(c *gin.Context) {
-	userID := common.GetUserID()
+	userID, err := common.GetUserID(c)
+	if err != nil {
+		httperror.AbortUnauthorized(c, err.Error())
+		return
+	}
+
+	expectedVersion, err := etag.ParseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
 	productID := c.Param("productID")
 	count := c.Param("count")
 	if count == "" {
@@ -89,75 +125,162 @@ func (controller *BasketControllerImpl) AddProduct(c *gin.Context) {
 
 	countInteger, err := strconv.Atoi(count)
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
-		})
+		httperror.WriteBadRequest(c, fmt.Errorf("count %q is not a number", count))
 		return
 	}
 
 	output, err := controller.AddProductUseCase.Execute(
+		c.Request.Context(),
 		&usecases.AddProductUseCaseInput{
-			UserID:    userID,
-			ProductID: productID,
-			Count:     countInteger,
+			UserID:          userID,
+			ProductID:       productID,
+			Count:           countInteger,
+			ExpectedVersion: expectedVersion,
 		},
 	)
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
-		})
+		httperror.WriteConditional(c, err, expectedVersion != nil)
 		return
 	}
 
-	c.JSON(200, output)
+	writeBasket(c, output.UserBasket, output)
 }
 
 func (controller *BasketControllerImpl) UpdateProductCount(c *gin.Context) {
-	userID := common.GetUserID()
+	userID, err := common.GetUserID(c)
+	if err != nil {
+		httperror.AbortUnauthorized(c, err.Error())
+		return
+	}
+
+	expectedVersion, err := etag.ParseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
 	productID := c.Param("productID")
 	count := c.Param("count")
 
 	countInteger, err := strconv.Atoi(count)
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
-		})
+		httperror.WriteBadRequest(c, fmt.Errorf("count %q is not a number", count))
 		return
 	}
 
 	output, err := controller.UpdateProductCountUseCase.Execute(
+		c.Request.Context(),
 		&usecases.UpdateProductCountUseCaseInput{
-			UserID:    userID,
-			ProductID: productID,
-			Count:     countInteger,
+			UserID:          userID,
+			ProductID:       productID,
+			Count:           countInteger,
+			ExpectedVersion: expectedVersion,
 		},
 	)
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
-		})
+		httperror.WriteConditional(c, err, expectedVersion != nil)
 		return
 	}
 
-	c.JSON(200, output)
+	writeBasket(c, output.UserBasket, output)
 }
 
 func (controller *BasketControllerImpl) RemoveProduct(c *gin.Context) {
-	userID := common.GetUserID()
+	userID, err := common.GetUserID(c)
+	if err != nil {
+		httperror.AbortUnauthorized(c, err.Error())
+		return
+	}
+
+	expectedVersion, err := etag.ParseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
 	productID := c.Param("productID")
 
 	output, err := controller.RemoveProductUseCase.Execute(
+		c.Request.Context(),
 		&usecases.RemoveProductUseCaseInput{
-			UserID:    userID,
-			ProductID: productID,
+			UserID:          userID,
+			ProductID:       productID,
+			ExpectedVersion: expectedVersion,
 		},
 	)
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
-		})
+		httperror.WriteConditional(c, err, expectedVersion != nil)
 		return
 	}
 
-	c.JSON(200, output.UserBasket)
+	writeBasket(c, output.UserBasket, output)
+}
+
+func (controller *BasketControllerImpl) ApplyCoupon(c *gin.Context) {
+	userID, err := common.GetUserID(c)
+	if err != nil {
+		httperror.AbortUnauthorized(c, err.Error())
+		return
+	}
+
+	expectedVersion, err := etag.ParseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	code := c.Param("code")
+
+	output, err := controller.ApplyCouponUseCase.Execute(
+		c.Request.Context(),
+		&usecases.ApplyCouponUseCaseInput{
+			UserID:          userID,
+			Code:            code,
+			ExpectedVersion: expectedVersion,
+		},
+	)
+	if err != nil {
+		httperror.WriteConditional(c, err, expectedVersion != nil)
+		return
+	}
+
+	writeBasket(c, output.UserBasket, output)
+}
+
+func (controller *BasketControllerImpl) RemoveCoupon(c *gin.Context) {
+	userID, err := common.GetUserID(c)
+	if err != nil {
+		httperror.AbortUnauthorized(c, err.Error())
+		return
+	}
+
+	expectedVersion, err := etag.ParseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	code := c.Param("code")
+
+	output, err := controller.RemoveCouponUseCase.Execute(
+		c.Request.Context(),
+		&usecases.RemoveCouponUseCaseInput{
+			UserID:          userID,
+			Code:            code,
+			ExpectedVersion: expectedVersion,
+		},
+	)
+	if err != nil {
+		httperror.WriteConditional(c, err, expectedVersion != nil)
+		return
+	}
+
+	writeBasket(c, output.UserBasket, output)
+}
+
+// writeBasket sets the ETag header to the basket version, the client sends it back in the If-Match header
+// to change the basket only if nobody else changed it in the meantime
+func writeBasket(c *gin.Context, basket *dto.BasketDTO, body any) {
+	c.Header("ETag", etag.Format(basket.Version))
+	c.JSON(200, body)
 }
@@ -33,6 +33,8 @@ func (controllerRouter *BasketControllerRouterImpl) RegisterRoutes(router gin.IR
 	router.POST("/basket/:productID/:count", controllerRouter.basketController.AddProduct)
 	router.PATCH("/basket/:productID/:count", controllerRouter.basketController.UpdateProductCount)
 	router.DELETE("/basket/:productID", controllerRouter.basketController.RemoveProduct)
+	router.POST("/basket/coupons/:code", controllerRouter.basketController.ApplyCoupon)
+	router.DELETE("/basket/coupons/:code", controllerRouter.basketController.RemoveCoupon)
 
 	return nil
 }
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/adapters/auth"
+	identity "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+)
+
+var testActions = map[string]string{"price_changed_A1": "Price of product A1 changed from 9.99 EUR to 11.99 EUR."}
+
+type showBasketUseCaseStub struct{}
+
+func (useCase *showBasketUseCaseStub) Execute(ctx context.Context, input *usecases.ShowBasketUseCaseInput) (*usecases.ShowBasketUseCaseOutput, error) {
+	return &usecases.ShowBasketUseCaseOutput{UserBasket: &dto.BasketDTO{TotalItems: 2, Version: 3}, Actions: testActions}, nil
+}
+
+type clearBasketUseCaseStub struct{}
+
+func (useCase *clearBasketUseCaseStub) Execute(ctx context.Context, input *usecases.ClearBasketUseCaseInput) (*usecases.ClearBasketUseCaseOutput, error) {
+	return &usecases.ClearBasketUseCaseOutput{UserBasket: &dto.BasketDTO{TotalItems: 2, Version: 3}, Actions: testActions}, nil
+}
+
+type removeProductUseCaseStub struct{}
+
+func (useCase *removeProductUseCaseStub) Execute(ctx context.Context, input *usecases.RemoveProductUseCaseInput) (*usecases.RemoveProductUseCaseOutput, error) {
+	return &usecases.RemoveProductUseCaseOutput{UserBasket: &dto.BasketDTO{TotalItems: 2, Version: 3}, Actions: testActions}, nil
+}
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	basketController := NewBasketController(&showBasketUseCaseStub{}, &clearBasketUseCaseStub{}, nil, nil, &removeProductUseCaseStub{}, nil, nil)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		auth.SetIdentity(c, &identity.Identity{UserID: "1337"})
+	})
+	require.NoError(t, NewBasketControllerRouter(basketController).RegisterRoutes(router))
+
+	return router
+}
+
+func Test_BasketController_ReturnsActions(t *testing.T) {
+	testCases := map[string]struct {
+		method string
+		path   string
+	}{
+		"show basket": {
+			method: http.MethodGet,
+			path:   "/basket",
+		},
+		"clear basket": {
+			method: http.MethodDelete,
+			path:   "/basket",
+		},
+		"remove product": {
+			method: http.MethodDelete,
+			path:   "/basket/A2",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			newTestRouter(t).ServeHTTP(recorder, httptest.NewRequest(testCase.method, testCase.path, nil))
+
+			var body struct {
+				UserBasket *dto.BasketDTO
+				Actions    map[string]string
+			}
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+			require.Equal(t, 2, body.UserBasket.TotalItems)
+			require.Equal(t, testActions, body.Actions)
+			require.Equal(t, `"3"`, recorder.Header().Get("ETag"))
+		})
+	}
+}
@@ -21,9 +21,16 @@ func NewBasketController(showBasketUseCase usecases.ShowBasketUseCase) BasketCon
 }
 
 func (controller *BasketControllerImpl) ShowBasket(c *gin.Context) {
-	userID := common.GetUserID()
+	userID, err := common.GetUserID(c)
+	if err != nil {
+		c.HTML(401, "index.html", gin.H{
+			"unauthorized": true,
+		})
+		return
+	}
 
 	output, err := controller.ShowBasketUseCase.Execute(
+		c.Request.Context(),
 		&usecases.ShowBasketUseCaseInput{
 			UserID: userID,
 		},
@@ -36,6 +43,8 @@ func (controller *BasketControllerImpl) ShowBasket(c *gin.Context) {
 	}
 
 	c.HTML(200, "index.html", gin.H{
+		"userID":     userID,
+		"guest":      common.IsGuest(c),
 		"userBasket": output.UserBasket,
 	})
 }
@@ -19,23 +19,27 @@ var _ BasketControllerRouter = (*BasketControllerRouterImpl)(nil)
 
 type BasketControllerRouterImpl struct {
 	basketController BasketController
+	authenticator    gin.HandlerFunc
 }
 
-func NewBasketControllerRouter(basketController BasketController) BasketControllerRouter {
+func NewBasketControllerRouter(basketController BasketController, authenticator gin.HandlerFunc) BasketControllerRouter {
 	return &BasketControllerRouterImpl{
 		basketController: basketController,
+		authenticator:    authenticator,
 	}
 }
 
 func (controllerRouter *BasketControllerRouterImpl) RegisterRoutes(router *gin.Engine) error {
 	if router == nil {
 		return fmt.Errorf("router is nil")
+	} else if controllerRouter.authenticator == nil {
+		return fmt.Errorf("authenticator is nil")
 	}
 
 	templ := template.Must(template.New("").ParseFS(templatesFS, "templates/*.html"))
 	router.SetHTMLTemplate(templ)
 
-	router.GET("/", controllerRouter.basketController.ShowBasket)
+	router.GET("/", controllerRouter.authenticator, controllerRouter.basketController.ShowBasket)
 
 	return nil
 }
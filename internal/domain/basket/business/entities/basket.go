@@ -1,16 +1,34 @@
 package entities
 
-import "fmt"
-
+import (
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+const (
+	// BasketItemResource is the resource of the domainerror.NotFoundError returned if the basket does not contain a product
+	BasketItemResource = "basket item"
+	// BasketCouponResource is the resource of the domainerror.NotFoundError returned if the basket does not contain a coupon
+	BasketCouponResource = "basket coupon"
+)
+
+// Basket has explicit bson field names, because the mongodb driver queries and indexes them.
+// They are the lowercase field names used by the documents stored before the tags were added.
 type Basket struct {
-	Id     string
-	UserID string
-	Items  map[string]*BasketItem
+	Id     string                 `bson:"id"`
+	UserID string                 `bson:"userid"`
+	Items  map[string]*BasketItem `bson:"items"`
+	// Coupons contains the normalized coupon codes in the order they were applied
+	Coupons []string `bson:"coupons"`
+	// Version is incremented by every save, a basket which was not saved yet has version 0
+	Version int64 `bson:"version"`
 }
 
 type BasketItem struct {
-	ProductID string
-	Count     int
+	ProductID string `bson:"productid"`
+	Count     int    `bson:"count"`
+	// Price is the product price at the time the item was added or its count was updated
+	Price money.Money `bson:"price"`
 }
 
 func (basket *Basket) GetID() string {
@@ -21,6 +39,10 @@ func (basket *Basket) SetID(id string) {
 	basket.Id = id
 }
 
+func (basket *Basket) GetVersion() int64 {
+	return basket.Version
+}
+
 func (basket *Basket) GetUserID() string {
 	return basket.UserID
 }
@@ -37,7 +59,7 @@ func (basket *Basket) HasItem(productID string) bool {
 
 func (basket *Basket) GetItem(productID string) (*BasketItem, error) {
 	if !basket.HasItem(productID) {
-		return nil, fmt.Errorf("basket does not have item with id: %s", productID)
+		return nil, &domainerror.NotFoundError{Resource: BasketItemResource, ID: productID}
 	}
 
 	return basket.Items[productID], nil
@@ -61,7 +83,7 @@ func (basket *Basket) AddItem(productID string, count int) *BasketItem {
 
 func (basket *Basket) RemoveItem(productID string) error {
 	if !basket.HasItem(productID) {
-		return fmt.Errorf("basket does not have item with id: %s", productID)
+		return &domainerror.NotFoundError{Resource: BasketItemResource, ID: productID}
 	}
 
 	delete(basket.Items, productID)
@@ -69,10 +91,48 @@ func (basket *Basket) RemoveItem(productID string) error {
 	return nil
 }
 
+// Clear removes the items and the coupons
 func (basket *Basket) Clear() {
 	if len(basket.Items) > 0 {
 		basket.Items = map[string]*BasketItem{}
 	}
+	basket.Coupons = nil
+}
+
+func (basket *Basket) GetCoupons() []string {
+	return basket.Coupons
+}
+
+func (basket *Basket) HasCoupon(code string) bool {
+	for _, coupon := range basket.Coupons {
+		if coupon == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddCoupon returns false if the coupon was already added
+func (basket *Basket) AddCoupon(code string) bool {
+	if basket.HasCoupon(code) {
+		return false
+	}
+
+	basket.Coupons = append(basket.Coupons, code)
+
+	return true
+}
+
+func (basket *Basket) RemoveCoupon(code string) error {
+	for i, coupon := range basket.Coupons {
+		if coupon == code {
+			basket.Coupons = append(basket.Coupons[:i:i], basket.Coupons[i+1:]...)
+			return nil
+		}
+	}
+
+	return &domainerror.NotFoundError{Resource: BasketCouponResource, ID: code}
 }
 
 func (basketItem *BasketItem) GetProductID() string {
@@ -86,3 +146,16 @@ func (basketItem *BasketItem) GetCount() int {
 func (basketItem *BasketItem) SetCount(count int) {
 	basketItem.Count = count
 }
+
+func (basketItem *BasketItem) GetPrice() money.Money {
+	return basketItem.Price
+}
+
+// HasPrice returns false for items stored before the price was part of the basket item
+func (basketItem *BasketItem) HasPrice() bool {
+	return basketItem.Price.GetCurrency() != ""
+}
+
+func (basketItem *BasketItem) SetPrice(price money.Money) {
+	basketItem.Price = price
+}
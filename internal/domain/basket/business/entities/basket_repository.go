@@ -1,18 +1,31 @@
 package entities
 
+import (
+	"context"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
 //go:generate mockgen -source=basket_repository.go -destination=basket_repository_mock.go -package=entities
 
 type BasketRepository interface {
-	Find(id string) (*Basket, error)
-	FindByUserId(userId string) (*Basket, error) // special function
-	Save(basket *Basket) (string, error)
+	Find(ctx context.Context, id string) (*Basket, error)
+	FindByUserId(ctx context.Context, userId string) (*Basket, error) // special function
+	// Save inserts or replaces the basket with the same id and increments its version.
+	// It returns the error of NewVersionConflictError if the stored basket has another version than the given basket
+	// and the error of NewUserHasBasketError if the user already has another basket.
+	Save(ctx context.Context, basket *Basket) (string, error)
 }
 
-var _ error = (*BasketNotFoundError)(nil)
+// BasketResource is the resource of the domainerror.NotFoundError returned if a basket does not exist
+const BasketResource = "basket"
 
-type BasketNotFoundError struct {
+// NewVersionConflictError is returned by the repositories if the basket was saved by someone else after it was read
+func NewVersionConflictError(basket *Basket) *domainerror.VersionConflictError {
+	return &domainerror.VersionConflictError{Resource: BasketResource, ID: basket.GetID(), Version: basket.GetVersion()}
 }
 
-func (err *BasketNotFoundError) Error() string {
-	return "basket not found"
+// NewUserHasBasketError is returned by the repositories, because every user has exactly one basket
+func NewUserHasBasketError(userID string) *domainerror.ConflictError {
+	return domainerror.NewConflictError("user %s already has a basket", userID)
 }
@@ -10,6 +10,7 @@
 package entities
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,46 +41,46 @@ func (m *MockBasketRepository) EXPECT() *MockBasketRepositoryMockRecorder {
 }
 
 // Find mocks base method.
-func (m *MockBasketRepository) Find(id string) (*Basket, error) {
+func (m *MockBasketRepository) Find(ctx context.Context, id string) (*Basket, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Find", id)
+	ret := m.ctrl.Call(m, "Find", ctx, id)
 	ret0, _ := ret[0].(*Basket)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Find indicates an expected call of Find.
-func (mr *MockBasketRepositoryMockRecorder) Find(id any) *gomock.Call {
+func (mr *MockBasketRepositoryMockRecorder) Find(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockBasketRepository)(nil).Find), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockBasketRepository)(nil).Find), ctx, id)
 }
 
 // FindByUserId mocks base method.
-func (m *MockBasketRepository) FindByUserId(userId string) (*Basket, error) {
+func (m *MockBasketRepository) FindByUserId(ctx context.Context, userId string) (*Basket, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByUserId", userId)
+	ret := m.ctrl.Call(m, "FindByUserId", ctx, userId)
 	ret0, _ := ret[0].(*Basket)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByUserId indicates an expected call of FindByUserId.
-func (mr *MockBasketRepositoryMockRecorder) FindByUserId(userId any) *gomock.Call {
+func (mr *MockBasketRepositoryMockRecorder) FindByUserId(ctx, userId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserId", reflect.TypeOf((*MockBasketRepository)(nil).FindByUserId), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserId", reflect.TypeOf((*MockBasketRepository)(nil).FindByUserId), ctx, userId)
 }
 
 // Save mocks base method.
-func (m *MockBasketRepository) Save(basket *Basket) (string, error) {
+func (m *MockBasketRepository) Save(ctx context.Context, basket *Basket) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", basket)
+	ret := m.ctrl.Call(m, "Save", ctx, basket)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockBasketRepositoryMockRecorder) Save(basket any) *gomock.Call {
+func (mr *MockBasketRepositoryMockRecorder) Save(ctx, basket any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockBasketRepository)(nil).Save), basket)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockBasketRepository)(nil).Save), ctx, basket)
 }
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
 )
 
 func Test_Basket(t *testing.T) {
@@ -95,3 +97,37 @@ func Test_Basket_ClearBasket(t *testing.T) {
 	basket.Clear()
 	require.Equal(t, 0, len(basket.GetItems()))
 }
+
+func Test_Basket_ItemPrice(t *testing.T) {
+	factory := NewBasketFactory()
+	basket, err := factory.NewBasketWithID("1", "1337")
+
+	require.NoError(t, err)
+
+	basketItem := basket.AddItem("A12345", 1)
+	require.False(t, basketItem.HasPrice())
+
+	basketItem.SetPrice(money.New(1199, "EUR"))
+	require.True(t, basketItem.HasPrice())
+	require.Equal(t, money.New(1199, "EUR"), basketItem.GetPrice())
+}
+
+func Test_Basket_Coupons(t *testing.T) {
+	factory := NewBasketFactory()
+	basket, err := factory.NewBasketWithID("1", "1337")
+
+	require.NoError(t, err)
+	require.Empty(t, basket.GetCoupons())
+
+	require.True(t, basket.AddCoupon("TEN"))
+	require.True(t, basket.AddCoupon("FIVE"))
+	require.False(t, basket.AddCoupon("TEN"))
+	require.Equal(t, []string{"TEN", "FIVE"}, basket.GetCoupons())
+
+	require.NoError(t, basket.RemoveCoupon("TEN"))
+	require.Error(t, basket.RemoveCoupon("TEN"))
+	require.Equal(t, []string{"FIVE"}, basket.GetCoupons())
+
+	basket.Clear()
+	require.Empty(t, basket.GetCoupons())
+}
@@ -0,0 +1,18 @@
+package usecases
+
+// mergeActions adds the actions of the basket output service to the actions of the use case
+func mergeActions(actions map[string]string, outputActions map[string]string) map[string]string {
+	if len(outputActions) == 0 {
+		return actions
+	}
+
+	if actions == nil {
+		actions = map[string]string{}
+	}
+
+	for key, value := range outputActions {
+		actions[key] = value
+	}
+
+	return actions
+}
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -8,12 +9,16 @@ import (
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
 	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
 )
 
 type AddProductUseCaseInput struct {
 	UserID    string
 	ProductID string
 	Count     int
+	// ExpectedVersion is the basket version the client has seen, nil changes the current basket
+	ExpectedVersion *int64
 }
 
 type AddProductUseCaseOutput struct {
@@ -22,25 +27,27 @@ type AddProductUseCaseOutput struct {
 }
 
 type AddProductUseCase interface {
-	Execute(input *AddProductUseCaseInput) (*AddProductUseCaseOutput, error)
+	Execute(ctx context.Context, input *AddProductUseCaseInput) (*AddProductUseCaseOutput, error)
 }
 
-func NewAddProductUseCaseImpl(basketCreatorService helper.BasketCreatorService, basketOutputService helper.BasketOutputService, basketRepository entities.BasketRepository, productRepository warehouse.ProductRepository) AddProductUseCase {
+func NewAddProductUseCaseImpl(basketCreatorService helper.BasketCreatorService, basketOutputService helper.BasketOutputService, basketRepository entities.BasketRepository, productRepository warehouse.ProductRepository, stockReservationService warehousehelper.StockReservationService) AddProductUseCase {
 	return &AddProductUseCaseImpl{
-		basketCreatorService: basketCreatorService,
-		basketOutputService:  basketOutputService,
-		basketRepository:     basketRepository,
-		productRepository:    productRepository,
+		basketCreatorService:    basketCreatorService,
+		basketOutputService:     basketOutputService,
+		basketRepository:        basketRepository,
+		productRepository:       productRepository,
+		stockReservationService: stockReservationService,
 	}
 }
 
 var _ AddProductUseCase = (*AddProductUseCaseImpl)(nil)
 
 type AddProductUseCaseImpl struct {
-	basketCreatorService helper.BasketCreatorService
-	basketOutputService  helper.BasketOutputService
-	basketRepository     entities.BasketRepository
-	productRepository    warehouse.ProductRepository
+	basketCreatorService    helper.BasketCreatorService
+	basketOutputService     helper.BasketOutputService
+	basketRepository        entities.BasketRepository
+	productRepository       warehouse.ProductRepository
+	stockReservationService warehousehelper.StockReservationService
 }
 
 func (useCase *AddProductUseCaseImpl) validate(input *AddProductUseCaseInput) error {
@@ -57,63 +64,97 @@ func (useCase *AddProductUseCaseImpl) validate(input *AddProductUseCaseInput) er
 	return nil
 }
 
-func (useCase *AddProductUseCaseImpl) Execute(input *AddProductUseCaseInput) (*AddProductUseCaseOutput, error) {
+func (useCase *AddProductUseCaseImpl) Execute(ctx context.Context, input *AddProductUseCaseInput) (*AddProductUseCaseOutput, error) {
 	// validate input first
 	err := useCase.validate(input)
 	if err != nil {
-		return nil, fmt.Errorf("input validation error: %w", err)
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
 	}
 
-	userBasket, userBasketErr := useCase.basketCreatorService.FindOrCreate(input.UserID)
+	return retryOnVersionConflict(input.ExpectedVersion, func() (*AddProductUseCaseOutput, error) {
+		return useCase.execute(ctx, input)
+	})
+}
+
+// execute adds the product to the current basket
+func (useCase *AddProductUseCaseImpl) execute(ctx context.Context, input *AddProductUseCaseInput) (*AddProductUseCaseOutput, error) {
+	userBasket, userBasketErr := useCase.basketCreatorService.FindOrCreate(ctx, input.UserID)
 	if userBasketErr != nil {
-		return nil, err
+		return nil, userBasketErr
+	}
+
+	versionErr := checkExpectedVersion(userBasket, input.ExpectedVersion)
+	if versionErr != nil {
+		return nil, versionErr
 	}
 
 	log.Printf("add userBasket: %+v", userBasket)
 
-	product, productRepositoryErr := useCase.productRepository.Find(input.ProductID)
+	product, productRepositoryErr := useCase.productRepository.Find(ctx, input.ProductID)
 	if productRepositoryErr != nil {
 		return nil, productRepositoryErr
 	}
 
-	if product.Stock <= 0 {
-		return nil, fmt.Errorf("product %s is out of stock", input.ProductID)
+	if !product.IsActive() {
+		return nil, warehouse.NewProductDeactivatedError(product.ID)
 	}
 
-	var basketItem *entities.BasketItem
-	if !userBasket.HasItem(input.ProductID) {
-		// add the product with count = 0
-		// because of the following product stock check
-		// which will set the correct count
-		// related to the available stock
-		basketItem = userBasket.AddItem(input.ProductID, 0)
-	} else {
-		basketItem, _ = userBasket.GetItem(input.ProductID)
+	// the units in the baskets of other users are not available, the units of a product with stock policy can be backordered
+	sellableStock, sellableStockErr := useCase.stockReservationService.SellableStock(ctx, input.ProductID, input.UserID)
+	if sellableStockErr != nil {
+		return nil, sellableStockErr
 	}
 
+	if sellableStock <= 0 {
+		return nil, &domainerror.OutOfStockError{ProductID: input.ProductID, Requested: input.Count}
+	}
+
+	storedCount := 0
+	if userBasket.HasItem(input.ProductID) {
+		basketItem, _ := userBasket.GetItem(input.ProductID)
+		storedCount = basketItem.GetCount()
+	}
+
+	count := storedCount + input.Count
+
 	var actions map[string]string
-	if product.Stock < basketItem.GetCount()+input.Count {
-		basketItem.SetCount(product.Stock)
+	if sellableStock < count {
 		actions = map[string]string{
-			"product_stock": fmt.Sprintf("Product %s stock is too low to add %d. Updated basket item count to %d.", input.ProductID, input.Count, product.Stock),
+			"product_stock": fmt.Sprintf("Product %s stock is too low to add %d. Updated basket item count to %d.", input.ProductID, input.Count, sellableStock),
 		}
+		count = sellableStock
+	}
+
+	// reserve before changing the basket, so a failed reservation leaves the basket unchanged
+	reserveErr := useCase.stockReservationService.Reserve(ctx, input.UserID, input.ProductID, count)
+	if reserveErr != nil {
+		return nil, reserveErr
+	}
+
+	var basketItem *entities.BasketItem
+	if !userBasket.HasItem(input.ProductID) {
+		basketItem = userBasket.AddItem(input.ProductID, count)
 	} else {
-		basketItem.SetCount(basketItem.GetCount() + input.Count)
+		basketItem, _ = userBasket.GetItem(input.ProductID)
+		basketItem.SetCount(count)
 	}
 
-	_, basketRepositorySaveErr := useCase.basketRepository.Save(userBasket)
+	// the user accepted the current price by adding the product
+	basketItem.SetPrice(product.Price)
+
+	_, basketRepositorySaveErr := useCase.basketRepository.Save(ctx, userBasket)
 	if basketRepositorySaveErr != nil {
-		return nil, basketRepositorySaveErr
+		return nil, resetReservation(ctx, useCase.basketRepository, useCase.stockReservationService, input.UserID, input.ProductID, storedCount, basketRepositorySaveErr)
 	}
 
-	userBasketDTO, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(userBasket)
+	userBasketDTO, basketOutputActions, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(ctx, userBasket)
 	if basketOutputServiceErr != nil {
 		return nil, basketOutputServiceErr
 	}
 
 	output := &AddProductUseCaseOutput{
 		UserBasket: userBasketDTO,
-		Actions:    actions,
+		Actions:    mergeActions(actions, basketOutputActions),
 	}
 
 	return output, nil
@@ -1,14 +1,20 @@
 package usecases
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
 	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
 )
 
 func Test_AddProductToBasketUseCase_NewAddProductUseCaseImpl_ReturnsError(t *testing.T) {
@@ -44,11 +50,13 @@ func Test_AddProductToBasketUseCase_NewAddProductUseCaseImpl_ReturnsError(t *tes
 			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
 
 			basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
-			basketOutputService := helper.NewBasketOutputService(productRepositoryMock)
+			basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
 
-			useCase := NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock)
+			stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
 
-			_, err := useCase.Execute(testCase.input)
+			useCase := NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
 
 			require.Error(t, err)
 			require.ErrorContains(t, err, errorString)
@@ -72,10 +80,7 @@ func Test_AddProductToBasketUseCase(t *testing.T) {
 		ID:    product1ID,
 		Name:  product1Name,
 		Stock: 10,
-		Price: &warehouse.ProductPrice{
-			Value:    13.37,
-			Currency: "EUR",
-		},
+		Price: money.New(1337, "EUR"),
 	}
 
 	basketFactory := entities.NewBasketFactory()
@@ -86,18 +91,22 @@ func Test_AddProductToBasketUseCase(t *testing.T) {
 	userBasket, err := basketFactory.NewBasketWithID(basketID, userID)
 	require.NoError(t, err)
 
-	basketRepositoryMock.EXPECT().FindByUserId(userID).Return(userBasket, nil)
-	basketRepositoryMock.EXPECT().Save(userBasket).Return(basketID, nil)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+	basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return(basketID, nil)
 
 	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
-	// first the stock check in the usecase
+	// first the price lookup in the usecase
 	// second in the basket output service
-	productRepositoryMock.EXPECT().Find(product1ID).Return(product1, nil).Times(2)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1ID).Return(product1, nil).Times(2)
+
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), product1ID, userID).Return(product1.Stock, nil)
+	stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product1ID, 1).Return(nil)
 
 	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
-	basketOutputService := helper.NewBasketOutputService(productRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
 
-	useCase := NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock)
+	useCase := NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
 
 	input := &AddProductUseCaseInput{
 		UserID:    userID,
@@ -107,10 +116,303 @@ func Test_AddProductToBasketUseCase(t *testing.T) {
 
 	// act
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(t.Context(), input)
 
 	// assert
 
 	require.NoError(t, err)
 	require.NotNil(t, output)
+	require.Equal(t, product1.Price, userBasket.Items[product1ID].GetPrice())
+}
+
+func Test_AddProductToBasketUseCase_ReservedByOtherUsers(t *testing.T) {
+	// arrange
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+
+	product1 := &warehouse.Product{
+		ID:    "1",
+		Name:  "Product 1",
+		Stock: 10,
+		Price: money.New(1337, "EUR"),
+	}
+
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("12345", userID)
+	require.NoError(t, err)
+	userBasket.AddItem(product1.ID, 1)
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+	basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return(userBasket.GetID(), nil)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1.ID).Return(product1, nil).Times(2)
+
+	// other users reserved 7 of the 10 units
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), product1.ID, userID).Return(3, nil)
+	stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product1.ID, 3).Return(nil)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
+
+	// act
+
+	output, err := useCase.Execute(t.Context(), &AddProductUseCaseInput{UserID: userID, ProductID: product1.ID, Count: 5})
+
+	// assert
+
+	require.NoError(t, err)
+	require.Contains(t, output.Actions, "product_stock")
+	require.Equal(t, 3, userBasket.Items[product1.ID].GetCount())
+}
+
+func Test_AddProductToBasketUseCase_Backorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+	restockDate := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	product4 := &warehouse.Product{
+		ID:          "A12344",
+		Name:        "Product 4",
+		Stock:       2,
+		Price:       money.New(1499, "EUR"),
+		StockPolicy: warehouse.StockPolicy{Type: warehouse.StockPolicyTypeBackorder, RestockDate: restockDate, MaxBackorder: 10},
+	}
+
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("12345", userID)
+	require.NoError(t, err)
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+	basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return(userBasket.GetID(), nil)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product4.ID).Return(product4, nil).Times(2)
+
+	// 2 units are in stock, the other 10 units can be backordered
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), product4.ID, userID).Return(12, nil)
+	stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product4.ID, 5).Return(nil)
+	stockReservationServiceMock.EXPECT().AvailableStock(gomock.Any(), product4.ID, userID).Return(2, nil)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, stockReservationServiceMock, newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
+
+	// act
+
+	output, err := useCase.Execute(t.Context(), &AddProductUseCaseInput{UserID: userID, ProductID: product4.ID, Count: 5})
+
+	// assert
+
+	require.NoError(t, err)
+	require.NotContains(t, output.Actions, "product_stock")
+	require.Equal(t, "Product A12344 has 3 backordered units, they are shipped after the restock expected on 2026-12-01.", output.Actions[helper.BackorderActionPrefix+product4.ID])
+	require.Equal(t, 5, output.UserBasket.Items[0].Count)
+	require.Equal(t, &dto.Backorder{Type: "backorder", Count: 3, EstimatedDate: &restockDate}, output.UserBasket.Items[0].Backorder)
+}
+
+func Test_AddProductToBasketUseCase_ReserveFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+
+	product1 := &warehouse.Product{
+		ID:    "1",
+		Name:  "Product 1",
+		Stock: 10,
+		Price: money.New(1337, "EUR"),
+	}
+
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("12345", userID)
+	require.NoError(t, err)
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1.ID).Return(product1, nil)
+
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), product1.ID, userID).Return(10, nil)
+	stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product1.ID, 1).Return(&domainerror.OutOfStockError{ProductID: product1.ID, Available: 0, Requested: 1})
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &AddProductUseCaseInput{UserID: userID, ProductID: product1.ID, Count: 1})
+
+	require.ErrorAs(t, err, new(*domainerror.OutOfStockError))
+	require.Nil(t, output)
+	require.Empty(t, userBasket.GetItems())
+}
+
+func Test_AddProductToBasketUseCase_SaveConflictResetsReservation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+
+	product1 := &warehouse.Product{
+		ID:    "1",
+		Name:  "Product 1",
+		Stock: 10,
+		Price: money.New(1337, "EUR"),
+	}
+
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("12345", userID)
+	require.NoError(t, err)
+	userBasket.AddItem(product1.ID, 1)
+	userBasket.Version = 4
+
+	// the change with an expected version is not retried
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1.ID).Return(product1, nil)
+
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), product1.ID, userID).Return(10, nil)
+
+	// another request saved the basket with a count of 2 meanwhile
+	concurrentBasket, err := basketFactory.NewBasketWithID("12345", userID)
+	require.NoError(t, err)
+	concurrentBasket.AddItem(product1.ID, 2)
+	concurrentBasket.Version = 5
+
+	// the reservation is reset to the count of the basket stored now, not to the count read before
+	gomock.InOrder(
+		stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product1.ID, 3).Return(nil),
+		basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return("", entities.NewVersionConflictError(userBasket)),
+		basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(concurrentBasket, nil),
+		stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product1.ID, 2).Return(nil),
+	)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
+
+	expectedVersion := int64(4)
+	output, err := useCase.Execute(t.Context(), &AddProductUseCaseInput{UserID: userID, ProductID: product1.ID, Count: 2, ExpectedVersion: &expectedVersion})
+
+	require.True(t, domainerror.IsVersionConflict(err))
+	require.Nil(t, output)
+}
+
+func Test_AddProductToBasketUseCase_SaveConflictExhaustsRetries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+
+	product1 := &warehouse.Product{
+		ID:    "1",
+		Name:  "Product 1",
+		Stock: 10,
+		Price: money.New(1337, "EUR"),
+	}
+
+	basketFactory := entities.NewBasketFactory()
+
+	// another request adds a unit after every read, so every save of the use case conflicts
+	storedCount := 1
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).DoAndReturn(func(ctx context.Context, userID string) (*entities.Basket, error) {
+		storedBasket, storedBasketErr := basketFactory.NewBasketWithID("12345", userID)
+		require.NoError(t, storedBasketErr)
+		storedBasket.AddItem(product1.ID, storedCount)
+
+		return storedBasket, nil
+	}).Times(2 * maxVersionConflictAttempts)
+	basketRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, basket *entities.Basket) (string, error) {
+		storedCount++
+
+		return "", entities.NewVersionConflictError(basket)
+	}).Times(maxVersionConflictAttempts)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1.ID).Return(product1, nil).Times(maxVersionConflictAttempts)
+
+	var reservedCounts []int
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), product1.ID, userID).Return(10, nil).Times(maxVersionConflictAttempts)
+	stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product1.ID, gomock.Any()).DoAndReturn(func(ctx context.Context, userID string, productID string, count int) error {
+		reservedCounts = append(reservedCounts, count)
+
+		return nil
+	}).Times(2 * maxVersionConflictAttempts)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &AddProductUseCaseInput{UserID: userID, ProductID: product1.ID, Count: 2})
+
+	require.True(t, domainerror.IsVersionConflict(err))
+	require.Nil(t, output)
+	// every attempt resets the reservation to the count stored by the other request, so the last reservation matches the stored basket
+	require.Equal(t, []int{3, 2, 4, 3, 5, 4}, reservedCounts)
+	require.Equal(t, 4, storedCount)
+}
+
+func Test_AddProductToBasketUseCase_ProductDeactivated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+
+	product1 := &warehouse.Product{
+		ID:          "1",
+		Name:        "Product 1",
+		Stock:       10,
+		Price:       money.New(1337, "EUR"),
+		Deactivated: true,
+	}
+
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("12345", userID)
+	require.NoError(t, err)
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1.ID).Return(product1, nil)
+
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewAddProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &AddProductUseCaseInput{UserID: userID, ProductID: product1.ID, Count: 1})
+
+	require.ErrorAs(t, err, new(*domainerror.ConflictError))
+	require.Nil(t, output)
+	require.Empty(t, userBasket.GetItems())
 }
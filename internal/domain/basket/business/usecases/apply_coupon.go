@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
+	promotion "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type ApplyCouponUseCaseInput struct {
+	UserID string
+	Code   string
+	// ExpectedVersion is the basket version the client has seen, nil changes the current basket
+	ExpectedVersion *int64
+}
+
+type ApplyCouponUseCaseOutput struct {
+	UserBasket *dto.BasketDTO
+	Actions    map[string]string
+}
+
+// ApplyCouponUseCase stores an existing coupon code on the basket,
+// the basket output decides if the coupon can be applied to the current items
+type ApplyCouponUseCase interface {
+	Execute(ctx context.Context, input *ApplyCouponUseCaseInput) (*ApplyCouponUseCaseOutput, error)
+}
+
+func NewApplyCouponUseCaseImpl(basketCreatorService helper.BasketCreatorService, basketOutputService helper.BasketOutputService, basketRepository entities.BasketRepository, promotionRepository promotion.PromotionRepository) ApplyCouponUseCase {
+	return &ApplyCouponUseCaseImpl{
+		basketCreatorService: basketCreatorService,
+		basketOutputService:  basketOutputService,
+		basketRepository:     basketRepository,
+		promotionRepository:  promotionRepository,
+	}
+}
+
+var _ ApplyCouponUseCase = (*ApplyCouponUseCaseImpl)(nil)
+
+type ApplyCouponUseCaseImpl struct {
+	basketCreatorService helper.BasketCreatorService
+	basketOutputService  helper.BasketOutputService
+	basketRepository     entities.BasketRepository
+	promotionRepository  promotion.PromotionRepository
+}
+
+func (useCase *ApplyCouponUseCaseImpl) validate(input *ApplyCouponUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.UserID == "" {
+		return fmt.Errorf("input parameter UserID is empty")
+	} else if promotion.NormalizeCode(input.Code) == "" {
+		return fmt.Errorf("input parameter Code is empty")
+	}
+
+	return nil
+}
+
+func (useCase *ApplyCouponUseCaseImpl) Execute(ctx context.Context, input *ApplyCouponUseCaseInput) (*ApplyCouponUseCaseOutput, error) {
+	// validate input first
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	return retryOnVersionConflict(input.ExpectedVersion, func() (*ApplyCouponUseCaseOutput, error) {
+		return useCase.execute(ctx, input)
+	})
+}
+
+// execute adds the coupon to the current basket
+func (useCase *ApplyCouponUseCaseImpl) execute(ctx context.Context, input *ApplyCouponUseCaseInput) (*ApplyCouponUseCaseOutput, error) {
+	code := promotion.NormalizeCode(input.Code)
+
+	_, promotionRepositoryErr := useCase.promotionRepository.FindByCode(ctx, code)
+	if promotionRepositoryErr != nil {
+		return nil, promotionRepositoryErr
+	}
+
+	userBasket, userBasketErr := useCase.basketCreatorService.FindOrCreate(ctx, input.UserID)
+	if userBasketErr != nil {
+		return nil, userBasketErr
+	}
+
+	versionErr := checkExpectedVersion(userBasket, input.ExpectedVersion)
+	if versionErr != nil {
+		return nil, versionErr
+	}
+
+	actions := map[string]string{}
+	if userBasket.AddCoupon(code) {
+		_, basketRepositorySaveErr := useCase.basketRepository.Save(ctx, userBasket)
+		if basketRepositorySaveErr != nil {
+			return nil, basketRepositorySaveErr
+		}
+	} else {
+		actions["coupon_already_applied"] = fmt.Sprintf("Coupon %s was already added to the basket.", code)
+	}
+
+	userBasketDTO, basketOutputActions, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(ctx, userBasket)
+	if basketOutputServiceErr != nil {
+		return nil, basketOutputServiceErr
+	}
+
+	output := &ApplyCouponUseCaseOutput{
+		UserBasket: userBasketDTO,
+		Actions:    mergeActions(actions, basketOutputActions),
+	}
+
+	return output, nil
+}
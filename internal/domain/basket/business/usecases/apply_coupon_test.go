@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
+	promotion "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	promotionhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/usecases/helper"
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	taxhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/usecases/helper"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+// newTestPromotionEngine returns a promotion engine without any promotions
+func newTestPromotionEngine(ctrl *gomock.Controller) promotionhelper.PromotionEngine {
+	return promotionhelper.NewPromotionEngine(promotion.NewMockPromotionRepository(ctrl))
+}
+
+// newTestTaxCalculationService returns a tax calculation service with the german standard rate
+func newTestTaxCalculationService(t *testing.T) taxhelper.TaxCalculationService {
+	taxCalculationService, err := taxhelper.NewTaxCalculationService("DE", []*tax.TaxRate{
+		{Country: "DE", Class: tax.TaxClassStandard, BasisPoints: 1900},
+	})
+	require.NoError(t, err)
+
+	return taxCalculationService
+}
+
+func Test_ApplyCouponUseCase_NewApplyCouponUseCaseImpl_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *ApplyCouponUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"UserID is empty": {
+			input: &ApplyCouponUseCaseInput{},
+		},
+		"Code is empty": {
+			input: &ApplyCouponUseCaseInput{
+				UserID: "1337",
+				Code:   " ",
+			},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			basketFactory := entities.NewBasketFactory()
+			basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+
+			basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+			basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+			useCase := NewApplyCouponUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, promotion.NewMockPromotionRepository(ctrl))
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.Error(t, err)
+			require.ErrorContains(t, err, errorString)
+		})
+	}
+}
+
+func Test_ApplyCouponUseCase(t *testing.T) {
+	// arrange
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+
+	product1 := &warehouse.Product{
+		ID:    "1",
+		Name:  "Product 1",
+		Stock: 10,
+		Price: money.New(1000, "EUR"),
+	}
+
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("12345", userID)
+	require.NoError(t, err)
+	userBasket.AddItem(product1.ID, 2)
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+	basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return(userBasket.GetID(), nil)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1.ID).Return(product1, nil)
+
+	tenPercent := &promotion.Promotion{
+		Code:       "TEN",
+		Type:       promotion.PromotionTypePercentage,
+		Percentage: 10,
+	}
+
+	promotionRepositoryMock := promotion.NewMockPromotionRepository(ctrl)
+	// first the existence check in the usecase
+	// second in the promotion engine of the basket output service
+	promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "TEN").Return(tenPercent, nil).Times(2)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), promotionhelper.NewPromotionEngine(promotionRepositoryMock), newTestTaxCalculationService(t))
+
+	useCase := NewApplyCouponUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, promotionRepositoryMock)
+
+	// act
+
+	output, err := useCase.Execute(t.Context(), &ApplyCouponUseCaseInput{UserID: userID, Code: "ten"})
+
+	// assert
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"TEN"}, userBasket.GetCoupons())
+	require.Equal(t, []string{"TEN"}, output.UserBasket.Coupons)
+	require.Len(t, output.UserBasket.Discounts, 1)
+	require.Equal(t, "2.00", output.UserBasket.Discounts[0].Amount.Value)
+	require.Equal(t, "18.00", output.UserBasket.Totals[0].Value)
+}
+
+func Test_ApplyCouponUseCase_UnknownCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	basketFactory := entities.NewBasketFactory()
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+
+	promotionRepositoryMock := promotion.NewMockPromotionRepository(ctrl)
+	promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "UNKNOWN").Return(nil, &domainerror.NotFoundError{Resource: promotion.PromotionResource, ID: "UNKNOWN"})
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewApplyCouponUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, promotionRepositoryMock)
+
+	output, err := useCase.Execute(t.Context(), &ApplyCouponUseCaseInput{UserID: "1337", Code: "unknown"})
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+	require.Nil(t, output)
+}
@@ -1,41 +1,49 @@
 package usecases
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
 )
 
 type ClearBasketUseCaseInput struct {
 	UserID    string
 	ProductID string
 	Count     int
+	// ExpectedVersion is the basket version the client has seen, nil changes the current basket
+	ExpectedVersion *int64
 }
 
 type ClearBasketUseCaseOutput struct {
 	UserBasket *dto.BasketDTO
+	Actions    map[string]string
 }
 
 type ClearBasketUseCase interface {
-	Execute(input *ClearBasketUseCaseInput) (*ClearBasketUseCaseOutput, error)
+	Execute(ctx context.Context, input *ClearBasketUseCaseInput) (*ClearBasketUseCaseOutput, error)
 }
 
-func NewClearBasketUseCaseImpl(basketService helper.BasketCreatorService, basketOutputService helper.BasketOutputService, basketRepository entities.BasketRepository) ClearBasketUseCase {
+func NewClearBasketUseCaseImpl(basketService helper.BasketCreatorService, basketOutputService helper.BasketOutputService, basketRepository entities.BasketRepository, stockReservationService warehousehelper.StockReservationService) ClearBasketUseCase {
 	return &ClearBasketUseCaseImpl{
-		basketService:       basketService,
-		basketOutputService: basketOutputService,
-		basketRepository:    basketRepository,
+		basketService:           basketService,
+		basketOutputService:     basketOutputService,
+		basketRepository:        basketRepository,
+		stockReservationService: stockReservationService,
 	}
 }
 
 var _ ClearBasketUseCase = (*ClearBasketUseCaseImpl)(nil)
 
 type ClearBasketUseCaseImpl struct {
-	basketService       helper.BasketCreatorService
-	basketOutputService helper.BasketOutputService
-	basketRepository    entities.BasketRepository
+	basketService           helper.BasketCreatorService
+	basketOutputService     helper.BasketOutputService
+	basketRepository        entities.BasketRepository
+	stockReservationService warehousehelper.StockReservationService
 }
 
 func (useCase *ClearBasketUseCaseImpl) validate(input *ClearBasketUseCaseInput) error {
@@ -48,31 +56,49 @@ func (useCase *ClearBasketUseCaseImpl) validate(input *ClearBasketUseCaseInput)
 	return nil
 }
 
-func (useCase *ClearBasketUseCaseImpl) Execute(input *ClearBasketUseCaseInput) (*ClearBasketUseCaseOutput, error) {
+func (useCase *ClearBasketUseCaseImpl) Execute(ctx context.Context, input *ClearBasketUseCaseInput) (*ClearBasketUseCaseOutput, error) {
 	err := useCase.validate(input)
 	if err != nil {
-		return nil, fmt.Errorf("input validation error: %w", err)
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
 	}
 
-	userBasket, userBasketErr := useCase.basketService.FindOrCreate(input.UserID)
+	return retryOnVersionConflict(input.ExpectedVersion, func() (*ClearBasketUseCaseOutput, error) {
+		return useCase.execute(ctx, input)
+	})
+}
+
+// execute removes all items and coupons from the current basket
+func (useCase *ClearBasketUseCaseImpl) execute(ctx context.Context, input *ClearBasketUseCaseInput) (*ClearBasketUseCaseOutput, error) {
+	userBasket, userBasketErr := useCase.basketService.FindOrCreate(ctx, input.UserID)
 	if userBasketErr != nil {
-		return nil, err
+		return nil, userBasketErr
+	}
+
+	versionErr := checkExpectedVersion(userBasket, input.ExpectedVersion)
+	if versionErr != nil {
+		return nil, versionErr
 	}
 
 	userBasket.Clear()
 
-	_, basketRepositorySaveErr := useCase.basketRepository.Save(userBasket)
+	_, basketRepositorySaveErr := useCase.basketRepository.Save(ctx, userBasket)
 	if basketRepositorySaveErr != nil {
 		return nil, basketRepositorySaveErr
 	}
 
-	userBasketDTO, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(userBasket)
+	releaseErr := useCase.stockReservationService.ReleaseAll(ctx, input.UserID)
+	if releaseErr != nil {
+		return nil, releaseErr
+	}
+
+	userBasketDTO, basketOutputActions, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(ctx, userBasket)
 	if basketOutputServiceErr != nil {
 		return nil, basketOutputServiceErr
 	}
 
 	output := &ClearBasketUseCaseOutput{
 		UserBasket: userBasketDTO,
+		Actions:    mergeActions(nil, basketOutputActions),
 	}
 
 	return output, nil
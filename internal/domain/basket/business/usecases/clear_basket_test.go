@@ -9,6 +9,8 @@ import (
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
 	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
 )
 
 func Test_ClearBasketUseCase_NewClearBasketUseCaseImpl_ReturnsError(t *testing.T) {
@@ -33,11 +35,13 @@ func Test_ClearBasketUseCase_NewClearBasketUseCaseImpl_ReturnsError(t *testing.T
 			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
 
 			basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
-			basketOutputService := helper.NewBasketOutputService(productRepositoryMock)
+			basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
 
-			useCase := NewClearBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock)
+			stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
 
-			_, err := useCase.Execute(testCase.input)
+			useCase := NewClearBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, stockReservationServiceMock)
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
 
 			require.Error(t, err)
 			require.ErrorContains(t, err, errorString)
@@ -66,16 +70,19 @@ func Test_ClearBasketUseCase(t *testing.T) {
 
 	userBasket.AddItem(product1ID, 1)
 
-	basketRepositoryMock.EXPECT().FindByUserId(userID).Return(userBasket, nil)
-	basketRepositoryMock.EXPECT().Save(userBasket).Return(basketID, nil)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+	basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return(basketID, nil)
 
 	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
 
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().ReleaseAll(gomock.Any(), userID).Return(nil)
+
 	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
 
-	basketOutputService := helper.NewBasketOutputService(productRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
 
-	useCase := NewClearBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock)
+	useCase := NewClearBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, stockReservationServiceMock)
 
 	input := &ClearBasketUseCaseInput{
 		UserID: userID,
@@ -83,10 +90,124 @@ func Test_ClearBasketUseCase(t *testing.T) {
 
 	// act
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(t.Context(), input)
 
 	// assert
 
 	require.NoError(t, err)
 	require.NotNil(t, output)
 }
+
+func Test_ClearBasketUseCase_RetriesVersionConflict(t *testing.T) {
+	testCases := map[string]struct {
+		saveErrs []error
+		err      string
+	}{
+		"second attempt succeeds": {
+			saveErrs: []error{&domainerror.VersionConflictError{Resource: entities.BasketResource, ID: "12345", Version: 1}, nil},
+		},
+		"basket 12345 was modified concurrently, version 1 is outdated": {
+			saveErrs: []error{
+				&domainerror.VersionConflictError{Resource: entities.BasketResource, ID: "12345", Version: 1},
+				&domainerror.VersionConflictError{Resource: entities.BasketResource, ID: "12345", Version: 1},
+				&domainerror.VersionConflictError{Resource: entities.BasketResource, ID: "12345", Version: 1},
+			},
+			err: "basket 12345 was modified concurrently, version 1 is outdated",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			basketFactory := entities.NewBasketFactory()
+			basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+
+			// every attempt reads the basket again
+			for _, saveErr := range testCase.saveErrs {
+				userBasket, err := basketFactory.NewBasketWithID("12345", "1337")
+				require.NoError(t, err)
+
+				userBasket.Version = 1
+				userBasket.AddItem("1", 1)
+
+				basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(userBasket, nil)
+				basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return("12345", saveErr)
+			}
+
+			stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+			stockReservationServiceMock.EXPECT().ReleaseAll(gomock.Any(), "1337").Return(nil).MaxTimes(1)
+
+			basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+			basketOutputService := helper.NewBasketOutputService(warehouse.NewMockProductRepository(ctrl), warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+			useCase := NewClearBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, stockReservationServiceMock)
+
+			output, err := useCase.Execute(t.Context(), &ClearBasketUseCaseInput{UserID: "1337"})
+
+			if testCase.err == "" {
+				require.NoError(t, err)
+				require.NotNil(t, output)
+			} else {
+				require.EqualError(t, err, testCase.err)
+				require.True(t, domainerror.IsVersionConflict(err))
+			}
+		})
+	}
+}
+
+func Test_ClearBasketUseCase_ExpectedVersion(t *testing.T) {
+	testCases := map[string]struct {
+		expectedVersion int64
+		err             string
+	}{
+		"current version": {
+			expectedVersion: 2,
+		},
+		"basket 12345 was modified concurrently, version 1 is outdated": {
+			expectedVersion: 1,
+			err:             "basket 12345 was modified concurrently, version 1 is outdated",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			basketFactory := entities.NewBasketFactory()
+
+			userBasket, err := basketFactory.NewBasketWithID("12345", "1337")
+			require.NoError(t, err)
+
+			userBasket.Version = 2
+
+			basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+			basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(userBasket, nil)
+
+			stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+
+			if testCase.err == "" {
+				basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return("12345", nil)
+				stockReservationServiceMock.EXPECT().ReleaseAll(gomock.Any(), "1337").Return(nil)
+			}
+
+			basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+			basketOutputService := helper.NewBasketOutputService(warehouse.NewMockProductRepository(ctrl), warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+			useCase := NewClearBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, stockReservationServiceMock)
+
+			// a request with an expected version is not retried, the basket is read only once
+			output, err := useCase.Execute(t.Context(), &ClearBasketUseCaseInput{UserID: "1337", ExpectedVersion: &testCase.expectedVersion})
+
+			if testCase.err == "" {
+				require.NoError(t, err)
+				require.Equal(t, int64(2), output.UserBasket.Version)
+			} else {
+				require.EqualError(t, err, testCase.err)
+				require.True(t, domainerror.IsVersionConflict(err))
+			}
+		})
+	}
+}
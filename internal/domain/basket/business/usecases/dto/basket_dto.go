@@ -1,12 +1,50 @@
 package dto
 
+import "time"
+
 type BasketDTO struct {
 	Items []*BasketItem
+	// Coupons contains the codes of all coupons, also of the coupons which could not be applied
+	Coupons []string
+	// Discounts contains the discounts of the applied coupons
+	Discounts []*Discount
+	// Totals contains one total per currency after the discounts, sorted by currency
+	Totals []*ProductPrice
+	// TaxCountry is the country of the tax rates
+	TaxCountry string
+	// TaxTotals contains the net, tax and gross amounts per currency after the discounts, sorted by currency
+	TaxTotals  []*TaxTotal
+	TotalItems int
+	// Version is the version of the basket, it is sent back by the client to change exactly this basket
+	Version int64
+}
+
+type Discount struct {
+	Code   string
+	Reason string
+	Amount *ProductPrice
 }
 
 type BasketItem struct {
-	Product *Product
-	Count   int
+	Product  *Product
+	Count    int
+	Subtotal *ProductPrice
+	// PreviousPrice is the price at the time the item was added, but only if the price changed since then
+	PreviousPrice *ProductPrice
+	// Tax splits the subtotal into net and tax
+	Tax *Tax
+	// Backorder is set if units of the item are not shipped right away
+	Backorder *Backorder
+}
+
+// Backorder contains the units of a basket item which are shipped later
+type Backorder struct {
+	// Type is backorder or preorder, all units of a pre-order are shipped on the release date
+	Type string
+	// Count is the number of units which are not in stock
+	Count int
+	// EstimatedDate is the expected restock date or the release date, it is nil if the date is unknown
+	EstimatedDate *time.Time
 }
 
 type Product struct {
@@ -19,3 +57,20 @@ type ProductPrice struct {
 	Value    string
 	Currency string
 }
+
+// Tax splits a gross amount of one tax class into net and tax
+type Tax struct {
+	Class string
+	Rate  string
+	Net   *ProductPrice
+	Tax   *ProductPrice
+	Gross *ProductPrice
+}
+
+type TaxTotal struct {
+	Net   *ProductPrice
+	Tax   *ProductPrice
+	Gross *ProductPrice
+	// Classes contains the amounts per tax class, sorted by class
+	Classes []*Tax
+}
@@ -1,14 +1,16 @@
 package helper
 
 import (
+	"context"
 	"errors"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
 )
 
 // BasketCreatorService used for special business logic to prevent duplicate code
 type BasketCreatorService interface {
-	FindOrCreate(userID string) (*entities.Basket, error)
+	FindOrCreate(ctx context.Context, userID string) (*entities.Basket, error)
 }
 
 var _ BasketCreatorService = (*BasketCreatorServiceImpl)(nil)
@@ -26,20 +28,25 @@ func NewBasketCreatorServiceImpl(basketFactory entities.BasketFactory, basketRep
 }
 
 // FindOrCreate will create a new basket if a basket could not be found for the given userID
-func (service *BasketCreatorServiceImpl) FindOrCreate(userID string) (*entities.Basket, error) {
-	userBasket, basketRepositoryErr := service.basketRepository.FindByUserId(userID)
+func (service *BasketCreatorServiceImpl) FindOrCreate(ctx context.Context, userID string) (*entities.Basket, error) {
+	userBasket, basketRepositoryErr := service.basketRepository.FindByUserId(ctx, userID)
 	if basketRepositoryErr != nil {
 		// if the user has no basket yet, create it
-		if errors.Is(basketRepositoryErr, &entities.BasketNotFoundError{}) {
+		if domainerror.IsNotFound(basketRepositoryErr, entities.BasketResource) {
 			basket, newBasketErr := service.basketFactory.NewBasket(userID)
 			if newBasketErr != nil {
 				return nil, newBasketErr
 			}
-			userBasketID, saveBasketErr := service.basketRepository.Save(basket)
+			userBasketID, saveBasketErr := service.basketRepository.Save(ctx, basket)
 			if saveBasketErr != nil {
+				// a concurrent request created the basket of the user in the meantime
+				var conflictErr *domainerror.ConflictError
+				if errors.As(saveBasketErr, &conflictErr) {
+					return service.basketRepository.FindByUserId(ctx, userID)
+				}
 				return nil, saveBasketErr
 			}
-			userBasket, basketRepositoryErr = service.basketRepository.Find(userBasketID)
+			userBasket, basketRepositoryErr = service.basketRepository.Find(ctx, userBasketID)
 			if basketRepositoryErr != nil {
 				return nil, basketRepositoryErr
 			}
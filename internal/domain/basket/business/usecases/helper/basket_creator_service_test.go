@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+func Test_BasketCreatorService_FindOrCreate_CreatesBasket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userBasket := &entities.Basket{Id: "1", UserID: "1337", Items: map[string]*entities.BasketItem{}}
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(nil, &domainerror.NotFoundError{Resource: entities.BasketResource})
+	basketRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return("1", nil)
+	basketRepositoryMock.EXPECT().Find(gomock.Any(), "1").Return(userBasket, nil)
+
+	service := NewBasketCreatorServiceImpl(entities.NewBasketFactory(), basketRepositoryMock)
+
+	basket, err := service.FindOrCreate(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, userBasket, basket)
+}
+
+func Test_BasketCreatorService_FindOrCreate_ConcurrentlyCreatedBasket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userBasket := &entities.Basket{Id: "1", UserID: "1337", Items: map[string]*entities.BasketItem{}}
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	gomock.InOrder(
+		basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(nil, &domainerror.NotFoundError{Resource: entities.BasketResource}),
+		// another request saved the basket of the user between FindByUserId and Save
+		basketRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return("", entities.NewUserHasBasketError("1337")),
+		basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(userBasket, nil),
+	)
+
+	service := NewBasketCreatorServiceImpl(entities.NewBasketFactory(), basketRepositoryMock)
+
+	basket, err := service.FindOrCreate(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, userBasket, basket)
+}
@@ -1,38 +1,69 @@
 package helper
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
+	promotion "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	promotionhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/usecases/helper"
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	taxhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/usecases/helper"
 	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+const (
+	PriceChangedActionPrefix   = "price_changed_"
+	CouponRejectedActionPrefix = "coupon_"
+	BackorderActionPrefix      = "backorder_"
 )
 
 type BasketOutputService interface {
-	CreateBasketDTO(basket *entities.Basket) (*dto.BasketDTO, error)
+	// CreateBasketDTO returns the basket with current product data and the actions the user should be informed about
+	CreateBasketDTO(ctx context.Context, basket *entities.Basket) (*dto.BasketDTO, map[string]string, error)
 }
 
 var _ BasketOutputService = (*BasketOutputServiceImpl)(nil)
 
 type BasketOutputServiceImpl struct {
-	productRepository warehouse.ProductRepository
+	productRepository       warehouse.ProductRepository
+	stockReservationService warehousehelper.StockReservationService
+	promotionEngine         promotionhelper.PromotionEngine
+	taxCalculationService   taxhelper.TaxCalculationService
 }
 
-func NewBasketOutputService(productRepository warehouse.ProductRepository) BasketOutputService {
+func NewBasketOutputService(productRepository warehouse.ProductRepository, stockReservationService warehousehelper.StockReservationService, promotionEngine promotionhelper.PromotionEngine, taxCalculationService taxhelper.TaxCalculationService) BasketOutputService {
 	return &BasketOutputServiceImpl{
-		productRepository: productRepository,
+		productRepository:       productRepository,
+		stockReservationService: stockReservationService,
+		promotionEngine:         promotionEngine,
+		taxCalculationService:   taxCalculationService,
 	}
 }
 
-func (service *BasketOutputServiceImpl) CreateBasketDTO(basket *entities.Basket) (*dto.BasketDTO, error) {
+func (service *BasketOutputServiceImpl) CreateBasketDTO(ctx context.Context, basket *entities.Basket) (*dto.BasketDTO, map[string]string, error) {
 	if basket == nil {
-		return nil, fmt.Errorf("basket is nil")
+		return nil, nil, fmt.Errorf("basket is nil")
 	}
 
 	basketDTO := &dto.BasketDTO{
-		Items: []*dto.BasketItem{},
+		Items:      []*dto.BasketItem{},
+		Coupons:    []string{},
+		Discounts:  []*dto.Discount{},
+		Totals:     []*dto.ProductPrice{},
+		TaxCountry: service.taxCalculationService.GetCountry(),
+		Version:    basket.GetVersion(),
+		TaxTotals:  []*dto.TaxTotal{},
 	}
 
+	promotionItems := make([]*promotion.PromotionItem, 0, len(basket.GetItems()))
+	taxableAmounts := make([]*taxhelper.TaxableAmount, 0, len(basket.GetItems()))
+	actions := map[string]string{}
+
 	// order guarantee
 	basketItemsKeys := make([]string, 0)
 	for k := range basket.GetItems() {
@@ -43,27 +74,174 @@ func (service *BasketOutputServiceImpl) CreateBasketDTO(basket *entities.Basket)
 	for _, productId := range basketItemsKeys {
 		item, _ := basket.GetItem(productId)
 
-		product, productRepositoryErr := service.productRepository.Find(item.GetProductID())
+		product, productRepositoryErr := service.productRepository.Find(ctx, item.GetProductID())
 		if productRepositoryErr != nil {
-			return nil, productRepositoryErr
+			return nil, nil, productRepositoryErr
 		}
 
 		basketProduct := &dto.Product{
-			ID:   product.ID,
-			Name: product.Name,
-			Price: &dto.ProductPrice{
-				Value:    fmt.Sprintf("%.2f", product.Price.Value),
-				Currency: product.Price.Currency,
-			},
+			ID:    product.ID,
+			Name:  product.Name,
+			Price: newProductPriceDTO(product.Price),
+		}
+
+		subtotal, subtotalErr := product.Price.Multiply(int64(item.GetCount()))
+		if subtotalErr != nil {
+			return nil, nil, subtotalErr
+		}
+
+		promotionItems = append(promotionItems, &promotion.PromotionItem{
+			ProductID: product.ID,
+			Count:     item.GetCount(),
+			Price:     product.Price,
+		})
+
+		itemTax, itemTaxErr := service.taxCalculationService.Calculate(product.TaxClass, subtotal)
+		if itemTaxErr != nil {
+			return nil, nil, itemTaxErr
 		}
 
+		taxableAmounts = append(taxableAmounts, &taxhelper.TaxableAmount{
+			ProductID: product.ID,
+			Class:     product.TaxClass,
+			Gross:     subtotal,
+		})
+
 		basketItem := &dto.BasketItem{
-			Product: basketProduct,
-			Count:   item.GetCount(),
+			Product:  basketProduct,
+			Count:    item.GetCount(),
+			Subtotal: newProductPriceDTO(subtotal),
+			Tax:      newTaxDTO(itemTax),
+		}
+
+		// the product price changed since the item was put into the basket
+		if item.HasPrice() && !item.GetPrice().Equal(product.Price) {
+			basketItem.PreviousPrice = newProductPriceDTO(item.GetPrice())
+			actions[PriceChangedActionPrefix+product.ID] = fmt.Sprintf("Product %s price changed from %s to %s.", product.ID, item.GetPrice(), product.Price)
+		}
+
+		if product.StockPolicy.AllowsBackorder() {
+			backorder, backorderErr := service.createBackorderDTO(ctx, basket.GetUserID(), product, item.GetCount())
+			if backorderErr != nil {
+				return nil, nil, backorderErr
+			}
+
+			if backorder != nil {
+				basketItem.Backorder = backorder
+				actions[BackorderActionPrefix+product.ID] = backorderAction(product.ID, backorder)
+			}
 		}
 
 		basketDTO.Items = append(basketDTO.Items, basketItem)
+		basketDTO.TotalItems += item.GetCount()
+	}
+
+	promotionResult, promotionErr := service.promotionEngine.Evaluate(ctx, basket.GetCoupons(), promotionItems)
+	if promotionErr != nil {
+		return nil, nil, promotionErr
+	}
+
+	basketDTO.Coupons = append(basketDTO.Coupons, basket.GetCoupons()...)
+
+	for _, discount := range promotionResult.Discounts {
+		basketDTO.Discounts = append(basketDTO.Discounts, &dto.Discount{
+			Code:   discount.Code,
+			Reason: discount.Reason,
+			Amount: newProductPriceDTO(discount.Amount),
+		})
+	}
+
+	for code, reason := range promotionResult.Rejected {
+		actions[CouponRejectedActionPrefix+code] = fmt.Sprintf("Coupon %s was not applied: %s.", code, reason)
+	}
+
+	// a basket can contain products with different currencies which cannot be summed up
+	for _, currency := range promotionResult.SortedCurrencies() {
+		basketDTO.Totals = append(basketDTO.Totals, newProductPriceDTO(promotionResult.Totals[currency]))
+	}
+
+	taxableDiscounts := make([]*taxhelper.TaxableDiscount, 0, len(promotionResult.Discounts))
+	for _, discount := range promotionResult.Discounts {
+		taxableDiscounts = append(taxableDiscounts, &taxhelper.TaxableDiscount{
+			ProductID: discount.ProductID,
+			Amount:    discount.Amount,
+		})
+	}
+
+	taxTotals, taxTotalsErr := service.taxCalculationService.CalculateTotals(taxableAmounts, taxableDiscounts)
+	if taxTotalsErr != nil {
+		return nil, nil, taxTotalsErr
 	}
 
-	return basketDTO, nil
+	for _, taxTotal := range taxTotals {
+		basketDTO.TaxTotals = append(basketDTO.TaxTotals, newTaxTotalDTO(taxTotal))
+	}
+
+	return basketDTO, actions, nil
+}
+
+// createBackorderDTO returns nil if all units are in stock, the units in the baskets of other users are not in stock
+func (service *BasketOutputServiceImpl) createBackorderDTO(ctx context.Context, userID string, product *warehouse.Product, count int) (*dto.Backorder, error) {
+	availableStock, availableStockErr := service.stockReservationService.AvailableStock(ctx, product.ID, userID)
+	if availableStockErr != nil {
+		return nil, availableStockErr
+	}
+
+	backordered := max(count-availableStock, 0)
+	if backordered == 0 && product.StockPolicy.Type != warehouse.StockPolicyTypePreorder {
+		return nil, nil
+	}
+
+	backorder := &dto.Backorder{
+		Type:  string(product.StockPolicy.Type),
+		Count: backordered,
+	}
+	if !product.StockPolicy.RestockDate.IsZero() {
+		estimatedDate := product.StockPolicy.RestockDate
+		backorder.EstimatedDate = &estimatedDate
+	}
+
+	return backorder, nil
+}
+
+func backorderAction(productID string, backorder *dto.Backorder) string {
+	if backorder.Type == string(warehouse.StockPolicyTypePreorder) && backorder.EstimatedDate != nil {
+		return fmt.Sprintf("Product %s is a pre-order, it is shipped on the release date %s.", productID, backorder.EstimatedDate.Format(time.DateOnly))
+	} else if backorder.EstimatedDate != nil {
+		return fmt.Sprintf("Product %s has %d backordered units, they are shipped after the restock expected on %s.", productID, backorder.Count, backorder.EstimatedDate.Format(time.DateOnly))
+	}
+
+	return fmt.Sprintf("Product %s has %d backordered units, they are shipped after the restock.", productID, backorder.Count)
+}
+
+func newTaxDTO(taxAmount *taxhelper.TaxAmount) *dto.Tax {
+	return &dto.Tax{
+		Class: string(taxAmount.Class),
+		Rate:  tax.FormatBasisPoints(taxAmount.BasisPoints),
+		Net:   newProductPriceDTO(taxAmount.Net),
+		Tax:   newProductPriceDTO(taxAmount.Tax),
+		Gross: newProductPriceDTO(taxAmount.Gross),
+	}
+}
+
+func newTaxTotalDTO(taxTotal *taxhelper.TaxTotal) *dto.TaxTotal {
+	taxTotalDTO := &dto.TaxTotal{
+		Net:     newProductPriceDTO(taxTotal.Net),
+		Tax:     newProductPriceDTO(taxTotal.Tax),
+		Gross:   newProductPriceDTO(taxTotal.Gross),
+		Classes: make([]*dto.Tax, 0, len(taxTotal.Classes)),
+	}
+
+	for _, taxAmount := range taxTotal.Classes {
+		taxTotalDTO.Classes = append(taxTotalDTO.Classes, newTaxDTO(taxAmount))
+	}
+
+	return taxTotalDTO
+}
+
+func newProductPriceDTO(price money.Money) *dto.ProductPrice {
+	return &dto.ProductPrice{
+		Value:    price.FormatAmount(),
+		Currency: price.GetCurrency(),
+	}
 }
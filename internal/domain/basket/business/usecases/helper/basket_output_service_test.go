@@ -0,0 +1,282 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
+	promotion "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	promotionhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/usecases/helper"
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	taxhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/usecases/helper"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func newTestTaxCalculationService(t *testing.T) taxhelper.TaxCalculationService {
+	taxCalculationService, err := taxhelper.NewTaxCalculationService("DE", []*tax.TaxRate{
+		{Country: "DE", Class: tax.TaxClassStandard, BasisPoints: 1900},
+		{Country: "DE", Class: tax.TaxClassReduced, BasisPoints: 700},
+	})
+	require.NoError(t, err)
+
+	return taxCalculationService
+}
+
+func Test_BasketOutputService_CreateBasketDTO_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewBasketOutputService(warehouse.NewMockProductRepository(ctrl), warehousehelper.NewMockStockReservationService(ctrl), promotionhelper.NewPromotionEngine(promotion.NewMockPromotionRepository(ctrl)), newTestTaxCalculationService(t))
+
+	basketDTO, actions, err := service.CreateBasketDTO(t.Context(), nil)
+
+	require.ErrorContains(t, err, "basket is nil")
+	require.Nil(t, basketDTO)
+	require.Nil(t, actions)
+}
+
+func Test_BasketOutputService_CreateBasketDTO(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	products := []*warehouse.Product{
+		{ID: "1", Name: "Product 1", Price: money.New(110, "EUR")},
+		{ID: "2", Name: "Product 2", Price: money.New(225, "EUR")},
+		{ID: "3", Name: "Product 3", Price: money.New(999, "USD")},
+	}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	for _, product := range products {
+		productRepositoryMock.EXPECT().Find(gomock.Any(), product.ID).Return(product, nil)
+	}
+
+	basket, err := entities.NewBasketFactory().NewBasketWithID("1", "1337")
+	require.NoError(t, err)
+	basket.AddItem("1", 3)
+	basket.AddItem("2", 2)
+	basket.AddItem("3", 1)
+
+	service := NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), promotionhelper.NewPromotionEngine(promotion.NewMockPromotionRepository(ctrl)), newTestTaxCalculationService(t))
+
+	basketDTO, actions, err := service.CreateBasketDTO(t.Context(), basket)
+
+	require.NoError(t, err)
+	require.Empty(t, actions)
+	require.Len(t, basketDTO.Items, 3)
+	require.Equal(t, 6, basketDTO.TotalItems)
+
+	for _, item := range basketDTO.Items {
+		if item.Product.ID == "1" {
+			require.Equal(t, "3.30", item.Subtotal.Value)
+			require.Equal(t, "EUR", item.Subtotal.Currency)
+		}
+	}
+
+	require.Len(t, basketDTO.Totals, 2)
+	require.Equal(t, "7.80", basketDTO.Totals[0].Value)
+	require.Equal(t, "EUR", basketDTO.Totals[0].Currency)
+	require.Equal(t, "9.99", basketDTO.Totals[1].Value)
+	require.Equal(t, "USD", basketDTO.Totals[1].Currency)
+}
+
+func Test_BasketOutputService_CreateBasketDTO_Backorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseDate := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	products := []*warehouse.Product{
+		{ID: "1", Name: "Backorder", Price: money.New(100, "EUR"), StockPolicy: warehouse.StockPolicy{Type: warehouse.StockPolicyTypeBackorder, MaxBackorder: 10}},
+		{ID: "2", Name: "Pre-order", Price: money.New(100, "EUR"), StockPolicy: warehouse.StockPolicy{Type: warehouse.StockPolicyTypePreorder, RestockDate: releaseDate, MaxBackorder: 10}},
+		{ID: "3", Name: "In stock", Price: money.New(100, "EUR"), StockPolicy: warehouse.StockPolicy{Type: warehouse.StockPolicyTypeBackorder, MaxBackorder: 10}},
+	}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	for _, product := range products {
+		productRepositoryMock.EXPECT().Find(gomock.Any(), product.ID).Return(product, nil)
+	}
+
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().AvailableStock(gomock.Any(), "1", "1337").Return(1, nil)
+	stockReservationServiceMock.EXPECT().AvailableStock(gomock.Any(), "2", "1337").Return(5, nil)
+	stockReservationServiceMock.EXPECT().AvailableStock(gomock.Any(), "3", "1337").Return(5, nil)
+
+	basket, err := entities.NewBasketFactory().NewBasketWithID("1", "1337")
+	require.NoError(t, err)
+	basket.AddItem("1", 3)
+	basket.AddItem("2", 2)
+	basket.AddItem("3", 5)
+
+	service := NewBasketOutputService(productRepositoryMock, stockReservationServiceMock, promotionhelper.NewPromotionEngine(promotion.NewMockPromotionRepository(ctrl)), newTestTaxCalculationService(t))
+
+	basketDTO, actions, err := service.CreateBasketDTO(t.Context(), basket)
+
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		BackorderActionPrefix + "1": "Product 1 has 2 backordered units, they are shipped after the restock.",
+		BackorderActionPrefix + "2": "Product 2 is a pre-order, it is shipped on the release date 2026-12-01.",
+	}, actions)
+
+	backorders := make(map[string]*dto.Backorder)
+	for _, item := range basketDTO.Items {
+		backorders[item.Product.ID] = item.Backorder
+	}
+
+	// the units of a pre-order in stock are shipped on the release date as well
+	require.Equal(t, map[string]*dto.Backorder{
+		"1": {Type: "backorder", Count: 2},
+		"2": {Type: "preorder", Count: 0, EstimatedDate: &releaseDate},
+		"3": nil,
+	}, backorders)
+}
+
+func Test_BasketOutputService_CreateBasketDTO_PriceChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	products := []*warehouse.Product{
+		{ID: "1", Name: "Product 1", Price: money.New(1205, "EUR")},
+		{ID: "2", Name: "Product 2", Price: money.New(225, "EUR")},
+		{ID: "3", Name: "Product 3", Price: money.New(999, "EUR")},
+	}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	for _, product := range products {
+		productRepositoryMock.EXPECT().Find(gomock.Any(), product.ID).Return(product, nil)
+	}
+
+	basket, err := entities.NewBasketFactory().NewBasketWithID("1", "1337")
+	require.NoError(t, err)
+	// price increased since the item was added
+	basket.AddItem("1", 1).SetPrice(money.New(1199, "EUR"))
+	// price did not change
+	basket.AddItem("2", 1).SetPrice(money.New(225, "EUR"))
+	// item without price snapshot
+	basket.AddItem("3", 1)
+
+	service := NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), promotionhelper.NewPromotionEngine(promotion.NewMockPromotionRepository(ctrl)), newTestTaxCalculationService(t))
+
+	basketDTO, actions, err := service.CreateBasketDTO(t.Context(), basket)
+
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+	require.Equal(t, "Product 1 price changed from 11.99 EUR to 12.05 EUR.", actions[PriceChangedActionPrefix+"1"])
+
+	for _, item := range basketDTO.Items {
+		if item.Product.ID == "1" {
+			require.Equal(t, "11.99", item.PreviousPrice.Value)
+			require.Equal(t, "12.05", item.Product.Price.Value)
+		} else {
+			require.Nil(t, item.PreviousPrice)
+		}
+	}
+}
+
+func Test_BasketOutputService_CreateBasketDTO_Coupons(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	product := &warehouse.Product{ID: "1", Name: "Product 1", Price: money.New(2500, "EUR")}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product.ID).Return(product, nil)
+
+	promotionRepositoryMock := promotion.NewMockPromotionRepository(ctrl)
+	promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "TEN").Return(&promotion.Promotion{
+		Code:       "TEN",
+		Type:       promotion.PromotionTypePercentage,
+		Percentage: 10,
+	}, nil)
+	promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "FIVE").Return(&promotion.Promotion{
+		Code:         "FIVE",
+		Type:         promotion.PromotionTypeFixedAmount,
+		Amount:       money.New(500, "EUR"),
+		MinimumTotal: money.New(10000, "EUR"),
+	}, nil)
+
+	basket, err := entities.NewBasketFactory().NewBasketWithID("1", "1337")
+	require.NoError(t, err)
+	basket.AddItem("1", 2)
+	basket.AddCoupon("TEN")
+	basket.AddCoupon("FIVE")
+
+	service := NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), promotionhelper.NewPromotionEngine(promotionRepositoryMock), newTestTaxCalculationService(t))
+
+	basketDTO, actions, err := service.CreateBasketDTO(t.Context(), basket)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"TEN", "FIVE"}, basketDTO.Coupons)
+	require.Len(t, basketDTO.Discounts, 1)
+	require.Equal(t, "TEN", basketDTO.Discounts[0].Code)
+	require.Equal(t, "5.00", basketDTO.Discounts[0].Amount.Value)
+	require.Contains(t, actions, CouponRejectedActionPrefix+"FIVE")
+	require.Len(t, basketDTO.Totals, 1)
+	require.Equal(t, "45.00", basketDTO.Totals[0].Value)
+}
+
+func Test_BasketOutputService_CreateBasketDTO_Tax(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	products := []*warehouse.Product{
+		{ID: "1", Name: "Product 1", Price: money.New(1190, "EUR")},
+		{ID: "2", Name: "Product 2", Price: money.New(535, "EUR"), TaxClass: tax.TaxClassReduced},
+	}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	for _, product := range products {
+		productRepositoryMock.EXPECT().Find(gomock.Any(), product.ID).Return(product, nil)
+	}
+
+	promotionRepositoryMock := promotion.NewMockPromotionRepository(ctrl)
+	promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "HALF").Return(&promotion.Promotion{
+		Code:       "HALF",
+		Type:       promotion.PromotionTypePercentage,
+		Percentage: 50,
+		ProductID:  "2",
+	}, nil)
+
+	basket, err := entities.NewBasketFactory().NewBasketWithID("1", "1337")
+	require.NoError(t, err)
+	basket.AddItem("1", 2)
+	basket.AddItem("2", 2)
+	basket.AddCoupon("HALF")
+
+	service := NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), promotionhelper.NewPromotionEngine(promotionRepositoryMock), newTestTaxCalculationService(t))
+
+	basketDTO, _, err := service.CreateBasketDTO(t.Context(), basket)
+
+	require.NoError(t, err)
+	require.Equal(t, "DE", basketDTO.TaxCountry)
+
+	for _, item := range basketDTO.Items {
+		if item.Product.ID == "1" {
+			require.Equal(t, "standard", item.Tax.Class)
+			require.Equal(t, "19%", item.Tax.Rate)
+			require.Equal(t, "20.00", item.Tax.Net.Value)
+			require.Equal(t, "3.80", item.Tax.Tax.Value)
+			require.Equal(t, "23.80", item.Tax.Gross.Value)
+		} else {
+			require.Equal(t, "reduced", item.Tax.Class)
+			require.Equal(t, "7%", item.Tax.Rate)
+			require.Equal(t, "10.00", item.Tax.Net.Value)
+			require.Equal(t, "0.70", item.Tax.Tax.Value)
+		}
+	}
+
+	// the product discount only reduces the tax of product 2
+	require.Len(t, basketDTO.TaxTotals, 1)
+	require.Equal(t, basketDTO.Totals[0].Value, basketDTO.TaxTotals[0].Gross.Value)
+	require.Equal(t, "29.15", basketDTO.TaxTotals[0].Gross.Value)
+	require.Equal(t, "25.00", basketDTO.TaxTotals[0].Net.Value)
+	require.Equal(t, "4.15", basketDTO.TaxTotals[0].Tax.Value)
+	require.Len(t, basketDTO.TaxTotals[0].Classes, 2)
+	require.Equal(t, "0.35", basketDTO.TaxTotals[0].Classes[0].Tax.Value)
+	require.Equal(t, "3.80", basketDTO.TaxTotals[0].Classes[1].Tax.Value)
+}
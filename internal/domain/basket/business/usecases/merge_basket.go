@@ -0,0 +1,234 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type MergeBasketUseCaseInput struct {
+	// GuestUserID is the owner of the basket which will be merged and emptied afterwards
+	GuestUserID string
+	UserID      string
+}
+
+type MergeBasketUseCaseOutput struct {
+	UserBasket *dto.BasketDTO
+	Actions    map[string]string
+}
+
+// MergeBasketUseCase moves the items of a guest basket into the basket of the registered user
+type MergeBasketUseCase interface {
+	Execute(ctx context.Context, input *MergeBasketUseCaseInput) (*MergeBasketUseCaseOutput, error)
+}
+
+func NewMergeBasketUseCaseImpl(basketCreatorService helper.BasketCreatorService, basketOutputService helper.BasketOutputService, basketRepository entities.BasketRepository, stockReservationService warehousehelper.StockReservationService) MergeBasketUseCase {
+	return &MergeBasketUseCaseImpl{
+		basketCreatorService:    basketCreatorService,
+		basketOutputService:     basketOutputService,
+		basketRepository:        basketRepository,
+		stockReservationService: stockReservationService,
+	}
+}
+
+var _ MergeBasketUseCase = (*MergeBasketUseCaseImpl)(nil)
+
+type MergeBasketUseCaseImpl struct {
+	basketCreatorService    helper.BasketCreatorService
+	basketOutputService     helper.BasketOutputService
+	basketRepository        entities.BasketRepository
+	stockReservationService warehousehelper.StockReservationService
+}
+
+func (useCase *MergeBasketUseCaseImpl) validate(input *MergeBasketUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.GuestUserID == "" {
+		return fmt.Errorf("input parameter GuestUserID is empty")
+	} else if input.UserID == "" {
+		return fmt.Errorf("input parameter UserID is empty")
+	} else if input.GuestUserID == input.UserID {
+		return fmt.Errorf("input parameter GuestUserID must differ from UserID")
+	}
+
+	return nil
+}
+
+func (useCase *MergeBasketUseCaseImpl) Execute(ctx context.Context, input *MergeBasketUseCaseInput) (*MergeBasketUseCaseOutput, error) {
+	// validate input first
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	// the login has no expected version, a concurrent change of the user basket is merged again
+	return retryOnVersionConflict(nil, func() (*MergeBasketUseCaseOutput, error) {
+		return useCase.execute(ctx, input)
+	})
+}
+
+func (useCase *MergeBasketUseCaseImpl) execute(ctx context.Context, input *MergeBasketUseCaseInput) (*MergeBasketUseCaseOutput, error) {
+	userBasket, userBasketErr := useCase.basketCreatorService.FindOrCreate(ctx, input.UserID)
+	if userBasketErr != nil {
+		return nil, userBasketErr
+	}
+
+	actions := map[string]string{}
+
+	guestBasket, guestBasketErr := useCase.basketRepository.FindByUserId(ctx, input.GuestUserID)
+	if guestBasketErr != nil && !domainerror.IsNotFound(guestBasketErr, entities.BasketResource) {
+		return nil, guestBasketErr
+	}
+
+	// a guest without basket has nothing to merge
+	if guestBasket != nil && len(guestBasket.GetItems()) > 0 {
+		mergeErr := useCase.merge(ctx, userBasket, guestBasket, actions)
+		if mergeErr != nil {
+			return nil, mergeErr
+		}
+	}
+
+	userBasketDTO, basketOutputActions, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(ctx, userBasket)
+	if basketOutputServiceErr != nil {
+		return nil, basketOutputServiceErr
+	}
+
+	output := &MergeBasketUseCaseOutput{
+		UserBasket: userBasketDTO,
+		Actions:    mergeActions(actions, basketOutputActions),
+	}
+
+	return output, nil
+}
+
+// merge empties the guest basket before the user basket is saved, so a failed merge cannot leave the items in both baskets.
+// If the merge fails, the guest basket and the reservations of both users are restored.
+func (useCase *MergeBasketUseCaseImpl) merge(ctx context.Context, userBasket *entities.Basket, guestBasket *entities.Basket, actions map[string]string) error {
+	// Clear replaces the items, so the items and coupons of the guest are kept for the restore
+	guestItems := guestBasket.GetItems()
+	guestCoupons := guestBasket.GetCoupons()
+
+	userCounts := map[string]int{}
+	for productID, basketItem := range userBasket.GetItems() {
+		userCounts[productID] = basketItem.GetCount()
+	}
+
+	guestBasket.Clear()
+
+	// nothing is merged yet, so a version conflict of the guest basket is retried
+	_, basketRepositorySaveErr := useCase.basketRepository.Save(ctx, guestBasket)
+	if basketRepositorySaveErr != nil {
+		return basketRepositorySaveErr
+	}
+
+	// the units reserved for the guest are available for the user again
+	releaseErr := useCase.stockReservationService.ReleaseAll(ctx, guestBasket.GetUserID())
+	if releaseErr != nil {
+		return errors.Join(releaseErr, useCase.restore(ctx, userBasket, guestBasket, guestItems, guestCoupons, userCounts))
+	}
+
+	for _, guestItem := range guestItems {
+		mergeItemErr := useCase.mergeItem(ctx, userBasket, guestItem, actions)
+		if mergeItemErr != nil {
+			return errors.Join(mergeItemErr, useCase.restore(ctx, userBasket, guestBasket, guestItems, guestCoupons, userCounts))
+		}
+	}
+
+	for _, coupon := range guestCoupons {
+		userBasket.AddCoupon(coupon)
+	}
+
+	_, basketRepositorySaveErr = useCase.basketRepository.Save(ctx, userBasket)
+	if basketRepositorySaveErr != nil {
+		return errors.Join(basketRepositorySaveErr, useCase.restore(ctx, userBasket, guestBasket, guestItems, guestCoupons, userCounts))
+	}
+
+	return nil
+}
+
+// restore resets the reservations of the user to the stored user basket, reserves the guest items again
+// and saves them into the guest basket. It continues after an error, so as much as possible is restored.
+func (useCase *MergeBasketUseCaseImpl) restore(ctx context.Context, userBasket *entities.Basket, guestBasket *entities.Basket, guestItems map[string]*entities.BasketItem, guestCoupons []string, userCounts map[string]int) error {
+	// the merge failed, but the restore has to be completed even if the request is canceled
+	ctx = context.WithoutCancel(ctx)
+
+	var restoreErrs []error
+	for productID := range guestItems {
+		// a count of 0 releases the units reserved for the user by the merge
+		reserveErr := useCase.stockReservationService.Reserve(ctx, userBasket.GetUserID(), productID, userCounts[productID])
+		if reserveErr != nil {
+			restoreErrs = append(restoreErrs, reserveErr)
+		}
+	}
+
+	for productID, guestItem := range guestItems {
+		reserveErr := useCase.stockReservationService.Reserve(ctx, guestBasket.GetUserID(), productID, guestItem.GetCount())
+		if reserveErr != nil {
+			restoreErrs = append(restoreErrs, reserveErr)
+		}
+	}
+
+	guestBasket.Items = guestItems
+	guestBasket.Coupons = guestCoupons
+
+	_, basketRepositorySaveErr := useCase.basketRepository.Save(ctx, guestBasket)
+	if basketRepositorySaveErr != nil {
+		restoreErrs = append(restoreErrs, basketRepositorySaveErr)
+	}
+
+	if len(restoreErrs) > 0 {
+		return fmt.Errorf("failed to restore the basket of guest %s: %w", guestBasket.GetUserID(), errors.Join(restoreErrs...))
+	}
+
+	return nil
+}
+
+// mergeItem adds the guest count to the user basket, capped at the available stock and the units which can be backordered
+func (useCase *MergeBasketUseCaseImpl) mergeItem(ctx context.Context, userBasket *entities.Basket, guestItem *entities.BasketItem, actions map[string]string) error {
+	productID := guestItem.GetProductID()
+	actionKey := "product_stock_" + productID
+
+	sellableStock, sellableStockErr := useCase.stockReservationService.SellableStock(ctx, productID, userBasket.GetUserID())
+	if sellableStockErr != nil {
+		return sellableStockErr
+	}
+
+	if sellableStock <= 0 {
+		actions[actionKey] = fmt.Sprintf("Product %s is out of stock. It was not taken over from the guest basket.", productID)
+		return nil
+	}
+
+	mergedCount := guestItem.GetCount()
+	if userBasket.HasItem(productID) {
+		basketItem, _ := userBasket.GetItem(productID)
+		mergedCount += basketItem.GetCount()
+	}
+
+	count := mergedCount
+	if sellableStock < mergedCount {
+		count = sellableStock
+		actions[actionKey] = fmt.Sprintf("Product %s stock is too low to merge %d. Updated basket item count to %d.", productID, mergedCount, count)
+	}
+
+	reserveErr := useCase.stockReservationService.Reserve(ctx, userBasket.GetUserID(), productID, count)
+	if reserveErr != nil {
+		return reserveErr
+	}
+
+	if !userBasket.HasItem(productID) {
+		// keep the price the guest has seen, so a price change is reported to the user
+		basketItem := userBasket.AddItem(productID, count)
+		basketItem.SetPrice(guestItem.GetPrice())
+	} else {
+		basketItem, _ := userBasket.GetItem(productID)
+		basketItem.SetCount(count)
+	}
+
+	return nil
+}
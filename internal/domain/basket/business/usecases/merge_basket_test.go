@@ -0,0 +1,330 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_MergeBasketUseCase_NewMergeBasketUseCaseImpl_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *MergeBasketUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"GuestUserID is empty": {
+			input: &MergeBasketUseCaseInput{},
+		},
+		"UserID is empty": {
+			input: &MergeBasketUseCaseInput{
+				GuestUserID: "guest-1",
+			},
+		},
+		"GuestUserID must differ from UserID": {
+			input: &MergeBasketUseCaseInput{
+				GuestUserID: "1337",
+				UserID:      "1337",
+			},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			basketFactory := entities.NewBasketFactory()
+			basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+
+			basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+			basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+			stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+
+			useCase := NewMergeBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, stockReservationServiceMock)
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.Error(t, err)
+			require.ErrorContains(t, err, errorString)
+		})
+	}
+}
+
+func Test_MergeBasketUseCase(t *testing.T) {
+	// arrange
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+	guestUserID := "guest-1"
+
+	product1 := &warehouse.Product{
+		ID:    "1",
+		Name:  "Product 1",
+		Stock: 10,
+		Price: money.New(1337, "EUR"),
+	}
+	product2 := &warehouse.Product{
+		ID:    "2",
+		Name:  "Product 2",
+		Stock: 3,
+		Price: money.New(420, "EUR"),
+	}
+	product3 := &warehouse.Product{
+		ID:    "3",
+		Name:  "Product 3",
+		Stock: 0,
+		Price: money.New(100, "EUR"),
+	}
+
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("1", userID)
+	require.NoError(t, err)
+	userBasket.AddItem(product1.ID, 1)
+	userBasket.AddItem(product2.ID, 2)
+
+	guestBasket, err := basketFactory.NewBasketWithID("2", guestUserID)
+	require.NoError(t, err)
+	guestBasket.AddItem(product1.ID, 2)
+	guestBasket.AddItem(product2.ID, 2)
+	guestBasket.AddItem(product3.ID, 1)
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), guestUserID).Return(guestBasket, nil)
+	// the guest basket is emptied first, so a failed merge cannot leave the items in both baskets
+	gomock.InOrder(
+		basketRepositoryMock.EXPECT().Save(gomock.Any(), guestBasket).Return(guestBasket.GetID(), nil),
+		basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return(userBasket.GetID(), nil),
+	)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1.ID).Return(product1, nil)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product2.ID).Return(product2, nil)
+
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().ReleaseAll(gomock.Any(), guestUserID).Return(nil)
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), product1.ID, userID).Return(product1.Stock, nil)
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), product2.ID, userID).Return(product2.Stock, nil)
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), product3.ID, userID).Return(product3.Stock, nil)
+	stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product1.ID, 3).Return(nil)
+	stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product2.ID, 3).Return(nil)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewMergeBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, stockReservationServiceMock)
+
+	input := &MergeBasketUseCaseInput{
+		GuestUserID: guestUserID,
+		UserID:      userID,
+	}
+
+	// act
+
+	output, err := useCase.Execute(t.Context(), input)
+
+	// assert
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+
+	require.Len(t, userBasket.GetItems(), 2)
+	require.Equal(t, 3, userBasket.Items[product1.ID].GetCount())
+	require.Equal(t, 3, userBasket.Items[product2.ID].GetCount())
+	require.Empty(t, guestBasket.GetItems())
+
+	require.Len(t, output.Actions, 2)
+	require.Contains(t, output.Actions, "product_stock_"+product2.ID)
+	require.Contains(t, output.Actions, "product_stock_"+product3.ID)
+}
+
+func Test_MergeBasketUseCase_WithoutGuestBasket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+	guestUserID := "guest-1"
+
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("1", userID)
+	require.NoError(t, err)
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), guestUserID).Return(nil, &domainerror.NotFoundError{Resource: entities.BasketResource})
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewMergeBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, stockReservationServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &MergeBasketUseCaseInput{GuestUserID: guestUserID, UserID: userID})
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	require.Empty(t, output.Actions)
+	require.Empty(t, output.UserBasket.Items)
+}
+
+type mergeBasketFailureTestFixture struct {
+	userBasket                  *entities.Basket
+	guestBasket                 *entities.Basket
+	basketRepositoryMock        *entities.MockBasketRepository
+	stockReservationServiceMock *warehousehelper.MockStockReservationService
+	useCase                     MergeBasketUseCase
+}
+
+// newMergeBasketFailureTestFixture has a user basket with 1 unit of product 1 and a guest basket with 2 units of product 1 and a coupon
+func newMergeBasketFailureTestFixture(t *testing.T, ctrl *gomock.Controller) *mergeBasketFailureTestFixture {
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("1", "1337")
+	require.NoError(t, err)
+	userBasket.AddItem("1", 1)
+
+	guestBasket, err := basketFactory.NewBasketWithID("2", "guest-1")
+	require.NoError(t, err)
+	guestBasket.AddItem("1", 2)
+	guestBasket.AddCoupon("SAVE10")
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(userBasket, nil)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "guest-1").Return(guestBasket, nil)
+
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(warehouse.NewMockProductRepository(ctrl), warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	return &mergeBasketFailureTestFixture{
+		userBasket:                  userBasket,
+		guestBasket:                 guestBasket,
+		basketRepositoryMock:        basketRepositoryMock,
+		stockReservationServiceMock: stockReservationServiceMock,
+		useCase:                     NewMergeBasketUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, stockReservationServiceMock),
+	}
+}
+
+// expectRestore expects the reservations and the guest basket to be restored
+func (fixture *mergeBasketFailureTestFixture) expectRestore() {
+	gomock.InOrder(
+		fixture.stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), "1337", "1", 1).Return(nil),
+		fixture.stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), "guest-1", "1", 2).Return(nil),
+		fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.guestBasket).DoAndReturn(func(ctx context.Context, basket *entities.Basket) (string, error) {
+			// the guest basket contains its items again when it is saved
+			if len(basket.GetItems()) != 1 || len(basket.GetCoupons()) != 1 {
+				return "", errors.New("guest basket was not restored")
+			}
+
+			return basket.GetID(), nil
+		}),
+	)
+}
+
+func (fixture *mergeBasketFailureTestFixture) requireRestored(t *testing.T) {
+	require.Equal(t, 2, fixture.guestBasket.Items["1"].GetCount())
+	require.Equal(t, []string{"SAVE10"}, fixture.guestBasket.GetCoupons())
+}
+
+func Test_MergeBasketUseCase_UserBasketSaveFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newMergeBasketFailureTestFixture(t, ctrl)
+
+	gomock.InOrder(
+		fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.guestBasket).Return(fixture.guestBasket.GetID(), nil),
+		fixture.stockReservationServiceMock.EXPECT().ReleaseAll(gomock.Any(), "guest-1").Return(nil),
+		fixture.stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), "1", "1337").Return(10, nil),
+		fixture.stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), "1337", "1", 3).Return(nil),
+		fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.userBasket).Return("", errors.New("database is down")),
+	)
+	fixture.expectRestore()
+
+	output, err := fixture.useCase.Execute(t.Context(), &MergeBasketUseCaseInput{GuestUserID: "guest-1", UserID: "1337"})
+
+	require.ErrorContains(t, err, "database is down")
+	require.Nil(t, output)
+	fixture.requireRestored(t)
+}
+
+func Test_MergeBasketUseCase_GuestBasketSaveFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newMergeBasketFailureTestFixture(t, ctrl)
+
+	// nothing is merged or released before the guest basket is emptied
+	fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.guestBasket).Return("", errors.New("database is down"))
+
+	output, err := fixture.useCase.Execute(t.Context(), &MergeBasketUseCaseInput{GuestUserID: "guest-1", UserID: "1337"})
+
+	require.ErrorContains(t, err, "database is down")
+	require.Nil(t, output)
+	require.Equal(t, 1, fixture.userBasket.Items["1"].GetCount())
+}
+
+func Test_MergeBasketUseCase_ReserveFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newMergeBasketFailureTestFixture(t, ctrl)
+
+	gomock.InOrder(
+		fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.guestBasket).Return(fixture.guestBasket.GetID(), nil),
+		fixture.stockReservationServiceMock.EXPECT().ReleaseAll(gomock.Any(), "guest-1").Return(nil),
+		fixture.stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), "1", "1337").Return(10, nil),
+		fixture.stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), "1337", "1", 3).Return(errors.New("reservation failed")),
+	)
+	fixture.expectRestore()
+
+	output, err := fixture.useCase.Execute(t.Context(), &MergeBasketUseCaseInput{GuestUserID: "guest-1", UserID: "1337"})
+
+	require.ErrorContains(t, err, "reservation failed")
+	require.Nil(t, output)
+	fixture.requireRestored(t)
+}
+
+func Test_MergeBasketUseCase_RestoreFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newMergeBasketFailureTestFixture(t, ctrl)
+
+	gomock.InOrder(
+		fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.guestBasket).Return(fixture.guestBasket.GetID(), nil),
+		fixture.stockReservationServiceMock.EXPECT().ReleaseAll(gomock.Any(), "guest-1").Return(nil),
+		fixture.stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), "1", "1337").Return(10, nil),
+		fixture.stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), "1337", "1", 3).Return(nil),
+		fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.userBasket).Return("", errors.New("database is down")),
+		fixture.stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), "1337", "1", 1).Return(nil),
+		fixture.stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), "guest-1", "1", 2).Return(nil),
+		fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.guestBasket).Return("", errors.New("database is still down")),
+	)
+
+	output, err := fixture.useCase.Execute(t.Context(), &MergeBasketUseCaseInput{GuestUserID: "guest-1", UserID: "1337"})
+
+	require.ErrorContains(t, err, "database is down")
+	require.ErrorContains(t, err, "failed to restore the basket of guest guest-1: database is still down")
+	require.Nil(t, output)
+}
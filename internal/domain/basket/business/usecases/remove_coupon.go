@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
+	promotion "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type RemoveCouponUseCaseInput struct {
+	UserID string
+	Code   string
+	// ExpectedVersion is the basket version the client has seen, nil changes the current basket
+	ExpectedVersion *int64
+}
+
+type RemoveCouponUseCaseOutput struct {
+	UserBasket *dto.BasketDTO
+	Actions    map[string]string
+}
+
+type RemoveCouponUseCase interface {
+	Execute(ctx context.Context, input *RemoveCouponUseCaseInput) (*RemoveCouponUseCaseOutput, error)
+}
+
+func NewRemoveCouponUseCaseImpl(basketCreatorService helper.BasketCreatorService, basketOutputService helper.BasketOutputService, basketRepository entities.BasketRepository) RemoveCouponUseCase {
+	return &RemoveCouponUseCaseImpl{
+		basketCreatorService: basketCreatorService,
+		basketOutputService:  basketOutputService,
+		basketRepository:     basketRepository,
+	}
+}
+
+var _ RemoveCouponUseCase = (*RemoveCouponUseCaseImpl)(nil)
+
+type RemoveCouponUseCaseImpl struct {
+	basketCreatorService helper.BasketCreatorService
+	basketOutputService  helper.BasketOutputService
+	basketRepository     entities.BasketRepository
+}
+
+func (useCase *RemoveCouponUseCaseImpl) validate(input *RemoveCouponUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.UserID == "" {
+		return fmt.Errorf("input parameter UserID is empty")
+	} else if promotion.NormalizeCode(input.Code) == "" {
+		return fmt.Errorf("input parameter Code is empty")
+	}
+
+	return nil
+}
+
+func (useCase *RemoveCouponUseCaseImpl) Execute(ctx context.Context, input *RemoveCouponUseCaseInput) (*RemoveCouponUseCaseOutput, error) {
+	// validate input first
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	return retryOnVersionConflict(input.ExpectedVersion, func() (*RemoveCouponUseCaseOutput, error) {
+		return useCase.execute(ctx, input)
+	})
+}
+
+// execute removes the coupon from the current basket
+func (useCase *RemoveCouponUseCaseImpl) execute(ctx context.Context, input *RemoveCouponUseCaseInput) (*RemoveCouponUseCaseOutput, error) {
+	userBasket, userBasketErr := useCase.basketCreatorService.FindOrCreate(ctx, input.UserID)
+	if userBasketErr != nil {
+		return nil, userBasketErr
+	}
+
+	versionErr := checkExpectedVersion(userBasket, input.ExpectedVersion)
+	if versionErr != nil {
+		return nil, versionErr
+	}
+
+	userBasketErr = userBasket.RemoveCoupon(promotion.NormalizeCode(input.Code))
+	if userBasketErr != nil {
+		return nil, userBasketErr
+	}
+
+	_, basketRepositorySaveErr := useCase.basketRepository.Save(ctx, userBasket)
+	if basketRepositorySaveErr != nil {
+		return nil, basketRepositorySaveErr
+	}
+
+	userBasketDTO, basketOutputActions, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(ctx, userBasket)
+	if basketOutputServiceErr != nil {
+		return nil, basketOutputServiceErr
+	}
+
+	output := &RemoveCouponUseCaseOutput{
+		UserBasket: userBasketDTO,
+		Actions:    mergeActions(nil, basketOutputActions),
+	}
+
+	return output, nil
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+)
+
+func Test_RemoveCouponUseCase_NewRemoveCouponUseCaseImpl_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *RemoveCouponUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"UserID is empty": {
+			input: &RemoveCouponUseCaseInput{},
+		},
+		"Code is empty": {
+			input: &RemoveCouponUseCaseInput{
+				UserID: "1337",
+			},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			basketFactory := entities.NewBasketFactory()
+			basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+
+			basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+			basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+			useCase := NewRemoveCouponUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock)
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.Error(t, err)
+			require.ErrorContains(t, err, errorString)
+		})
+	}
+}
+
+func Test_RemoveCouponUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("12345", userID)
+	require.NoError(t, err)
+	userBasket.AddCoupon("TEN")
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil).Times(2)
+	basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return(userBasket.GetID(), nil)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewRemoveCouponUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock)
+
+	output, err := useCase.Execute(t.Context(), &RemoveCouponUseCaseInput{UserID: userID, Code: "ten"})
+
+	require.NoError(t, err)
+	require.Empty(t, output.UserBasket.Coupons)
+
+	// removing a coupon which is not in the basket fails
+	output, err = useCase.Execute(t.Context(), &RemoveCouponUseCaseInput{UserID: userID, Code: "ten"})
+
+	require.Error(t, err)
+	require.Nil(t, output)
+}
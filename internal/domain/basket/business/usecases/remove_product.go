@@ -1,17 +1,22 @@
 package usecases
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
 	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
 )
 
 type RemoveProductUseCaseInput struct {
 	UserID    string
 	ProductID string
+	// ExpectedVersion is the basket version the client has seen, nil changes the current basket
+	ExpectedVersion *int64
 }
 
 type RemoveProductUseCaseOutput struct {
@@ -20,25 +25,27 @@ type RemoveProductUseCaseOutput struct {
 }
 
 type RemoveProductUseCase interface {
-	Execute(input *RemoveProductUseCaseInput) (*RemoveProductUseCaseOutput, error)
+	Execute(ctx context.Context, input *RemoveProductUseCaseInput) (*RemoveProductUseCaseOutput, error)
 }
 
-func NewRemoveProductUseCaseImpl(basketService helper.BasketCreatorService, basketOutputService helper.BasketOutputService, basketRepository entities.BasketRepository, productRepository warehouse.ProductRepository) RemoveProductUseCase {
+func NewRemoveProductUseCaseImpl(basketService helper.BasketCreatorService, basketOutputService helper.BasketOutputService, basketRepository entities.BasketRepository, productRepository warehouse.ProductRepository, stockReservationService warehousehelper.StockReservationService) RemoveProductUseCase {
 	return &RemoveProductUseCaseImpl{
-		basketService:       basketService,
-		basketOutputService: basketOutputService,
-		basketRepository:    basketRepository,
-		productRepository:   productRepository,
+		basketService:           basketService,
+		basketOutputService:     basketOutputService,
+		basketRepository:        basketRepository,
+		productRepository:       productRepository,
+		stockReservationService: stockReservationService,
 	}
 }
 
 var _ RemoveProductUseCase = (*RemoveProductUseCaseImpl)(nil)
 
 type RemoveProductUseCaseImpl struct {
-	basketService       helper.BasketCreatorService
-	basketOutputService helper.BasketOutputService
-	basketRepository    entities.BasketRepository
-	productRepository   warehouse.ProductRepository
+	basketService           helper.BasketCreatorService
+	basketOutputService     helper.BasketOutputService
+	basketRepository        entities.BasketRepository
+	productRepository       warehouse.ProductRepository
+	stockReservationService warehousehelper.StockReservationService
 }
 
 func (useCase *RemoveProductUseCaseImpl) validate(input *RemoveProductUseCaseInput) error {
@@ -53,16 +60,28 @@ func (useCase *RemoveProductUseCaseImpl) validate(input *RemoveProductUseCaseInp
 	return nil
 }
 
-func (useCase *RemoveProductUseCaseImpl) Execute(input *RemoveProductUseCaseInput) (*RemoveProductUseCaseOutput, error) {
+func (useCase *RemoveProductUseCaseImpl) Execute(ctx context.Context, input *RemoveProductUseCaseInput) (*RemoveProductUseCaseOutput, error) {
 	// validate input first
 	err := useCase.validate(input)
 	if err != nil {
-		return nil, fmt.Errorf("input validation error: %w", err)
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
 	}
 
-	userBasket, userBasketErr := useCase.basketService.FindOrCreate(input.UserID)
+	return retryOnVersionConflict(input.ExpectedVersion, func() (*RemoveProductUseCaseOutput, error) {
+		return useCase.execute(ctx, input)
+	})
+}
+
+// execute removes the product from the current basket
+func (useCase *RemoveProductUseCaseImpl) execute(ctx context.Context, input *RemoveProductUseCaseInput) (*RemoveProductUseCaseOutput, error) {
+	userBasket, userBasketErr := useCase.basketService.FindOrCreate(ctx, input.UserID)
 	if userBasketErr != nil {
-		return nil, err
+		return nil, userBasketErr
+	}
+
+	versionErr := checkExpectedVersion(userBasket, input.ExpectedVersion)
+	if versionErr != nil {
+		return nil, versionErr
 	}
 
 	userBasketErr = userBasket.RemoveItem(input.ProductID)
@@ -70,19 +89,24 @@ func (useCase *RemoveProductUseCaseImpl) Execute(input *RemoveProductUseCaseInpu
 		return nil, userBasketErr
 	}
 
-	_, basketRepositorySaveErr := useCase.basketRepository.Save(userBasket)
+	_, basketRepositorySaveErr := useCase.basketRepository.Save(ctx, userBasket)
 	if basketRepositorySaveErr != nil {
 		return nil, basketRepositorySaveErr
 	}
 
-	userBasketDTO, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(userBasket)
+	releaseErr := useCase.stockReservationService.Release(ctx, input.UserID, input.ProductID)
+	if releaseErr != nil {
+		return nil, releaseErr
+	}
+
+	userBasketDTO, basketOutputActions, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(ctx, userBasket)
 	if basketOutputServiceErr != nil {
 		return nil, basketOutputServiceErr
 	}
 
 	output := &RemoveProductUseCaseOutput{
 		UserBasket: userBasketDTO,
-		Actions:    map[string]string{},
+		Actions:    mergeActions(map[string]string{}, basketOutputActions),
 	}
 
 	return output, nil
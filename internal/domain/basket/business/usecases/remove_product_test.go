@@ -9,6 +9,7 @@ import (
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
 	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
 )
 
 func Test_RemoveProductUseCase_NewRemoveProductUseCaseImpl_ReturnsError(t *testing.T) {
@@ -38,11 +39,13 @@ func Test_RemoveProductUseCase_NewRemoveProductUseCaseImpl_ReturnsError(t *testi
 			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
 
 			basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
-			basketOutputService := helper.NewBasketOutputService(productRepositoryMock)
+			basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
 
-			useCase := NewRemoveProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock)
+			stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
 
-			_, err := useCase.Execute(testCase.input)
+			useCase := NewRemoveProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
 
 			require.Error(t, err)
 			require.ErrorContains(t, err, errorString)
@@ -72,16 +75,19 @@ func Test_RemoveProductFromBasketUseCase(t *testing.T) {
 
 	userBasket.AddItem(product1ID, 1)
 
-	basketRepositoryMock.EXPECT().FindByUserId(userID).Return(userBasket, nil)
-	basketRepositoryMock.EXPECT().Save(userBasket).Return(basketID, nil)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+	basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return(basketID, nil)
 
 	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
 
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().Release(gomock.Any(), userID, product1ID).Return(nil)
+
 	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
 
-	basketOutputService := helper.NewBasketOutputService(productRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
 
-	useCase := NewRemoveProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock)
+	useCase := NewRemoveProductUseCaseImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
 
 	input := &RemoveProductUseCaseInput{
 		UserID:    userID,
@@ -90,7 +96,7 @@ func Test_RemoveProductFromBasketUseCase(t *testing.T) {
 
 	// act
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(t.Context(), input)
 
 	// assert
 
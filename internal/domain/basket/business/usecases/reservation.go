@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+// resetReservation sets the reservation of the product back to the count of the stored basket after the basket
+// could not be saved, so the reserved units match the stored basket again. It returns saveErr joined with the reset error.
+//
+// storedCount is the count of the basket which was read before the change. On a version conflict the basket was saved
+// by another request meanwhile, so the count of the stored basket is read again.
+func resetReservation(ctx context.Context, basketRepository entities.BasketRepository, stockReservationService warehousehelper.StockReservationService, userID string, productID string, storedCount int, saveErr error) error {
+	// the reset has to be completed even if the request is canceled
+	ctx = context.WithoutCancel(ctx)
+
+	if domainerror.IsVersionConflict(saveErr) {
+		var storedCountErr error
+		storedCount, storedCountErr = findStoredCount(ctx, basketRepository, userID, productID)
+		if storedCountErr != nil {
+			return errors.Join(saveErr, fmt.Errorf("failed to reset the reservation of product %s: %w", productID, storedCountErr))
+		}
+	}
+
+	// a count of 0 releases the reservation
+	reserveErr := stockReservationService.Reserve(ctx, userID, productID, storedCount)
+	if reserveErr != nil {
+		return errors.Join(saveErr, fmt.Errorf("failed to reset the reservation of product %s: %w", productID, reserveErr))
+	}
+
+	return saveErr
+}
+
+// findStoredCount returns the count of the product in the stored basket of the user, 0 if the basket or the item does not exist
+func findStoredCount(ctx context.Context, basketRepository entities.BasketRepository, userID string, productID string) (int, error) {
+	storedBasket, storedBasketErr := basketRepository.FindByUserId(ctx, userID)
+	if storedBasketErr != nil {
+		if domainerror.IsNotFound(storedBasketErr, entities.BasketResource) {
+			return 0, nil
+		}
+		return 0, storedBasketErr
+	}
+
+	if !storedBasket.HasItem(productID) {
+		return 0, nil
+	}
+
+	storedItem, _ := storedBasket.GetItem(productID)
+
+	return storedItem.GetCount(), nil
+}
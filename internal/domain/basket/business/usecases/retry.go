@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+// maxVersionConflictAttempts limits how often a use case reads and changes the basket if it was saved concurrently
+const maxVersionConflictAttempts = 3
+
+// retryOnVersionConflict executes the change of the basket again if the basket was saved concurrently.
+// A change with an expected version is not retried, because the client has to see the current basket first.
+func retryOnVersionConflict[T any](expectedVersion *int64, execute func() (T, error)) (T, error) {
+	output, err := execute()
+	for attempt := 1; attempt < maxVersionConflictAttempts && expectedVersion == nil && domainerror.IsVersionConflict(err); attempt++ {
+		output, err = execute()
+	}
+
+	return output, err
+}
+
+// checkExpectedVersion returns a domainerror.VersionConflictError if the basket does not have the expected version,
+// a nil expected version matches every version
+func checkExpectedVersion(basket *entities.Basket, expectedVersion *int64) error {
+	if expectedVersion == nil || *expectedVersion == basket.GetVersion() {
+		return nil
+	}
+
+	return &domainerror.VersionConflictError{Resource: entities.BasketResource, ID: basket.GetID(), Version: *expectedVersion}
+}
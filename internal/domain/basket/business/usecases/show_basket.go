@@ -1,10 +1,12 @@
 package usecases
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
 )
 
 type ShowBasketUseCaseInput struct {
@@ -13,10 +15,11 @@ type ShowBasketUseCaseInput struct {
 
 type ShowBasketUseCaseOutput struct {
 	UserBasket *dto.BasketDTO
+	Actions    map[string]string
 }
 
 type ShowBasketUseCase interface {
-	Execute(input *ShowBasketUseCaseInput) (*ShowBasketUseCaseOutput, error)
+	Execute(ctx context.Context, input *ShowBasketUseCaseInput) (*ShowBasketUseCaseOutput, error)
 }
 
 func NewShowBasketUseCaseImpl(basketService helper.BasketCreatorService, basketOutputService helper.BasketOutputService) ShowBasketUseCase {
@@ -43,24 +46,25 @@ func (useCase *ShowBasketUseCaseImpl) validate(input *ShowBasketUseCaseInput) er
 	return nil
 }
 
-func (useCase *ShowBasketUseCaseImpl) Execute(input *ShowBasketUseCaseInput) (*ShowBasketUseCaseOutput, error) {
+func (useCase *ShowBasketUseCaseImpl) Execute(ctx context.Context, input *ShowBasketUseCaseInput) (*ShowBasketUseCaseOutput, error) {
 	err := useCase.validate(input)
 	if err != nil {
-		return nil, fmt.Errorf("input validation error: %w", err)
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
 	}
 
-	userBasket, userBasketErr := useCase.basketService.FindOrCreate(input.UserID)
+	userBasket, userBasketErr := useCase.basketService.FindOrCreate(ctx, input.UserID)
 	if userBasketErr != nil {
 		return nil, userBasketErr
 	}
 
-	userBasketDTO, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(userBasket)
+	userBasketDTO, basketOutputActions, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(ctx, userBasket)
 	if basketOutputServiceErr != nil {
 		return nil, basketOutputServiceErr
 	}
 
 	output := &ShowBasketUseCaseOutput{
 		UserBasket: userBasketDTO,
+		Actions:    mergeActions(nil, basketOutputActions),
 	}
 
 	return output, nil
@@ -9,6 +9,8 @@ import (
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
 	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
 )
 
 func Test_ShowBasketUseCase_NewShowBasketUseCaseImpl_ReturnsError(t *testing.T) {
@@ -33,11 +35,11 @@ func Test_ShowBasketUseCase_NewShowBasketUseCaseImpl_ReturnsError(t *testing.T)
 			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
 
 			basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
-			basketOutputService := helper.NewBasketOutputService(productRepositoryMock)
+			basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
 
 			useCase := NewShowBasketUseCaseImpl(basketCreatorService, basketOutputService)
 
-			_, err := useCase.Execute(testCase.input)
+			_, err := useCase.Execute(t.Context(), testCase.input)
 
 			require.Error(t, err)
 			require.ErrorContains(t, err, errorString)
@@ -60,10 +62,7 @@ func Test_ShowBasketUseCase(t *testing.T) {
 		ID:    product1ID,
 		Name:  product1Name,
 		Stock: 10,
-		Price: &warehouse.ProductPrice{
-			Value:    13.37,
-			Currency: "EUR",
-		},
+		Price: money.New(1337, "EUR"),
 	}
 
 	basketFactory := entities.NewBasketFactory()
@@ -76,14 +75,14 @@ func Test_ShowBasketUseCase(t *testing.T) {
 
 	userBasket.AddItem(product1ID, 1)
 
-	basketRepositoryMock.EXPECT().FindByUserId(userID).Return(userBasket, nil)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
 
 	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
-	productRepositoryMock.EXPECT().Find(product1ID).Return(product1, nil)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1ID).Return(product1, nil)
 
 	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
 
-	basketOutputService := helper.NewBasketOutputService(productRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
 
 	useCase := NewShowBasketUseCaseImpl(basketCreatorService, basketOutputService)
 
@@ -93,10 +92,48 @@ func Test_ShowBasketUseCase(t *testing.T) {
 
 	// act
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(t.Context(), input)
 
 	// assert
 
 	require.NoError(t, err)
 	require.NotNil(t, output)
 }
+
+func Test_ShowBasketUseCase_PriceChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+
+	product1 := &warehouse.Product{
+		ID:    "1",
+		Name:  "Product 1",
+		Stock: 10,
+		Price: money.New(1400, "EUR"),
+	}
+
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("12345", userID)
+	require.NoError(t, err)
+
+	userBasket.AddItem(product1.ID, 1).SetPrice(money.New(1337, "EUR"))
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1.ID).Return(product1, nil)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewShowBasketUseCaseImpl(basketCreatorService, basketOutputService)
+
+	output, err := useCase.Execute(t.Context(), &ShowBasketUseCaseInput{UserID: userID})
+
+	require.NoError(t, err)
+	require.Contains(t, output.Actions, helper.PriceChangedActionPrefix+product1.ID)
+	require.Equal(t, "13.37", output.UserBasket.Items[0].PreviousPrice.Value)
+}
@@ -1,18 +1,23 @@
 package usecases
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/dto"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
 	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
 )
 
 type UpdateProductCountUseCaseInput struct {
 	UserID    string
 	ProductID string
 	Count     int
+	// ExpectedVersion is the basket version the client has seen, nil changes the current basket
+	ExpectedVersion *int64
 }
 
 type UpdateProductCountUseCaseOutput struct {
@@ -21,25 +26,27 @@ type UpdateProductCountUseCaseOutput struct {
 }
 
 type UpdateProductCountUseCase interface {
-	Execute(input *UpdateProductCountUseCaseInput) (*UpdateProductCountUseCaseOutput, error)
+	Execute(ctx context.Context, input *UpdateProductCountUseCaseInput) (*UpdateProductCountUseCaseOutput, error)
 }
 
-func NewUpdateProductCountImpl(basketService helper.BasketCreatorService, basketOutputService helper.BasketOutputService, basketRepository entities.BasketRepository, productRepository warehouse.ProductRepository) UpdateProductCountUseCase {
+func NewUpdateProductCountImpl(basketService helper.BasketCreatorService, basketOutputService helper.BasketOutputService, basketRepository entities.BasketRepository, productRepository warehouse.ProductRepository, stockReservationService warehousehelper.StockReservationService) UpdateProductCountUseCase {
 	return &UpdateProductCountUseCaseImpl{
-		basketService:       basketService,
-		basketOutputService: basketOutputService,
-		basketRepository:    basketRepository,
-		productRepository:   productRepository,
+		basketService:           basketService,
+		basketOutputService:     basketOutputService,
+		basketRepository:        basketRepository,
+		productRepository:       productRepository,
+		stockReservationService: stockReservationService,
 	}
 }
 
 var _ UpdateProductCountUseCase = (*UpdateProductCountUseCaseImpl)(nil)
 
 type UpdateProductCountUseCaseImpl struct {
-	basketService       helper.BasketCreatorService
-	basketOutputService helper.BasketOutputService
-	basketRepository    entities.BasketRepository
-	productRepository   warehouse.ProductRepository
+	basketService           helper.BasketCreatorService
+	basketOutputService     helper.BasketOutputService
+	basketRepository        entities.BasketRepository
+	productRepository       warehouse.ProductRepository
+	stockReservationService warehousehelper.StockReservationService
 }
 
 func (useCase *UpdateProductCountUseCaseImpl) validate(input *UpdateProductCountUseCaseInput) error {
@@ -56,61 +63,95 @@ func (useCase *UpdateProductCountUseCaseImpl) validate(input *UpdateProductCount
 	return nil
 }
 
-func (useCase *UpdateProductCountUseCaseImpl) Execute(input *UpdateProductCountUseCaseInput) (*UpdateProductCountUseCaseOutput, error) {
+func (useCase *UpdateProductCountUseCaseImpl) Execute(ctx context.Context, input *UpdateProductCountUseCaseInput) (*UpdateProductCountUseCaseOutput, error) {
 	// validate input first
 	err := useCase.validate(input)
 	if err != nil {
-		return nil, fmt.Errorf("input validation error: %w", err)
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
 	}
 
-	userBasket, userBasketErr := useCase.basketService.FindOrCreate(input.UserID)
+	return retryOnVersionConflict(input.ExpectedVersion, func() (*UpdateProductCountUseCaseOutput, error) {
+		return useCase.execute(ctx, input)
+	})
+}
+
+// execute sets the count of the product in the current basket
+func (useCase *UpdateProductCountUseCaseImpl) execute(ctx context.Context, input *UpdateProductCountUseCaseInput) (*UpdateProductCountUseCaseOutput, error) {
+	userBasket, userBasketErr := useCase.basketService.FindOrCreate(ctx, input.UserID)
 	if userBasketErr != nil {
-		return nil, err
+		return nil, userBasketErr
+	}
+
+	versionErr := checkExpectedVersion(userBasket, input.ExpectedVersion)
+	if versionErr != nil {
+		return nil, versionErr
 	}
 
-	product, productRepositoryErr := useCase.productRepository.Find(input.ProductID)
+	product, productRepositoryErr := useCase.productRepository.Find(ctx, input.ProductID)
 	if productRepositoryErr != nil {
 		return nil, productRepositoryErr
 	}
 
-	if product.Stock <= 0 {
-		return nil, fmt.Errorf("product %s is out of stock", input.ProductID)
+	if !product.IsActive() {
+		return nil, warehouse.NewProductDeactivatedError(product.ID)
 	}
 
-	var basketItem *entities.BasketItem
-	if !userBasket.HasItem(input.ProductID) {
-		// add the product with count = 0
-		// because of the following product stock check
-		// which will set the correct count
-		// related to the available stock
-		basketItem = userBasket.AddItem(input.ProductID, 0)
-	} else {
-		basketItem, _ = userBasket.GetItem(input.ProductID)
+	// the units in the baskets of other users are not available, the units of a product with stock policy can be backordered
+	sellableStock, sellableStockErr := useCase.stockReservationService.SellableStock(ctx, input.ProductID, input.UserID)
+	if sellableStockErr != nil {
+		return nil, sellableStockErr
 	}
 
+	if sellableStock <= 0 {
+		return nil, &domainerror.OutOfStockError{ProductID: input.ProductID, Requested: input.Count}
+	}
+
+	storedCount := 0
+	if userBasket.HasItem(input.ProductID) {
+		basketItem, _ := userBasket.GetItem(input.ProductID)
+		storedCount = basketItem.GetCount()
+	}
+
+	count := input.Count
+
 	var actions map[string]string
-	if product.Stock < input.Count {
-		basketItem.SetCount(product.Stock)
+	if sellableStock < count {
 		actions = map[string]string{
-			"product_stock": fmt.Sprintf("Product %s stock is too low to add %d. Updated basket item count to %d.", input.ProductID, input.Count, product.Stock),
+			"product_stock": fmt.Sprintf("Product %s stock is too low to add %d. Updated basket item count to %d.", input.ProductID, input.Count, sellableStock),
 		}
+		count = sellableStock
+	}
+
+	// reserve before changing the basket, so a failed reservation leaves the basket unchanged
+	reserveErr := useCase.stockReservationService.Reserve(ctx, input.UserID, input.ProductID, count)
+	if reserveErr != nil {
+		return nil, reserveErr
+	}
+
+	var basketItem *entities.BasketItem
+	if !userBasket.HasItem(input.ProductID) {
+		basketItem = userBasket.AddItem(input.ProductID, count)
 	} else {
-		basketItem.SetCount(input.Count)
+		basketItem, _ = userBasket.GetItem(input.ProductID)
+		basketItem.SetCount(count)
 	}
 
-	_, basketRepositorySaveErr := useCase.basketRepository.Save(userBasket)
+	// the user accepted the current price by updating the count
+	basketItem.SetPrice(product.Price)
+
+	_, basketRepositorySaveErr := useCase.basketRepository.Save(ctx, userBasket)
 	if basketRepositorySaveErr != nil {
-		return nil, basketRepositorySaveErr
+		return nil, resetReservation(ctx, useCase.basketRepository, useCase.stockReservationService, input.UserID, input.ProductID, storedCount, basketRepositorySaveErr)
 	}
 
-	userBasketDTO, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(userBasket)
+	userBasketDTO, basketOutputActions, basketOutputServiceErr := useCase.basketOutputService.CreateBasketDTO(ctx, userBasket)
 	if basketOutputServiceErr != nil {
 		return nil, basketOutputServiceErr
 	}
 
 	output := &UpdateProductCountUseCaseOutput{
 		UserBasket: userBasketDTO,
-		Actions:    actions,
+		Actions:    mergeActions(actions, basketOutputActions),
 	}
 
 	return output, nil
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -9,6 +10,8 @@ import (
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/usecases/helper"
 	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
 )
 
 func Test_UpdateProductCount_NewUpdateProductCountImpl_ReturnsError(t *testing.T) {
@@ -44,11 +47,13 @@ func Test_UpdateProductCount_NewUpdateProductCountImpl_ReturnsError(t *testing.T
 			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
 
 			basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
-			basketOutputService := helper.NewBasketOutputService(productRepositoryMock)
+			basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
 
-			useCase := NewUpdateProductCountImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock)
+			stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
 
-			_, err := useCase.Execute(testCase.input)
+			useCase := NewUpdateProductCountImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
 
 			require.Error(t, err)
 			require.ErrorContains(t, err, errorString)
@@ -72,10 +77,7 @@ func Test_UpdateProductCountUseCase(t *testing.T) {
 		ID:    product1ID,
 		Name:  product1Name,
 		Stock: 10,
-		Price: &warehouse.ProductPrice{
-			Value:    13.37,
-			Currency: "EUR",
-		},
+		Price: money.New(1337, "EUR"),
 	}
 
 	basketFactory := entities.NewBasketFactory()
@@ -88,19 +90,23 @@ func Test_UpdateProductCountUseCase(t *testing.T) {
 
 	userBasket.AddItem(product1ID, 1)
 
-	basketRepositoryMock.EXPECT().FindByUserId(userID).Return(userBasket, nil)
-	basketRepositoryMock.EXPECT().Save(userBasket).Return(basketID, nil)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+	basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return(basketID, nil)
 
 	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
-	// first the stock check in the usecase
+	// first the price lookup in the usecase
 	// second in the basket output service
-	productRepositoryMock.EXPECT().Find(product1ID).Return(product1, nil).Times(2)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1ID).Return(product1, nil).Times(2)
+
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), product1ID, userID).Return(product1.Stock, nil)
+	stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product1ID, 1).Return(nil)
 
 	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
 
-	basketOutputService := helper.NewBasketOutputService(productRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
 
-	useCase := NewUpdateProductCountImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock)
+	useCase := NewUpdateProductCountImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
 
 	input := &UpdateProductCountUseCaseInput{
 		UserID:    userID,
@@ -110,10 +116,56 @@ func Test_UpdateProductCountUseCase(t *testing.T) {
 
 	// act
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(t.Context(), input)
 
 	// assert
 
 	require.NoError(t, err)
 	require.NotNil(t, output)
 }
+
+func Test_UpdateProductCountUseCase_SaveFailsResetsReservation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+
+	product1 := &warehouse.Product{
+		ID:    "1",
+		Name:  "Product 1",
+		Stock: 10,
+		Price: money.New(1337, "EUR"),
+	}
+
+	basketFactory := entities.NewBasketFactory()
+
+	userBasket, err := basketFactory.NewBasketWithID("12345", userID)
+	require.NoError(t, err)
+
+	basketRepositoryMock := entities.NewMockBasketRepository(ctrl)
+	basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), userID).Return(userBasket, nil)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1.ID).Return(product1, nil)
+
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), product1.ID, userID).Return(10, nil)
+
+	// the stored basket does not contain the product, so the reservation is released again
+	gomock.InOrder(
+		stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product1.ID, 5).Return(nil),
+		basketRepositoryMock.EXPECT().Save(gomock.Any(), userBasket).Return("", errors.New("database is down")),
+		stockReservationServiceMock.EXPECT().Reserve(gomock.Any(), userID, product1.ID, 0).Return(errors.New("ledger is down")),
+	)
+
+	basketCreatorService := helper.NewBasketCreatorServiceImpl(basketFactory, basketRepositoryMock)
+	basketOutputService := helper.NewBasketOutputService(productRepositoryMock, warehousehelper.NewMockStockReservationService(ctrl), newTestPromotionEngine(ctrl), newTestTaxCalculationService(t))
+
+	useCase := NewUpdateProductCountImpl(basketCreatorService, basketOutputService, basketRepositoryMock, productRepositoryMock, stockReservationServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &UpdateProductCountUseCaseInput{UserID: userID, ProductID: product1.ID, Count: 5})
+
+	require.ErrorContains(t, err, "database is down")
+	require.ErrorContains(t, err, "failed to reset the reservation of product 1: ledger is down")
+	require.Nil(t, output)
+}
@@ -0,0 +1,375 @@
+// Package conformance contains the behavior every entities.BasketRepository driver has to implement.
+package conformance
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+// NewBasketRepository returns an empty repository for every test
+type NewBasketRepository func(t *testing.T) entities.BasketRepository
+
+// RunBasketRepositoryTests runs the conformance tests against the repositories of newRepository,
+// every driver calls it from its own test
+func RunBasketRepositoryTests(t *testing.T, newRepository NewBasketRepository) {
+	testCases := map[string]func(t *testing.T, repository entities.BasketRepository){
+		"save nil basket":     testSaveNilBasket,
+		"save new basket":     testSaveNewBasket,
+		"save basket with id": testSaveBasketWithID,
+		"update basket":       testUpdateBasket,
+		"find by user id":     testFindByUserId,
+		"find returns copies": testFindReturnsCopies,
+		"not found":           testNotFound,
+		"one basket per user": testOneBasketPerUser,
+		"version":             testVersion,
+		"stale version":       testStaleVersion,
+		"concurrent saves":    testConcurrentSaves,
+		"concurrent updates":  testConcurrentUpdates,
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testCase(t, newRepository(t))
+		})
+	}
+}
+
+func newBasket(t *testing.T, id string, userID string) *entities.Basket {
+	factory := entities.NewBasketFactory()
+
+	var basket *entities.Basket
+	var basketErr error
+	if id == "" {
+		basket, basketErr = factory.NewBasket(userID)
+	} else {
+		basket, basketErr = factory.NewBasketWithID(id, userID)
+	}
+
+	require.NoError(t, basketErr)
+
+	return basket
+}
+
+func testSaveNilBasket(t *testing.T, repository entities.BasketRepository) {
+	basketID, err := repository.Save(t.Context(), nil)
+
+	require.Error(t, err)
+	require.Empty(t, basketID)
+}
+
+func testSaveNewBasket(t *testing.T, repository entities.BasketRepository) {
+	basket := newBasket(t, "", "1337")
+
+	basketID, err := repository.Save(t.Context(), basket)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, basketID)
+	require.Equal(t, basketID, basket.GetID())
+
+	foundBasket, err := repository.Find(t.Context(), basketID)
+
+	require.NoError(t, err)
+	require.Equal(t, basketID, foundBasket.GetID())
+	require.Equal(t, "1337", foundBasket.GetUserID())
+	require.NotNil(t, foundBasket.GetItems())
+	require.Empty(t, foundBasket.GetItems())
+	require.Empty(t, foundBasket.GetCoupons())
+}
+
+func testSaveBasketWithID(t *testing.T, repository entities.BasketRepository) {
+	basket := newBasket(t, "1", "1337")
+
+	basketID, err := repository.Save(t.Context(), basket)
+
+	require.NoError(t, err)
+	require.Equal(t, "1", basketID)
+
+	foundBasket, err := repository.Find(t.Context(), "1")
+
+	require.NoError(t, err)
+	require.Equal(t, "1", foundBasket.GetID())
+	require.Equal(t, "1337", foundBasket.GetUserID())
+}
+
+func testUpdateBasket(t *testing.T, repository entities.BasketRepository) {
+	basket := newBasket(t, "1", "1337")
+
+	_, err := repository.Save(t.Context(), basket)
+
+	require.NoError(t, err)
+
+	basketItem := basket.AddItem("A12345", 2)
+	basketItem.SetPrice(money.New(1999, "EUR"))
+	basket.AddItem("A12346", 1)
+	basket.AddCoupon("TEN")
+	basket.AddCoupon("FIVE")
+
+	basketID, err := repository.Save(t.Context(), basket)
+
+	require.NoError(t, err)
+	require.Equal(t, "1", basketID)
+
+	foundBasket, err := repository.Find(t.Context(), "1")
+
+	require.NoError(t, err)
+	require.Len(t, foundBasket.GetItems(), 2)
+
+	foundBasketItem, err := foundBasket.GetItem("A12345")
+
+	require.NoError(t, err)
+	require.Equal(t, 2, foundBasketItem.GetCount())
+	require.Equal(t, money.New(1999, "EUR"), foundBasketItem.GetPrice())
+	require.Equal(t, []string{"TEN", "FIVE"}, foundBasket.GetCoupons())
+
+	require.NoError(t, foundBasket.RemoveItem("A12346"))
+	require.NoError(t, foundBasket.RemoveCoupon("TEN"))
+
+	_, err = repository.Save(t.Context(), foundBasket)
+
+	require.NoError(t, err)
+
+	foundBasket, err = repository.Find(t.Context(), "1")
+
+	require.NoError(t, err)
+	require.Len(t, foundBasket.GetItems(), 1)
+	require.True(t, foundBasket.HasItem("A12345"))
+	require.Equal(t, []string{"FIVE"}, foundBasket.GetCoupons())
+}
+
+func testFindByUserId(t *testing.T, repository entities.BasketRepository) {
+	_, err := repository.Save(t.Context(), newBasket(t, "1", "1337"))
+
+	require.NoError(t, err)
+
+	_, err = repository.Save(t.Context(), newBasket(t, "2", "1338"))
+
+	require.NoError(t, err)
+
+	foundBasket, err := repository.FindByUserId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, "1", foundBasket.GetID())
+	require.Equal(t, "1337", foundBasket.GetUserID())
+
+	foundBasket, err = repository.FindByUserId(t.Context(), "1338")
+
+	require.NoError(t, err)
+	require.Equal(t, "2", foundBasket.GetID())
+}
+
+func testFindReturnsCopies(t *testing.T, repository entities.BasketRepository) {
+	basket := newBasket(t, "1", "1337")
+	basket.AddItem("A12345", 1)
+	basket.AddCoupon("TEN")
+
+	_, err := repository.Save(t.Context(), basket)
+
+	require.NoError(t, err)
+
+	// changes without calling Save are not stored
+	basket.AddItem("A12345", 1)
+
+	foundBasket, err := repository.Find(t.Context(), "1")
+
+	require.NoError(t, err)
+
+	foundBasket.AddItem("A12346", 1)
+	foundBasket.AddCoupon("FIVE")
+
+	foundBasket, err = repository.FindByUserId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Len(t, foundBasket.GetItems(), 1)
+	require.Equal(t, 1, foundBasket.GetItems()["A12345"].GetCount())
+	require.Equal(t, []string{"TEN"}, foundBasket.GetCoupons())
+}
+
+func testNotFound(t *testing.T, repository entities.BasketRepository) {
+	_, err := repository.Save(t.Context(), newBasket(t, "1", "1337"))
+
+	require.NoError(t, err)
+
+	foundBasket, err := repository.Find(t.Context(), "2")
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+	require.True(t, domainerror.IsNotFound(err, entities.BasketResource))
+	require.Nil(t, foundBasket)
+
+	foundBasket, err = repository.FindByUserId(t.Context(), "1338")
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+	require.True(t, domainerror.IsNotFound(err, entities.BasketResource))
+	require.Nil(t, foundBasket)
+}
+
+func testOneBasketPerUser(t *testing.T, repository entities.BasketRepository) {
+	_, err := repository.Save(t.Context(), newBasket(t, "1", "1337"))
+
+	require.NoError(t, err)
+
+	basketID, err := repository.Save(t.Context(), newBasket(t, "2", "1337"))
+
+	require.ErrorAs(t, err, new(*domainerror.ConflictError))
+	require.Empty(t, basketID)
+
+	_, err = repository.Find(t.Context(), "2")
+
+	require.True(t, domainerror.IsNotFound(err, entities.BasketResource))
+
+	foundBasket, err := repository.FindByUserId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, "1", foundBasket.GetID())
+}
+
+func testConcurrentSaves(t *testing.T, repository entities.BasketRepository) {
+	const users = 10
+
+	var wg sync.WaitGroup
+	for i := 0; i < users; i++ {
+		wg.Add(1)
+		go func(userID string) {
+			defer wg.Done()
+
+			basket := newBasket(t, "", userID)
+
+			_, saveErr := repository.Save(t.Context(), basket)
+			assert.NoError(t, saveErr)
+
+			basket.AddItem("A12345", 1)
+
+			_, saveErr = repository.Save(t.Context(), basket)
+			assert.NoError(t, saveErr)
+		}(fmt.Sprintf("user-%d", i))
+	}
+	wg.Wait()
+
+	for i := 0; i < users; i++ {
+		foundBasket, err := repository.FindByUserId(t.Context(), fmt.Sprintf("user-%d", i))
+
+		require.NoError(t, err)
+		require.Len(t, foundBasket.GetItems(), 1)
+	}
+}
+
+func testVersion(t *testing.T, repository entities.BasketRepository) {
+	basket := newBasket(t, "1", "1337")
+
+	require.Equal(t, int64(0), basket.GetVersion())
+
+	_, err := repository.Save(t.Context(), basket)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(1), basket.GetVersion())
+
+	basket.AddItem("A12345", 1)
+
+	_, err = repository.Save(t.Context(), basket)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), basket.GetVersion())
+
+	foundBasket, err := repository.Find(t.Context(), "1")
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), foundBasket.GetVersion())
+
+	foundBasket, err = repository.FindByUserId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), foundBasket.GetVersion())
+}
+
+func testStaleVersion(t *testing.T, repository entities.BasketRepository) {
+	_, err := repository.Save(t.Context(), newBasket(t, "1", "1337"))
+
+	require.NoError(t, err)
+
+	firstBasket, err := repository.Find(t.Context(), "1")
+
+	require.NoError(t, err)
+
+	secondBasket, err := repository.Find(t.Context(), "1")
+
+	require.NoError(t, err)
+
+	firstBasket.AddItem("A12345", 1)
+
+	_, err = repository.Save(t.Context(), firstBasket)
+
+	require.NoError(t, err)
+
+	secondBasket.AddItem("A12346", 1)
+
+	basketID, err := repository.Save(t.Context(), secondBasket)
+
+	var versionConflictErr *domainerror.VersionConflictError
+	require.ErrorAs(t, err, &versionConflictErr)
+	require.Equal(t, entities.BasketResource, versionConflictErr.Resource)
+	require.Equal(t, "1", versionConflictErr.ID)
+	require.Equal(t, int64(1), versionConflictErr.Version)
+	require.Empty(t, basketID)
+	require.Equal(t, int64(1), secondBasket.GetVersion())
+
+	// a new basket with the id of a stored basket is stale as well
+	_, err = repository.Save(t.Context(), newBasket(t, "1", "1337"))
+
+	require.True(t, domainerror.IsVersionConflict(err))
+
+	foundBasket, err := repository.Find(t.Context(), "1")
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), foundBasket.GetVersion())
+	require.True(t, foundBasket.HasItem("A12345"))
+	require.False(t, foundBasket.HasItem("A12346"))
+}
+
+func testConcurrentUpdates(t *testing.T, repository entities.BasketRepository) {
+	_, err := repository.Save(t.Context(), newBasket(t, "1", "1337"))
+
+	require.NoError(t, err)
+
+	const updates = 10
+
+	var successes atomic.Int64
+	var wg sync.WaitGroup
+	for i := 1; i <= updates; i++ {
+		wg.Add(1)
+		go func(productID string) {
+			defer wg.Done()
+
+			basket, findErr := repository.Find(t.Context(), "1")
+			if !assert.NoError(t, findErr) {
+				return
+			}
+
+			basket.AddItem(productID, 1)
+
+			// only one of the saves of the same version succeeds, the others have to read the basket again
+			_, saveErr := repository.Save(t.Context(), basket)
+			if saveErr == nil {
+				successes.Add(1)
+			} else {
+				assert.True(t, domainerror.IsVersionConflict(saveErr), saveErr)
+			}
+		}(fmt.Sprintf("A%d", i))
+	}
+	wg.Wait()
+
+	// every successful save contains the items of all previous saves, so no update is lost
+	foundBasket, err := repository.Find(t.Context(), "1")
+
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, successes.Load(), int64(1))
+	require.Equal(t, 1+successes.Load(), foundBasket.GetVersion())
+	require.Len(t, foundBasket.GetItems(), int(successes.Load()))
+}
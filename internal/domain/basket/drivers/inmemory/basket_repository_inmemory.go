@@ -1,16 +1,22 @@
 package inmemory
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
 	"github.com/google/uuid"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
 )
 
 var _ entities.BasketRepository = (*InMemoryBasketRepository)(nil)
 
+// InMemoryBasketRepository stores copies of the baskets,
+// so like with the mongodb driver a basket only changes by calling Save
 type InMemoryBasketRepository struct {
+	mutex   sync.RWMutex
 	baskets map[string]*entities.Basket
 }
 
@@ -20,7 +26,7 @@ func NewInMemoryBasketRepository() entities.BasketRepository {
 	}
 }
 
-func (repository *InMemoryBasketRepository) Save(basket *entities.Basket) (string, error) {
+func (repository *InMemoryBasketRepository) Save(ctx context.Context, basket *entities.Basket) (string, error) {
 	if basket == nil {
 		return "", fmt.Errorf("basket is nil")
 	}
@@ -29,26 +35,72 @@ func (repository *InMemoryBasketRepository) Save(basket *entities.Basket) (strin
 		basket.SetID(uuid.NewString())
 	}
 
-	repository.baskets[basket.GetID()] = basket
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	for _, otherBasket := range repository.baskets {
+		if otherBasket.GetUserID() == basket.GetUserID() && otherBasket.GetID() != basket.GetID() {
+			return "", entities.NewUserHasBasketError(basket.GetUserID())
+		}
+	}
+
+	storedVersion := int64(0)
+	if storedBasket, basketExists := repository.baskets[basket.GetID()]; basketExists {
+		storedVersion = storedBasket.GetVersion()
+	}
+	if storedVersion != basket.GetVersion() {
+		return "", entities.NewVersionConflictError(basket)
+	}
+
+	basket.Version++
+	repository.baskets[basket.GetID()] = copyBasket(basket)
 
 	return basket.GetID(), nil
 }
 
-func (repository *InMemoryBasketRepository) Find(id string) (*entities.Basket, error) {
+func (repository *InMemoryBasketRepository) Find(ctx context.Context, id string) (*entities.Basket, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
 	basket, basketExists := repository.baskets[id]
 	if !basketExists {
-		return nil, fmt.Errorf("basket not found")
+		return nil, &domainerror.NotFoundError{Resource: entities.BasketResource, ID: id}
 	}
 
-	return basket, nil
+	return copyBasket(basket), nil
 }
 
-func (repository *InMemoryBasketRepository) FindByUserId(userId string) (*entities.Basket, error) {
+func (repository *InMemoryBasketRepository) FindByUserId(ctx context.Context, userId string) (*entities.Basket, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
 	for _, basket := range repository.baskets {
 		if basket.GetUserID() == userId {
-			return basket, nil
+			return copyBasket(basket), nil
 		}
 	}
 
-	return nil, &entities.BasketNotFoundError{}
+	return nil, &domainerror.NotFoundError{Resource: entities.BasketResource}
+}
+
+func copyBasket(basket *entities.Basket) *entities.Basket {
+	basketCopy := &entities.Basket{
+		Id:      basket.Id,
+		UserID:  basket.UserID,
+		Version: basket.Version,
+	}
+
+	if basket.Items != nil {
+		basketCopy.Items = make(map[string]*entities.BasketItem, len(basket.Items))
+		for productID, basketItem := range basket.Items {
+			basketItemCopy := *basketItem
+			basketCopy.Items[productID] = &basketItemCopy
+		}
+	}
+
+	if basket.Coupons != nil {
+		basketCopy.Coupons = append([]string{}, basket.Coupons...)
+	}
+
+	return basketCopy
 }
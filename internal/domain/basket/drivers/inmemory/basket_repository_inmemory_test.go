@@ -1,13 +1,23 @@
 package inmemory
 
 import (
+	"strconv"
+	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/drivers/conformance"
 )
 
+func Test_InMemoryBasketRepository_Conformance(t *testing.T) {
+	conformance.RunBasketRepositoryTests(t, func(t *testing.T) entities.BasketRepository {
+		return NewInMemoryBasketRepository()
+	})
+}
+
 func Test_InMemoryBasketRepository_Find(t *testing.T) {
 	repository := NewInMemoryBasketRepository()
 
@@ -16,7 +26,7 @@ func Test_InMemoryBasketRepository_Find(t *testing.T) {
 	basketID := "1"
 	userID := "1337"
 
-	basket, err := repository.Find(basketID)
+	basket, err := repository.Find(t.Context(), basketID)
 
 	require.Error(t, err)
 	require.Nil(t, basket)
@@ -27,12 +37,12 @@ func Test_InMemoryBasketRepository_Find(t *testing.T) {
 
 	require.NoError(t, basketErr)
 
-	repositoryBasketID, repositoryErr := repository.Save(basket)
+	repositoryBasketID, repositoryErr := repository.Save(t.Context(), basket)
 
 	require.Nil(t, repositoryErr)
 	require.Equal(t, basketID, repositoryBasketID)
 
-	basket, err = repository.Find(basketID)
+	basket, err = repository.Find(t.Context(), basketID)
 
 	require.NoError(t, err)
 	require.NotNil(t, basket)
@@ -50,7 +60,7 @@ func Test_InMemoryBasketRepository_FindByUserId(t *testing.T) {
 	basketID := "1"
 	userID := "1337"
 
-	basket, err := repository.FindByUserId(userID)
+	basket, err := repository.FindByUserId(t.Context(), userID)
 
 	require.Error(t, err)
 	require.Nil(t, basket)
@@ -61,12 +71,12 @@ func Test_InMemoryBasketRepository_FindByUserId(t *testing.T) {
 
 	require.NoError(t, basketErr)
 
-	repositoryBasketID, repositoryErr := repository.Save(basket)
+	repositoryBasketID, repositoryErr := repository.Save(t.Context(), basket)
 
 	require.Nil(t, repositoryErr)
 	require.Equal(t, basketID, repositoryBasketID)
 
-	basket, err = repository.FindByUserId(userID)
+	basket, err = repository.FindByUserId(t.Context(), userID)
 
 	require.NoError(t, err)
 	require.NotNil(t, basket)
@@ -83,7 +93,7 @@ func Test_InMemoryBasketRepository_SaveNewBasket(t *testing.T) {
 
 	userID := "1337"
 
-	basket, err := repository.FindByUserId(userID)
+	basket, err := repository.FindByUserId(t.Context(), userID)
 
 	require.Error(t, err)
 	require.Nil(t, basket)
@@ -94,12 +104,12 @@ func Test_InMemoryBasketRepository_SaveNewBasket(t *testing.T) {
 
 	require.NoError(t, basketErr)
 
-	repositoryBasketID, repositoryErr := repository.Save(basket)
+	repositoryBasketID, repositoryErr := repository.Save(t.Context(), basket)
 
 	require.Nil(t, repositoryErr)
 	require.NotEmpty(t, repositoryBasketID)
 
-	basket, err = repository.FindByUserId(userID)
+	basket, err = repository.FindByUserId(t.Context(), userID)
 
 	require.NoError(t, err)
 	require.NotNil(t, basket)
@@ -108,3 +118,84 @@ func Test_InMemoryBasketRepository_SaveNewBasket(t *testing.T) {
 	require.NotNil(t, basket.GetItems())
 	require.Empty(t, basket.GetItems())
 }
+
+func Test_InMemoryBasketRepository_StoresCopies(t *testing.T) {
+	repository := NewInMemoryBasketRepository()
+
+	basket, basketErr := entities.NewBasketFactory().NewBasketWithID("1", "1337")
+
+	require.NoError(t, basketErr)
+
+	basket.AddItem("A12345", 1)
+	basket.AddCoupon("TEN")
+
+	_, repositoryErr := repository.Save(t.Context(), basket)
+
+	require.NoError(t, repositoryErr)
+
+	// changes of the saved basket are not stored without calling Save
+	basket.AddItem("A12345", 1)
+	basket.AddItem("A12346", 1)
+	basket.AddCoupon("FIVE")
+
+	foundBasket, err := repository.Find(t.Context(), "1")
+
+	require.NoError(t, err)
+	require.Len(t, foundBasket.GetItems(), 1)
+	require.Equal(t, 1, foundBasket.GetItems()["A12345"].GetCount())
+	require.Equal(t, []string{"TEN"}, foundBasket.GetCoupons())
+
+	// changes of a found basket are not stored without calling Save
+	foundBasket.GetItems()["A12345"].SetCount(5)
+	foundBasket.Clear()
+
+	foundBasket, err = repository.FindByUserId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Len(t, foundBasket.GetItems(), 1)
+	require.Equal(t, 1, foundBasket.GetItems()["A12345"].GetCount())
+	require.Equal(t, []string{"TEN"}, foundBasket.GetCoupons())
+}
+
+func Test_InMemoryBasketRepository_ConcurrentAccess(t *testing.T) {
+	repository := NewInMemoryBasketRepository()
+
+	factory := entities.NewBasketFactory()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(userID string) {
+			defer wg.Done()
+
+			basket, basketErr := factory.NewBasket(userID)
+			if !assert.NoError(t, basketErr) {
+				return
+			}
+
+			for j := 0; j < 10; j++ {
+				basket.AddItem("A12345", 1)
+
+				_, saveErr := repository.Save(t.Context(), basket)
+				assert.NoError(t, saveErr)
+
+				foundBasket, findErr := repository.FindByUserId(t.Context(), userID)
+				if assert.NoError(t, findErr) {
+					foundBasket.AddItem("A12346", 1)
+				}
+
+				_, findErr = repository.Find(t.Context(), basket.GetID())
+				assert.NoError(t, findErr)
+			}
+		}(strconv.Itoa(i))
+	}
+	wg.Wait()
+
+	for i := 0; i < 20; i++ {
+		basket, err := repository.FindByUserId(t.Context(), strconv.Itoa(i))
+
+		require.NoError(t, err)
+		require.Len(t, basket.GetItems(), 1)
+		require.Equal(t, 10, basket.GetItems()["A12345"].GetCount())
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
 )
 
 const (
@@ -23,13 +26,38 @@ type MongoBasketRepository struct {
 	collection *mongo.Collection
 }
 
-func NewMongoBasketRepository(collection *mongo.Collection) entities.BasketRepository {
+// NewMongoBasketRepository creates the unique indexes of the collection, so it needs a connection to the database.
+// Creating the indexes fails if the collection contains duplicate baskets,
+// they are removed by the migration docs/migrations/mongodb/0001_deduplicate_baskets.js.
+func NewMongoBasketRepository(ctx context.Context, collection *mongo.Collection) (entities.BasketRepository, error) {
+	if collection == nil {
+		return nil, fmt.Errorf("collection is nil")
+	}
+
+	_, indexErr := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetName("id_unique").SetUnique(true),
+		},
+		{
+			// every user has exactly one basket
+			Keys:    bson.D{{Key: "userid", Value: 1}},
+			Options: options.Index().SetName("userid_unique").SetUnique(true),
+		},
+	})
+	if indexErr != nil {
+		return nil, fmt.Errorf("failed to create the indexes of collection %s: %w", collection.Name(), indexErr)
+	}
+
 	return &MongoBasketRepository{
 		collection: collection,
-	}
+	}, nil
 }
 
-func (repository *MongoBasketRepository) Save(basket *entities.Basket) (string, error) {
+// Save is an atomic upsert of the basket with the same id and version.
+// If the stored basket has another version, the upsert tries to insert a second basket with the id,
+// which is rejected by the unique index on the id.
+func (repository *MongoBasketRepository) Save(ctx context.Context, basket *entities.Basket) (string, error) {
 	if basket == nil {
 		return "", fmt.Errorf("basket is nil")
 	}
@@ -38,26 +66,35 @@ func (repository *MongoBasketRepository) Save(basket *entities.Basket) (string,
 		basket.SetID(uuid.NewString())
 	}
 
-	result, replaceErr := repository.collection.ReplaceOne(context.Background(), bson.M{"id": basket.GetID()}, basket)
-	if replaceErr != nil {
-		return "", replaceErr
+	filter := bson.M{"id": basket.GetID(), "version": basket.GetVersion()}
+	if basket.GetVersion() == 0 {
+		// the baskets stored before the version was added have no version field
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
 	}
 
-	if result.MatchedCount == 0 {
-		_, err := repository.collection.InsertOne(context.Background(), basket)
-		if err != nil {
-			return "", err
+	storedBasket := *basket
+	storedBasket.Version++
+
+	_, replaceErr := repository.collection.ReplaceOne(ctx, filter, &storedBasket, options.Replace().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(replaceErr) {
+		if strings.Contains(replaceErr.Error(), "userid_unique") {
+			return "", entities.NewUserHasBasketError(basket.GetUserID())
 		}
+		return "", entities.NewVersionConflictError(basket)
+	} else if replaceErr != nil {
+		return "", replaceErr
 	}
 
+	basket.Version = storedBasket.Version
+
 	return basket.GetID(), nil
 }
 
-func (repository *MongoBasketRepository) Find(id string) (*entities.Basket, error) {
-	result := repository.collection.FindOne(context.Background(), bson.M{"id": id})
+func (repository *MongoBasketRepository) Find(ctx context.Context, id string) (*entities.Basket, error) {
+	result := repository.collection.FindOne(ctx, bson.M{"id": id})
 	if result.Err() != nil {
 		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
-			return nil, &entities.BasketNotFoundError{}
+			return nil, &domainerror.NotFoundError{Resource: entities.BasketResource, ID: id}
 		}
 		return nil, result.Err()
 	}
@@ -71,11 +108,11 @@ func (repository *MongoBasketRepository) Find(id string) (*entities.Basket, erro
 	return &basket, nil
 }
 
-func (repository *MongoBasketRepository) FindByUserId(userId string) (*entities.Basket, error) {
-	result := repository.collection.FindOne(context.Background(), bson.M{"userid": userId})
+func (repository *MongoBasketRepository) FindByUserId(ctx context.Context, userId string) (*entities.Basket, error) {
+	result := repository.collection.FindOne(ctx, bson.M{"userid": userId})
 	if result.Err() != nil {
 		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
-			return nil, &entities.BasketNotFoundError{}
+			return nil, &domainerror.NotFoundError{Resource: entities.BasketResource}
 		}
 
 		return nil, result.Err()
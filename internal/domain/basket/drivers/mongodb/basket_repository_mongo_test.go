@@ -8,10 +8,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/mongodb"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/drivers/conformance"
 )
 
 func initTestcontainers(t *testing.T) (string, func()) {
@@ -34,6 +36,32 @@ func initTestcontainers(t *testing.T) (string, func()) {
 
 	return endpoint, stop
 }
+
+func Test_MongoBasketRepository_Conformance(t *testing.T) {
+	endpoint, stop := initTestcontainers(t)
+	defer stop()
+
+	clientOpts := options.Client().ApplyURI(endpoint)
+	mongoClient, mongoClientErr := mongo.Connect(clientOpts)
+	require.NoError(t, mongoClientErr)
+	defer func() {
+		if mongoClientErr = mongoClient.Disconnect(context.TODO()); mongoClientErr != nil {
+			panic(mongoClientErr)
+		}
+	}()
+
+	conformance.RunBasketRepositoryTests(t, func(t *testing.T) entities.BasketRepository {
+		// every test gets an empty collection
+		basketsCollection := mongoClient.Database(DatabaseName).Collection(BasketsCollectionName)
+		require.NoError(t, basketsCollection.Drop(context.Background()))
+
+		repository, err := NewMongoBasketRepository(t.Context(), basketsCollection)
+		require.NoError(t, err)
+
+		return repository
+	})
+}
+
 func Test_MongoBasketRepository(t *testing.T) {
 	endpoint, stop := initTestcontainers(t)
 	defer stop()
@@ -50,9 +78,11 @@ func Test_MongoBasketRepository(t *testing.T) {
 	}()
 
 	basketsCollection := mongoClient.Database(DatabaseName).Collection(BasketsCollectionName)
-	repository := NewMongoBasketRepository(basketsCollection)
+	repository, err := NewMongoBasketRepository(t.Context(), basketsCollection)
 
-	foundBasket1, err := repository.Find("1")
+	require.NoError(t, err)
+
+	foundBasket1, err := repository.Find(t.Context(), "1")
 
 	require.Error(t, err)
 	require.Nil(t, foundBasket1)
@@ -66,12 +96,12 @@ func Test_MongoBasketRepository(t *testing.T) {
 	require.NoError(t, basketErr)
 	require.NotNil(t, basket)
 
-	returnedBasketId, err := repository.Save(basket)
+	returnedBasketId, err := repository.Save(t.Context(), basket)
 
 	require.NoError(t, err)
 	require.NotEmpty(t, returnedBasketId)
 
-	foundBasket, err := repository.Find(basketId)
+	foundBasket, err := repository.Find(t.Context(), basketId)
 
 	require.NoError(t, err)
 	require.NotNil(t, foundBasket)
@@ -80,20 +110,73 @@ func Test_MongoBasketRepository(t *testing.T) {
 
 	_ = basket.AddItem("A12345", 1)
 
-	basketId, err = repository.Save(basket)
+	basketId, err = repository.Save(t.Context(), basket)
 
 	require.NoError(t, err)
 	require.NotEmpty(t, basketId)
 
-	foundBasket2, err := repository.FindByUserId("1336")
+	foundBasket2, err := repository.FindByUserId(t.Context(), "1336")
 
 	require.Error(t, err)
 	require.Nil(t, foundBasket2)
 
-	foundBasket3, err := repository.FindByUserId(userID)
+	foundBasket3, err := repository.FindByUserId(t.Context(), userID)
 
 	require.NoError(t, err)
 	require.NotNil(t, foundBasket3)
 	require.Equal(t, basket, foundBasket3)
 	require.Len(t, foundBasket3.GetItems(), 1)
 }
+
+func Test_MongoBasketRepository_NewMongoBasketRepository_ReturnsError(t *testing.T) {
+	repository, err := NewMongoBasketRepository(t.Context(), nil)
+
+	require.Error(t, err)
+	require.Nil(t, repository)
+}
+
+func Test_MongoBasketRepository_Indexes(t *testing.T) {
+	endpoint, stop := initTestcontainers(t)
+	defer stop()
+
+	clientOpts := options.Client().ApplyURI(endpoint)
+	mongoClient, mongoClientErr := mongo.Connect(clientOpts)
+	require.NoError(t, mongoClientErr)
+	defer func() {
+		if mongoClientErr = mongoClient.Disconnect(context.TODO()); mongoClientErr != nil {
+			panic(mongoClientErr)
+		}
+	}()
+
+	basketsCollection := mongoClient.Database(DatabaseName).Collection(BasketsCollectionName)
+
+	// documents stored before the indexes existed, the user 1337 has two baskets
+	_, err := basketsCollection.InsertMany(t.Context(), []any{
+		bson.M{"id": "1", "userid": "1337", "items": bson.M{}},
+		bson.M{"id": "2", "userid": "1337", "items": bson.M{}},
+	})
+	require.NoError(t, err)
+
+	repository, err := NewMongoBasketRepository(t.Context(), basketsCollection)
+
+	require.ErrorContains(t, err, "failed to create the indexes")
+	require.Nil(t, repository)
+
+	_, err = basketsCollection.DeleteOne(t.Context(), bson.M{"id": "2"})
+	require.NoError(t, err)
+
+	repository, err = NewMongoBasketRepository(t.Context(), basketsCollection)
+
+	require.NoError(t, err)
+
+	// the documents without bson tags are still found, because the tags use the same field names
+	foundBasket, err := repository.FindByUserId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, "1", foundBasket.GetID())
+
+	// the indexes reject a second document with the same id
+	_, err = basketsCollection.InsertOne(t.Context(), bson.M{"id": "1", "userid": "1338"})
+
+	require.True(t, mongo.IsDuplicateKeyError(err))
+}
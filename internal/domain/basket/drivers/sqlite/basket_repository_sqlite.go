@@ -0,0 +1,229 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/sqlite"
+)
+
+// MigrationComponent prefixes the migrations of the baskets in the schema_migrations table
+const MigrationComponent = "basket"
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Migrate creates or updates the tables of the baskets
+func Migrate(ctx context.Context, db *sql.DB) error {
+	return sqlite.Migrate(ctx, db, MigrationComponent, migrations)
+}
+
+var _ entities.BasketRepository = (*SQLiteBasketRepository)(nil)
+
+// SQLiteBasketRepository stores a basket in the baskets table and its items and coupons in their own tables,
+// the tables are created by Migrate
+type SQLiteBasketRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteBasketRepository(db *sql.DB) entities.BasketRepository {
+	return &SQLiteBasketRepository{
+		db: db,
+	}
+}
+
+// Save replaces the items and coupons of the basket in one transaction,
+// if the stored version still equals the version of the basket
+func (repository *SQLiteBasketRepository) Save(ctx context.Context, basket *entities.Basket) (string, error) {
+	if basket == nil {
+		return "", fmt.Errorf("basket is nil")
+	}
+
+	if basket.GetID() == "" {
+		basket.SetID(uuid.NewString())
+	}
+
+	saveErr := repository.save(ctx, basket)
+	if saveErr != nil {
+		return "", saveErr
+	}
+
+	return basket.GetID(), nil
+}
+
+func (repository *SQLiteBasketRepository) save(ctx context.Context, basket *entities.Basket) (err error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		// keep the typed error, e.g. a conflict, if the rollback succeeds
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			err = errors.Join(err, rollbackErr)
+		}
+	}()
+
+	// the unique index on user_id would reject the basket as well, but without a typed error
+	var otherBaskets int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM baskets WHERE user_id = ? AND id <> ?", basket.GetUserID(), basket.GetID()).Scan(&otherBaskets)
+	if err != nil {
+		return err
+	}
+	if otherBaskets > 0 {
+		return entities.NewUserHasBasketError(basket.GetUserID())
+	}
+
+	var storedVersion int64
+	err = tx.QueryRowContext(ctx, "SELECT version FROM baskets WHERE id = ?", basket.GetID()).Scan(&storedVersion)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if storedVersion != basket.GetVersion() {
+		return entities.NewVersionConflictError(basket)
+	}
+
+	newVersion := basket.GetVersion() + 1
+	_, err = tx.ExecContext(ctx, "INSERT INTO baskets (id, user_id, version) VALUES (?, ?, ?) ON CONFLICT (id) DO UPDATE SET user_id = excluded.user_id, version = excluded.version",
+		basket.GetID(), basket.GetUserID(), newVersion)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM basket_items WHERE basket_id = ?", basket.GetID())
+	if err != nil {
+		return err
+	}
+
+	for _, basketItem := range basket.GetItems() {
+		_, err = tx.ExecContext(ctx, "INSERT INTO basket_items (basket_id, product_id, count, price_amount, price_currency) VALUES (?, ?, ?, ?, ?)",
+			basket.GetID(), basketItem.GetProductID(), basketItem.GetCount(), basketItem.GetPrice().GetAmount(), basketItem.GetPrice().GetCurrency())
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM basket_coupons WHERE basket_id = ?", basket.GetID())
+	if err != nil {
+		return err
+	}
+
+	for position, code := range basket.GetCoupons() {
+		_, err = tx.ExecContext(ctx, "INSERT INTO basket_coupons (basket_id, position, code) VALUES (?, ?, ?)", basket.GetID(), position, code)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	basket.Version = newVersion
+
+	return nil
+}
+
+func (repository *SQLiteBasketRepository) Find(ctx context.Context, id string) (*entities.Basket, error) {
+	return repository.findOne(ctx, "SELECT id, user_id, version FROM baskets WHERE id = ?", id, &domainerror.NotFoundError{Resource: entities.BasketResource, ID: id})
+}
+
+func (repository *SQLiteBasketRepository) FindByUserId(ctx context.Context, userId string) (*entities.Basket, error) {
+	return repository.findOne(ctx, "SELECT id, user_id, version FROM baskets WHERE user_id = ? ORDER BY rowid LIMIT 1", userId, &domainerror.NotFoundError{Resource: entities.BasketResource})
+}
+
+// findOne reads the basket with its items and coupons in one transaction, so it never sees a half saved basket
+func (repository *SQLiteBasketRepository) findOne(ctx context.Context, query string, arg string, notFoundErr error) (basket *entities.Basket, err error) {
+	tx, err := repository.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// the transaction only reads, so it is always rolled back
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			err = errors.Join(err, rollbackErr)
+		}
+	}()
+
+	basket = &entities.Basket{
+		Items: map[string]*entities.BasketItem{},
+	}
+
+	err = tx.QueryRowContext(ctx, query, arg).Scan(&basket.Id, &basket.UserID, &basket.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, notFoundErr
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = readItems(ctx, tx, basket)
+	if err != nil {
+		return nil, err
+	}
+
+	err = readCoupons(ctx, tx, basket)
+	if err != nil {
+		return nil, err
+	}
+
+	return basket, nil
+}
+
+func readItems(ctx context.Context, tx *sql.Tx, basket *entities.Basket) error {
+	rows, err := tx.QueryContext(ctx, "SELECT product_id, count, price_amount, price_currency FROM basket_items WHERE basket_id = ?", basket.GetID())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var basketItem entities.BasketItem
+		var priceAmount int64
+		var priceCurrency string
+
+		err = rows.Scan(&basketItem.ProductID, &basketItem.Count, &priceAmount, &priceCurrency)
+		if err != nil {
+			return err
+		}
+		basketItem.Price = money.New(priceAmount, priceCurrency)
+
+		basket.Items[basketItem.ProductID] = &basketItem
+	}
+
+	return rows.Err()
+}
+
+func readCoupons(ctx context.Context, tx *sql.Tx, basket *entities.Basket) error {
+	rows, err := tx.QueryContext(ctx, "SELECT code FROM basket_coupons WHERE basket_id = ? ORDER BY position", basket.GetID())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var code string
+
+		err = rows.Scan(&code)
+		if err != nil {
+			return err
+		}
+
+		basket.Coupons = append(basket.Coupons, code)
+	}
+
+	return rows.Err()
+}
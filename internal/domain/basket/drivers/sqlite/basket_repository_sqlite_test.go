@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/drivers/conformance"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/sqlite"
+)
+
+func Test_SQLiteBasketRepository_Conformance(t *testing.T) {
+	conformance.RunBasketRepositoryTests(t, func(t *testing.T) entities.BasketRepository {
+		// every test gets an empty database
+		db, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, db.Close())
+		})
+
+		require.NoError(t, Migrate(t.Context(), db))
+
+		return NewSQLiteBasketRepository(db)
+	})
+}
+
+func Test_SQLiteBasketRepository_StoresBasketAfterReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := sqlite.Open(path)
+	require.NoError(t, err)
+	require.NoError(t, Migrate(t.Context(), db))
+
+	basket, err := entities.NewBasketFactory().NewBasketWithID("1", "1337")
+	require.NoError(t, err)
+	basket.AddItem("A12345", 2)
+	basket.AddCoupon("TEN")
+
+	_, err = NewSQLiteBasketRepository(db).Save(t.Context(), basket)
+
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = sqlite.Open(path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// the migrations were already applied
+	require.NoError(t, Migrate(t.Context(), db))
+
+	foundBasket, err := NewSQLiteBasketRepository(db).FindByUserId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, basket, foundBasket)
+}
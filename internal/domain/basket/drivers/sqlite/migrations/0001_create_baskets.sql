@@ -0,0 +1,25 @@
+CREATE TABLE baskets (
+    id      TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL
+);
+
+CREATE INDEX baskets_user_id ON baskets (user_id);
+
+-- the items are normalized, one row per product of a basket
+CREATE TABLE basket_items (
+    basket_id      TEXT    NOT NULL REFERENCES baskets (id) ON DELETE CASCADE,
+    product_id     TEXT    NOT NULL,
+    count          INTEGER NOT NULL,
+    -- the price in the minor unit of the currency at the time the item was added
+    price_amount   INTEGER NOT NULL,
+    price_currency TEXT    NOT NULL,
+    PRIMARY KEY (basket_id, product_id)
+);
+
+-- position keeps the order in which the coupons were applied
+CREATE TABLE basket_coupons (
+    basket_id TEXT    NOT NULL REFERENCES baskets (id) ON DELETE CASCADE,
+    position  INTEGER NOT NULL,
+    code      TEXT    NOT NULL,
+    PRIMARY KEY (basket_id, position)
+);
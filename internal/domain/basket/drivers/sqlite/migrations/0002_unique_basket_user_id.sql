@@ -0,0 +1,7 @@
+-- every user has exactly one basket, the oldest basket of a user is kept
+DELETE FROM baskets
+WHERE rowid NOT IN (SELECT MIN(rowid) FROM baskets GROUP BY user_id);
+
+DROP INDEX baskets_user_id;
+
+CREATE UNIQUE INDEX baskets_user_id ON baskets (user_id);
@@ -0,0 +1,2 @@
+-- the version of optimistic locking, existing baskets start with version 0
+ALTER TABLE baskets ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/httperror"
+)
+
+const (
+	SessionCookieName   = "session"
+	bearerTokenPrefix   = "Bearer "
+	authorizationHeader = "Authorization"
+)
+
+// NewBearerTokenAuthenticator returns a middleware for the REST adapters.
+// Requests without a valid "Authorization: Bearer <token>" header are rejected with a 401.
+func NewBearerTokenAuthenticator(tokenService entities.TokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := GetBearerToken(c)
+		if token == "" {
+			httperror.AbortUnauthorized(c, "missing bearer token")
+			return
+		}
+
+		identity, err := tokenService.Verify(token)
+		if err != nil {
+			httperror.AbortUnauthorized(c, err.Error())
+			return
+		}
+
+		SetIdentity(c, identity)
+		c.Next()
+	}
+}
+
+// NewRoleAuthorizer returns a middleware for routes which are only available to users with the role.
+// It has to run after NewBearerTokenAuthenticator, requests of other users are rejected with a 403.
+func NewRoleAuthorizer(role entities.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, exists := GetIdentity(c)
+		if !exists {
+			httperror.AbortUnauthorized(c, "unauthorized")
+			return
+		}
+
+		if !identity.HasRole(role) {
+			httperror.AbortForbidden(c, fmt.Sprintf("role %s is required", role))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// NewSessionCookieAuthenticator returns a middleware for the web adapters.
+// It only stores the identity of a valid session cookie, because the pages render their own 401 response.
+func NewSessionCookieAuthenticator(tokenService entities.TokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := GetSessionToken(c)
+		if token != "" {
+			identity, verifyErr := tokenService.Verify(token)
+			if verifyErr == nil {
+				SetIdentity(c, identity)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// GetBearerToken returns the token of the "Authorization: Bearer <token>" header or an empty string
+func GetBearerToken(c *gin.Context) string {
+	header := c.GetHeader(authorizationHeader)
+	if !strings.HasPrefix(header, bearerTokenPrefix) {
+		return ""
+	}
+
+	return strings.TrimPrefix(header, bearerTokenPrefix)
+}
+
+// GetSessionToken returns the token of the session cookie or an empty string
+func GetSessionToken(c *gin.Context) string {
+	token, err := c.Cookie(SessionCookieName)
+	if err != nil {
+		return ""
+	}
+
+	return token
+}
+
+// SetSessionCookie stores the signed token as http only session cookie
+func SetSessionCookie(c *gin.Context, token string) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(SessionCookieName, token, 0, "/", "", c.Request.TLS != nil, true)
+}
+
+// ClearSessionCookie removes the session cookie
+func ClearSessionCookie(c *gin.Context) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(SessionCookieName, "", -1, "/", "", c.Request.TLS != nil, true)
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+)
+
+const (
+	IdentityContextKey = "identity"
+)
+
+// SetIdentity stores the authenticated identity inside the gin context
+func SetIdentity(c *gin.Context, identity *entities.Identity) {
+	c.Set(IdentityContextKey, identity)
+}
+
+// GetIdentity returns the authenticated identity stored by one of the authenticators
+func GetIdentity(c *gin.Context) (*entities.Identity, bool) {
+	value, exists := c.Get(IdentityContextKey)
+	if !exists {
+		return nil, false
+	}
+
+	identity, ok := value.(*entities.Identity)
+	if !ok || identity == nil {
+		return nil, false
+	}
+
+	return identity, true
+}
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/adapters/auth"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/usecases"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/httperror"
+)
+
+type LoginController interface {
+	Login(c *gin.Context)
+	CreateGuest(c *gin.Context)
+}
+
+var _ LoginController = (*LoginControllerImpl)(nil)
+
+type LoginControllerImpl struct {
+	usecases.LoginUseCase
+	usecases.CreateGuestUseCase
+}
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func NewLoginController(loginUseCase usecases.LoginUseCase, createGuestUseCase usecases.CreateGuestUseCase) *LoginControllerImpl {
+	return &LoginControllerImpl{
+		LoginUseCase:       loginUseCase,
+		CreateGuestUseCase: createGuestUseCase,
+	}
+}
+
+func (controller *LoginControllerImpl) Login(c *gin.Context) {
+	var request loginRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	output, err := controller.LoginUseCase.Execute(
+		c.Request.Context(),
+		&usecases.LoginUseCaseInput{
+			Username: request.Username,
+			Password: request.Password,
+			// a guest sends its guest token to take over the guest basket
+			GuestToken: auth.GetBearerToken(c),
+		},
+	)
+	if err != nil {
+		var invalidCredentialsErr *usecases.InvalidCredentialsError
+		if errors.As(err, &invalidCredentialsErr) {
+			httperror.AbortUnauthorized(c, err.Error())
+			return
+		}
+
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"userId":  output.UserID,
+		"token":   output.Token,
+		"actions": output.Actions,
+	})
+}
+
+func (controller *LoginControllerImpl) CreateGuest(c *gin.Context) {
+	output, err := controller.CreateGuestUseCase.Execute(c.Request.Context())
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"userId": output.UserID,
+		"token":  output.Token,
+	})
+}
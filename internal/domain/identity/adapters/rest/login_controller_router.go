@@ -0,0 +1,34 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LoginControllerRouter interface {
+	RegisterRoutes(router gin.IRouter) error
+}
+
+var _ LoginControllerRouter = (*LoginControllerRouterImpl)(nil)
+
+type LoginControllerRouterImpl struct {
+	loginController LoginController
+}
+
+func NewLoginControllerRouter(loginController LoginController) LoginControllerRouter {
+	return &LoginControllerRouterImpl{
+		loginController: loginController,
+	}
+}
+
+func (controllerRouter *LoginControllerRouterImpl) RegisterRoutes(router gin.IRouter) error {
+	if router == nil {
+		return fmt.Errorf("router is nil")
+	}
+
+	router.POST("/auth/token", controllerRouter.loginController.Login)
+	router.POST("/auth/guest", controllerRouter.loginController.CreateGuest)
+
+	return nil
+}
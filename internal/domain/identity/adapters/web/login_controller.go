@@ -0,0 +1,97 @@
+package web
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/adapters/auth"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/usecases"
+)
+
+type LoginController interface {
+	ShowLogin(c *gin.Context)
+	Login(c *gin.Context)
+	LoginAsGuest(c *gin.Context)
+	Logout(c *gin.Context)
+}
+
+var _ LoginController = (*LoginControllerImpl)(nil)
+
+type LoginControllerImpl struct {
+	LoginUseCase       usecases.LoginUseCase
+	CreateGuestUseCase usecases.CreateGuestUseCase
+	templ              *template.Template
+}
+
+func NewLoginController(loginUseCase usecases.LoginUseCase, createGuestUseCase usecases.CreateGuestUseCase) LoginController {
+	return &LoginControllerImpl{
+		LoginUseCase:       loginUseCase,
+		CreateGuestUseCase: createGuestUseCase,
+		templ:              template.Must(template.New("").ParseFS(templatesFS, "templates/*.html")),
+	}
+}
+
+func (controller *LoginControllerImpl) ShowLogin(c *gin.Context) {
+	controller.render(c, 200, gin.H{})
+}
+
+func (controller *LoginControllerImpl) Login(c *gin.Context) {
+	output, err := controller.LoginUseCase.Execute(
+		c.Request.Context(),
+		&usecases.LoginUseCaseInput{
+			Username: c.PostForm("username"),
+			Password: c.PostForm("password"),
+			// the session of a guest is taken over by the user
+			GuestToken: auth.GetSessionToken(c),
+		},
+	)
+	if err != nil {
+		status := 500
+		var invalidCredentialsErr *usecases.InvalidCredentialsError
+		if errors.As(err, &invalidCredentialsErr) {
+			status = 401
+		}
+
+		controller.render(c, status, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	auth.SetSessionCookie(c, output.Token)
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+func (controller *LoginControllerImpl) LoginAsGuest(c *gin.Context) {
+	output, err := controller.CreateGuestUseCase.Execute(c.Request.Context())
+	if err != nil {
+		controller.render(c, 500, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	auth.SetSessionCookie(c, output.Token)
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+func (controller *LoginControllerImpl) Logout(c *gin.Context) {
+	auth.ClearSessionCookie(c)
+
+	c.Redirect(http.StatusSeeOther, "/login")
+}
+
+// render uses its own template set, because the engine wide HTML template belongs to the basket web adapter
+func (controller *LoginControllerImpl) render(c *gin.Context, status int, data gin.H) {
+	c.Render(status, render.HTML{
+		Template: controller.templ,
+		Name:     "login.html",
+		Data:     data,
+	})
+}
@@ -0,0 +1,40 @@
+package web
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed templates/*
+var templatesFS embed.FS
+
+type LoginControllerRouter interface {
+	RegisterRoutes(router gin.IRouter) error
+}
+
+var _ LoginControllerRouter = (*LoginControllerRouterImpl)(nil)
+
+type LoginControllerRouterImpl struct {
+	loginController LoginController
+}
+
+func NewLoginControllerRouter(loginController LoginController) LoginControllerRouter {
+	return &LoginControllerRouterImpl{
+		loginController: loginController,
+	}
+}
+
+func (controllerRouter *LoginControllerRouterImpl) RegisterRoutes(router gin.IRouter) error {
+	if router == nil {
+		return fmt.Errorf("router is nil")
+	}
+
+	router.GET("/login", controllerRouter.loginController.ShowLogin)
+	router.POST("/login", controllerRouter.loginController.Login)
+	router.POST("/login/guest", controllerRouter.loginController.LoginAsGuest)
+	router.POST("/logout", controllerRouter.loginController.Logout)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package entities
+
+import "github.com/google/uuid"
+
+const (
+	GuestUserIDPrefix = "guest-"
+)
+
+// Identity is a value object describing who is calling the application
+type Identity struct {
+	UserID string
+	Guest  bool
+	// Roles is empty for customers and guests
+	Roles []Role
+}
+
+// NewGuestIdentity creates an anonymous identity with a generated user id
+func NewGuestIdentity() *Identity {
+	return &Identity{
+		UserID: GuestUserIDPrefix + uuid.NewString(),
+		Guest:  true,
+	}
+}
+
+func (identity *Identity) GetUserID() string {
+	return identity.UserID
+}
+
+func (identity *Identity) IsGuest() bool {
+	return identity.Guest
+}
+
+func (identity *Identity) HasRole(role Role) bool {
+	return hasRole(identity.Roles, role)
+}
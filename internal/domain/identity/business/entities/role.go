@@ -0,0 +1,16 @@
+package entities
+
+import "slices"
+
+// Role grants a user access to use cases which are not available to every customer
+type Role string
+
+const (
+	// RoleAdmin manages the products, their prices and their stock
+	RoleAdmin Role = "admin"
+)
+
+// hasRole is shared by the user and the identity, both contain the roles of the user
+func hasRole(roles []Role, role Role) bool {
+	return slices.Contains(roles, role)
+}
@@ -0,0 +1,19 @@
+package entities
+
+//go:generate mockgen -source=token_service.go -destination=token_service_mock.go -package=entities
+
+// TokenService issues and verifies the signed tokens used as bearer tokens and session cookies
+type TokenService interface {
+	Issue(identity *Identity) (string, error)
+	Verify(token string) (*Identity, error)
+}
+
+var _ error = (*InvalidTokenError)(nil)
+
+type InvalidTokenError struct {
+	Reason string
+}
+
+func (err *InvalidTokenError) Error() string {
+	return "invalid token: " + err.Reason
+}
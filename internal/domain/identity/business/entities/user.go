@@ -0,0 +1,31 @@
+package entities
+
+import "golang.org/x/crypto/bcrypt"
+
+type User struct {
+	ID           string
+	Username     string
+	PasswordHash string
+	Roles        []Role
+}
+
+func (user *User) GetID() string {
+	return user.ID
+}
+
+func (user *User) GetUsername() string {
+	return user.Username
+}
+
+// CheckPassword compares the given plain text password with the stored password hash
+func (user *User) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil
+}
+
+func (user *User) GetRoles() []Role {
+	return user.Roles
+}
+
+func (user *User) HasRole(role Role) bool {
+	return hasRole(user.Roles, role)
+}
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserFactory interface {
+	NewUser(userID string, username string, password string, roles ...Role) (*User, error)
+}
+
+var _ UserFactory = (*UserFactoryImpl)(nil)
+
+type UserFactoryImpl struct {
+}
+
+func NewUserFactory() UserFactory {
+	return &UserFactoryImpl{}
+}
+
+func (factory *UserFactoryImpl) NewUser(userID string, username string, password string, roles ...Role) (*User, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID cannot be empty")
+	} else if username == "" {
+		return nil, fmt.Errorf("username cannot be empty")
+	} else if password == "" {
+		return nil, fmt.Errorf("password cannot be empty")
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return &User{
+		ID:           userID,
+		Username:     username,
+		PasswordHash: string(passwordHash),
+		Roles:        roles,
+	}, nil
+}
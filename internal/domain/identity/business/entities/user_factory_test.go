@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UserFactory_NewUser_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		userID   string
+		username string
+		password string
+	}{
+		"userID cannot be empty": {},
+		"username cannot be empty": {
+			userID: "1337",
+		},
+		"password cannot be empty": {
+			userID:   "1337",
+			username: "demo",
+		},
+	}
+
+	for errorMessage, testCase := range testCases {
+		t.Run(errorMessage, func(t *testing.T) {
+			factory := NewUserFactory()
+			user, err := factory.NewUser(testCase.userID, testCase.username, testCase.password)
+
+			require.Error(t, err)
+			require.ErrorContains(t, err, errorMessage)
+			require.Nil(t, user)
+		})
+	}
+}
+
+func Test_UserFactory_NewUser(t *testing.T) {
+	factory := NewUserFactory()
+	user, err := factory.NewUser("1337", "demo", "secret")
+
+	require.NoError(t, err)
+	require.NotNil(t, user)
+
+	require.Equal(t, "1337", user.GetID())
+	require.Equal(t, "demo", user.GetUsername())
+	require.NotEqual(t, "secret", user.PasswordHash)
+
+	require.True(t, user.CheckPassword("secret"))
+	require.False(t, user.CheckPassword("wrong"))
+	require.False(t, user.HasRole(RoleAdmin))
+}
+
+func Test_UserFactory_NewUser_WithRoles(t *testing.T) {
+	factory := NewUserFactory()
+	user, err := factory.NewUser("admin", "admin", "secret", RoleAdmin)
+
+	require.NoError(t, err)
+	require.Equal(t, []Role{RoleAdmin}, user.GetRoles())
+	require.True(t, user.HasRole(RoleAdmin))
+}
@@ -0,0 +1,14 @@
+package entities
+
+import "context"
+
+//go:generate mockgen -source=user_repository.go -destination=user_repository_mock.go -package=entities
+
+type UserRepository interface {
+	Find(ctx context.Context, id string) (*User, error)
+	FindByUsername(ctx context.Context, username string) (*User, error)
+	Save(ctx context.Context, user *User) error
+}
+
+// UserResource is the resource of the domainerror.NotFoundError returned if a user does not exist
+const UserResource = "user"
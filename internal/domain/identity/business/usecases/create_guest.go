@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+)
+
+type CreateGuestUseCaseOutput struct {
+	UserID string
+	Token  string
+}
+
+// CreateGuestUseCase creates an anonymous guest identity, so guests can fill a basket before logging in
+type CreateGuestUseCase interface {
+	Execute(ctx context.Context) (*CreateGuestUseCaseOutput, error)
+}
+
+func NewCreateGuestUseCaseImpl(tokenService entities.TokenService) CreateGuestUseCase {
+	return &CreateGuestUseCaseImpl{
+		tokenService: tokenService,
+	}
+}
+
+var _ CreateGuestUseCase = (*CreateGuestUseCaseImpl)(nil)
+
+type CreateGuestUseCaseImpl struct {
+	tokenService entities.TokenService
+}
+
+func (useCase *CreateGuestUseCaseImpl) Execute(ctx context.Context) (*CreateGuestUseCaseOutput, error) {
+	guest := entities.NewGuestIdentity()
+
+	token, tokenServiceErr := useCase.tokenService.Issue(guest)
+	if tokenServiceErr != nil {
+		return nil, tokenServiceErr
+	}
+
+	output := &CreateGuestUseCaseOutput{
+		UserID: guest.GetUserID(),
+		Token:  token,
+	}
+
+	return output, nil
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+)
+
+func Test_CreateGuestUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokenServiceMock := entities.NewMockTokenService(ctrl)
+	tokenServiceMock.EXPECT().Issue(gomock.Any()).DoAndReturn(func(identity *entities.Identity) (string, error) {
+		require.True(t, identity.IsGuest())
+		return "guest-token", nil
+	})
+
+	useCase := NewCreateGuestUseCaseImpl(tokenServiceMock)
+
+	output, err := useCase.Execute(t.Context())
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	require.True(t, strings.HasPrefix(output.UserID, entities.GuestUserIDPrefix))
+	require.Equal(t, "guest-token", output.Token)
+}
@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type LoginUseCaseInput struct {
+	Username string
+	Password string
+	// GuestToken is optional and identifies the guest session the user had before logging in
+	GuestToken string
+}
+
+type LoginUseCaseOutput struct {
+	UserID  string
+	Token   string
+	Actions map[string]string
+}
+
+// GuestLoginListener is notified when a guest logs in as a registered user, e.g. to take over the guest basket
+type GuestLoginListener interface {
+	OnGuestLogin(ctx context.Context, guestUserID string, userID string) (map[string]string, error)
+}
+
+type LoginUseCase interface {
+	Execute(ctx context.Context, input *LoginUseCaseInput) (*LoginUseCaseOutput, error)
+}
+
+var _ error = (*InvalidCredentialsError)(nil)
+
+type InvalidCredentialsError struct {
+}
+
+func (err *InvalidCredentialsError) Error() string {
+	return "invalid username or password"
+}
+
+func NewLoginUseCaseImpl(userRepository entities.UserRepository, tokenService entities.TokenService, guestLoginListeners ...GuestLoginListener) LoginUseCase {
+	return &LoginUseCaseImpl{
+		userRepository:      userRepository,
+		tokenService:        tokenService,
+		guestLoginListeners: guestLoginListeners,
+	}
+}
+
+var _ LoginUseCase = (*LoginUseCaseImpl)(nil)
+
+type LoginUseCaseImpl struct {
+	userRepository      entities.UserRepository
+	tokenService        entities.TokenService
+	guestLoginListeners []GuestLoginListener
+}
+
+func (useCase *LoginUseCaseImpl) validate(input *LoginUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.Username == "" {
+		return fmt.Errorf("input parameter Username is empty")
+	} else if input.Password == "" {
+		return fmt.Errorf("input parameter Password is empty")
+	}
+
+	return nil
+}
+
+func (useCase *LoginUseCaseImpl) Execute(ctx context.Context, input *LoginUseCaseInput) (*LoginUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	user, userRepositoryErr := useCase.userRepository.FindByUsername(ctx, input.Username)
+	if userRepositoryErr != nil {
+		// do not tell the caller whether the username or the password was wrong
+		if domainerror.IsNotFound(userRepositoryErr, entities.UserResource) {
+			return nil, &InvalidCredentialsError{}
+		}
+
+		return nil, userRepositoryErr
+	}
+
+	if !user.CheckPassword(input.Password) {
+		return nil, &InvalidCredentialsError{}
+	}
+
+	token, tokenServiceErr := useCase.tokenService.Issue(&entities.Identity{UserID: user.GetID(), Roles: user.GetRoles()})
+	if tokenServiceErr != nil {
+		return nil, tokenServiceErr
+	}
+
+	actions, guestLoginErr := useCase.notifyGuestLogin(ctx, input.GuestToken, user.GetID())
+	if guestLoginErr != nil {
+		return nil, guestLoginErr
+	}
+
+	output := &LoginUseCaseOutput{
+		UserID:  user.GetID(),
+		Token:   token,
+		Actions: actions,
+	}
+
+	return output, nil
+}
+
+// notifyGuestLogin informs the listeners about the guest session, but only if the guest token is valid
+func (useCase *LoginUseCaseImpl) notifyGuestLogin(ctx context.Context, guestToken string, userID string) (map[string]string, error) {
+	if guestToken == "" {
+		return nil, nil
+	}
+
+	guest, tokenServiceErr := useCase.tokenService.Verify(guestToken)
+	if tokenServiceErr != nil || !guest.IsGuest() {
+		// an invalid or expired guest session has nothing to take over
+		return nil, nil
+	}
+
+	var actions map[string]string
+	for _, listener := range useCase.guestLoginListeners {
+		listenerActions, listenerErr := listener.OnGuestLogin(ctx, guest.GetUserID(), userID)
+		if listenerErr != nil {
+			return nil, listenerErr
+		}
+
+		for key, value := range listenerActions {
+			if actions == nil {
+				actions = map[string]string{}
+			}
+			actions[key] = value
+		}
+	}
+
+	return actions, nil
+}
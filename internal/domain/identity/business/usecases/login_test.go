@@ -0,0 +1,195 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+func Test_LoginUseCase_NewLoginUseCaseImpl_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *LoginUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"Username is empty": {
+			input: &LoginUseCaseInput{},
+		},
+		"Password is empty": {
+			input: &LoginUseCaseInput{
+				Username: "demo",
+			},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepositoryMock := entities.NewMockUserRepository(ctrl)
+			tokenServiceMock := entities.NewMockTokenService(ctrl)
+
+			useCase := NewLoginUseCaseImpl(userRepositoryMock, tokenServiceMock)
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.Error(t, err)
+			require.ErrorContains(t, err, errorString)
+		})
+	}
+}
+
+func Test_LoginUseCase_InvalidCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user, err := entities.NewUserFactory().NewUser("1337", "demo", "demo")
+	require.NoError(t, err)
+
+	userRepositoryMock := entities.NewMockUserRepository(ctrl)
+	userRepositoryMock.EXPECT().FindByUsername(gomock.Any(), "unknown").Return(nil, &domainerror.NotFoundError{Resource: entities.UserResource})
+	userRepositoryMock.EXPECT().FindByUsername(gomock.Any(), "demo").Return(user, nil)
+
+	tokenServiceMock := entities.NewMockTokenService(ctrl)
+
+	useCase := NewLoginUseCaseImpl(userRepositoryMock, tokenServiceMock)
+
+	_, err = useCase.Execute(t.Context(), &LoginUseCaseInput{Username: "unknown", Password: "demo"})
+	require.ErrorAs(t, err, new(*InvalidCredentialsError))
+
+	_, err = useCase.Execute(t.Context(), &LoginUseCaseInput{Username: "demo", Password: "wrong"})
+	require.ErrorAs(t, err, new(*InvalidCredentialsError))
+}
+
+func Test_LoginUseCase(t *testing.T) {
+	// arrange
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+	token := "token"
+
+	user, err := entities.NewUserFactory().NewUser(userID, "demo", "demo")
+	require.NoError(t, err)
+
+	userRepositoryMock := entities.NewMockUserRepository(ctrl)
+	userRepositoryMock.EXPECT().FindByUsername(gomock.Any(), "demo").Return(user, nil)
+
+	tokenServiceMock := entities.NewMockTokenService(ctrl)
+	tokenServiceMock.EXPECT().Issue(&entities.Identity{UserID: userID}).Return(token, nil)
+
+	useCase := NewLoginUseCaseImpl(userRepositoryMock, tokenServiceMock)
+
+	input := &LoginUseCaseInput{
+		Username: "demo",
+		Password: "demo",
+	}
+
+	// act
+
+	output, err := useCase.Execute(t.Context(), input)
+
+	// assert
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	require.Equal(t, userID, output.UserID)
+	require.Equal(t, token, output.Token)
+}
+
+func Test_LoginUseCase_Admin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user, err := entities.NewUserFactory().NewUser("admin", "admin", "admin", entities.RoleAdmin)
+	require.NoError(t, err)
+
+	userRepositoryMock := entities.NewMockUserRepository(ctrl)
+	userRepositoryMock.EXPECT().FindByUsername(gomock.Any(), "admin").Return(user, nil)
+
+	// the roles of the user are part of the token
+	tokenServiceMock := entities.NewMockTokenService(ctrl)
+	tokenServiceMock.EXPECT().Issue(&entities.Identity{UserID: "admin", Roles: []entities.Role{entities.RoleAdmin}}).Return("token", nil)
+
+	useCase := NewLoginUseCaseImpl(userRepositoryMock, tokenServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &LoginUseCaseInput{Username: "admin", Password: "admin"})
+
+	require.NoError(t, err)
+	require.Equal(t, "token", output.Token)
+}
+
+type guestLoginListenerStub struct {
+	guestUserID string
+	userID      string
+}
+
+func (listener *guestLoginListenerStub) OnGuestLogin(ctx context.Context, guestUserID string, userID string) (map[string]string, error) {
+	listener.guestUserID = guestUserID
+	listener.userID = userID
+
+	return map[string]string{"merged": "guest basket merged"}, nil
+}
+
+func Test_LoginUseCase_WithGuestToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "1337"
+	guestUserID := "guest-1"
+
+	user, err := entities.NewUserFactory().NewUser(userID, "demo", "demo")
+	require.NoError(t, err)
+
+	userRepositoryMock := entities.NewMockUserRepository(ctrl)
+	userRepositoryMock.EXPECT().FindByUsername(gomock.Any(), "demo").Return(user, nil)
+
+	tokenServiceMock := entities.NewMockTokenService(ctrl)
+	tokenServiceMock.EXPECT().Issue(&entities.Identity{UserID: userID}).Return("token", nil)
+	tokenServiceMock.EXPECT().Verify("guest-token").Return(&entities.Identity{UserID: guestUserID, Guest: true}, nil)
+
+	listener := &guestLoginListenerStub{}
+
+	useCase := NewLoginUseCaseImpl(userRepositoryMock, tokenServiceMock, listener)
+
+	output, err := useCase.Execute(t.Context(), &LoginUseCaseInput{Username: "demo", Password: "demo", GuestToken: "guest-token"})
+
+	require.NoError(t, err)
+	require.Equal(t, guestUserID, listener.guestUserID)
+	require.Equal(t, userID, listener.userID)
+	require.Contains(t, output.Actions, "merged")
+}
+
+func Test_LoginUseCase_WithRegisteredUserToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user, err := entities.NewUserFactory().NewUser("1337", "demo", "demo")
+	require.NoError(t, err)
+
+	userRepositoryMock := entities.NewMockUserRepository(ctrl)
+	userRepositoryMock.EXPECT().FindByUsername(gomock.Any(), "demo").Return(user, nil)
+
+	tokenServiceMock := entities.NewMockTokenService(ctrl)
+	tokenServiceMock.EXPECT().Issue(gomock.Any()).Return("token", nil)
+	// a registered user token must never be merged like a guest
+	tokenServiceMock.EXPECT().Verify("user-token").Return(&entities.Identity{UserID: "1338"}, nil)
+
+	listener := &guestLoginListenerStub{}
+
+	useCase := NewLoginUseCaseImpl(userRepositoryMock, tokenServiceMock, listener)
+
+	output, err := useCase.Execute(t.Context(), &LoginUseCaseInput{Username: "demo", Password: "demo", GuestToken: "user-token"})
+
+	require.NoError(t, err)
+	require.Empty(t, listener.guestUserID)
+	require.Empty(t, output.Actions)
+}
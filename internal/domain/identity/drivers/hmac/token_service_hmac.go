@@ -0,0 +1,111 @@
+package hmac
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+)
+
+const (
+	DefaultTokenLifetime = 24 * time.Hour
+)
+
+var _ entities.TokenService = (*HMACTokenService)(nil)
+
+// HMACTokenService signs the token claims with HMAC-SHA256.
+// A token has the format base64url(claims) + "." + base64url(signature).
+type HMACTokenService struct {
+	secret   []byte
+	lifetime time.Duration
+	now      func() time.Time
+}
+
+type tokenClaims struct {
+	Subject   string          `json:"sub"`
+	Guest     bool            `json:"guest,omitempty"`
+	Roles     []entities.Role `json:"roles,omitempty"`
+	ExpiresAt int64           `json:"exp"`
+}
+
+func NewHMACTokenService(secret []byte, lifetime time.Duration) (entities.TokenService, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("secret is empty")
+	} else if lifetime <= 0 {
+		return nil, fmt.Errorf("lifetime must be greater than 0")
+	}
+
+	return &HMACTokenService{
+		secret:   secret,
+		lifetime: lifetime,
+		now:      time.Now,
+	}, nil
+}
+
+func (service *HMACTokenService) Issue(identity *entities.Identity) (string, error) {
+	if identity == nil {
+		return "", fmt.Errorf("identity is nil")
+	} else if identity.GetUserID() == "" {
+		return "", fmt.Errorf("identity has no user id")
+	}
+
+	claims := &tokenClaims{
+		Subject:   identity.GetUserID(),
+		Guest:     identity.IsGuest(),
+		Roles:     identity.Roles,
+		ExpiresAt: service.now().Add(service.lifetime).Unix(),
+	}
+
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	return payload + "." + service.sign(payload), nil
+}
+
+func (service *HMACTokenService) Verify(token string) (*entities.Identity, error) {
+	payload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, &entities.InvalidTokenError{Reason: "malformed"}
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(service.sign(payload))) {
+		return nil, &entities.InvalidTokenError{Reason: "signature mismatch"}
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, &entities.InvalidTokenError{Reason: "malformed"}
+	}
+
+	var claims tokenClaims
+	err = json.Unmarshal(claimsJSON, &claims)
+	if err != nil || claims.Subject == "" {
+		return nil, &entities.InvalidTokenError{Reason: "malformed"}
+	}
+
+	if service.now().Unix() >= claims.ExpiresAt {
+		return nil, &entities.InvalidTokenError{Reason: "expired"}
+	}
+
+	return &entities.Identity{
+		UserID: claims.Subject,
+		Guest:  claims.Guest,
+		Roles:  claims.Roles,
+	}, nil
+}
+
+func (service *HMACTokenService) sign(payload string) string {
+	mac := hmac.New(sha256.New, service.secret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
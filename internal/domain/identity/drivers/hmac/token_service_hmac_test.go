@@ -0,0 +1,112 @@
+package hmac
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+)
+
+func Test_HMACTokenService_NewHMACTokenService_ReturnsError(t *testing.T) {
+	service, err := NewHMACTokenService(nil, time.Hour)
+
+	require.Error(t, err)
+	require.Nil(t, service)
+
+	service, err = NewHMACTokenService([]byte("secret"), 0)
+
+	require.Error(t, err)
+	require.Nil(t, service)
+}
+
+func Test_HMACTokenService_IssueAndVerify(t *testing.T) {
+	service, err := NewHMACTokenService([]byte("secret"), time.Hour)
+	require.NoError(t, err)
+
+	token, err := service.Issue(&entities.Identity{UserID: "1337"})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	identity, err := service.Verify(token)
+
+	require.NoError(t, err)
+	require.Equal(t, "1337", identity.GetUserID())
+}
+
+func Test_HMACTokenService_Verify_ReturnsError(t *testing.T) {
+	service, err := NewHMACTokenService([]byte("secret"), time.Hour)
+	require.NoError(t, err)
+
+	otherService, err := NewHMACTokenService([]byte("other secret"), time.Hour)
+	require.NoError(t, err)
+
+	otherToken, err := otherService.Issue(&entities.Identity{UserID: "1337"})
+	require.NoError(t, err)
+
+	testCases := map[string]string{
+		"malformed":          "abc",
+		"signature mismatch": otherToken,
+	}
+
+	for errorString, token := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			identity, err := service.Verify(token)
+
+			require.ErrorAs(t, err, new(*entities.InvalidTokenError))
+			require.ErrorContains(t, err, errorString)
+			require.Nil(t, identity)
+		})
+	}
+}
+
+func Test_HMACTokenService_Verify_Expired(t *testing.T) {
+	tokenService, err := NewHMACTokenService([]byte("secret"), time.Hour)
+	require.NoError(t, err)
+
+	service := tokenService.(*HMACTokenService)
+
+	token, err := service.Issue(&entities.Identity{UserID: "1337"})
+	require.NoError(t, err)
+
+	service.now = func() time.Time {
+		return time.Now().Add(2 * time.Hour)
+	}
+
+	identity, err := service.Verify(token)
+
+	require.ErrorContains(t, err, "expired")
+	require.Nil(t, identity)
+}
+
+func Test_HMACTokenService_IssueAndVerify_Guest(t *testing.T) {
+	service, err := NewHMACTokenService([]byte("secret"), time.Hour)
+	require.NoError(t, err)
+
+	guest := entities.NewGuestIdentity()
+
+	token, err := service.Issue(guest)
+	require.NoError(t, err)
+
+	identity, err := service.Verify(token)
+
+	require.NoError(t, err)
+	require.True(t, identity.IsGuest())
+	require.Equal(t, guest.GetUserID(), identity.GetUserID())
+}
+
+func Test_HMACTokenService_IssueAndVerify_Roles(t *testing.T) {
+	service, err := NewHMACTokenService([]byte("secret"), time.Hour)
+	require.NoError(t, err)
+
+	token, err := service.Issue(&entities.Identity{UserID: "admin", Roles: []entities.Role{entities.RoleAdmin}})
+	require.NoError(t, err)
+
+	identity, err := service.Verify(token)
+
+	require.NoError(t, err)
+	require.True(t, identity.HasRole(entities.RoleAdmin))
+	require.False(t, identity.IsGuest())
+}
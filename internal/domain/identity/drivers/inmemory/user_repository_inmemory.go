@@ -0,0 +1,63 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+var _ entities.UserRepository = (*InMemoryUserRepository)(nil)
+
+type InMemoryUserRepository struct {
+	mutex sync.RWMutex
+	users map[string]*entities.User
+}
+
+func NewInMemoryUserRepository() entities.UserRepository {
+	return &InMemoryUserRepository{
+		users: make(map[string]*entities.User),
+	}
+}
+
+func (repository *InMemoryUserRepository) Find(ctx context.Context, id string) (*entities.User, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	user, userExists := repository.users[id]
+	if !userExists {
+		return nil, &domainerror.NotFoundError{Resource: entities.UserResource}
+	}
+
+	return user, nil
+}
+
+func (repository *InMemoryUserRepository) FindByUsername(ctx context.Context, username string) (*entities.User, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	for _, user := range repository.users {
+		if user.GetUsername() == username {
+			return user, nil
+		}
+	}
+
+	return nil, &domainerror.NotFoundError{Resource: entities.UserResource}
+}
+
+func (repository *InMemoryUserRepository) Save(ctx context.Context, user *entities.User) error {
+	if user == nil {
+		return fmt.Errorf("user is nil")
+	} else if user.GetID() == "" {
+		return fmt.Errorf("user has no id")
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	repository.users[user.GetID()] = user
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+func Test_InMemoryUserRepository(t *testing.T) {
+	repository := NewInMemoryUserRepository()
+
+	require.NotNil(t, repository)
+
+	user, err := repository.FindByUsername(t.Context(), "demo")
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+	require.Nil(t, user)
+
+	newUser, err := entities.NewUserFactory().NewUser("1337", "demo", "demo")
+	require.NoError(t, err)
+
+	err = repository.Save(t.Context(), newUser)
+	require.NoError(t, err)
+
+	user, err = repository.Find(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, "demo", user.GetUsername())
+
+	user, err = repository.FindByUsername(t.Context(), "demo")
+
+	require.NoError(t, err)
+	require.Equal(t, "1337", user.GetID())
+}
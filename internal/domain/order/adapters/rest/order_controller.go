@@ -0,0 +1,164 @@
+package rest
+
+import (
+	"errors"
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/identity/adapters/auth"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/etag"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/httperror"
+)
+
+type OrderController interface {
+	Checkout(c *gin.Context)
+	ListOrders(c *gin.Context)
+	ShowOrder(c *gin.Context)
+	CancelOrder(c *gin.Context)
+}
+
+var _ OrderController = (*OrderControllerImpl)(nil)
+
+type OrderControllerImpl struct {
+	usecases.CheckoutUseCase
+	usecases.ListOrdersUseCase
+	usecases.ShowOrderUseCase
+	usecases.CancelOrderUseCase
+}
+
+// checkoutRequest is optional, a checkout without body ships from the locations nearest to the default warehouse location
+type checkoutRequest struct {
+	Destination *positionRequest `json:"destination"`
+}
+
+type positionRequest struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+func NewOrderController(
+	checkoutUseCase usecases.CheckoutUseCase,
+	listOrdersUseCase usecases.ListOrdersUseCase,
+	showOrderUseCase usecases.ShowOrderUseCase,
+	cancelOrderUseCase usecases.CancelOrderUseCase,
+) *OrderControllerImpl {
+	return &OrderControllerImpl{
+		CheckoutUseCase:    checkoutUseCase,
+		ListOrdersUseCase:  listOrdersUseCase,
+		ShowOrderUseCase:   showOrderUseCase,
+		CancelOrderUseCase: cancelOrderUseCase,
+	}
+}
+
+func (controller *OrderControllerImpl) Checkout(c *gin.Context) {
+	identity, exists := auth.GetIdentity(c)
+	if !exists {
+		httperror.AbortUnauthorized(c, "unauthorized")
+		return
+	}
+
+	// the If-Match header contains the ETag of the basket the user has seen
+	expectedBasketVersion, err := etag.ParseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	var request checkoutRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil && !errors.Is(err, io.EOF) {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	var destination *warehouse.Position
+	if request.Destination != nil {
+		destination = &warehouse.Position{
+			Latitude:  request.Destination.Latitude,
+			Longitude: request.Destination.Longitude,
+		}
+	}
+
+	output, err := controller.CheckoutUseCase.Execute(
+		c.Request.Context(),
+		&usecases.CheckoutUseCaseInput{
+			UserID:                identity.GetUserID(),
+			ExpectedBasketVersion: expectedBasketVersion,
+			Destination:           destination,
+		},
+	)
+	if err != nil {
+		httperror.WriteConditional(c, err, expectedBasketVersion != nil)
+		return
+	}
+
+	c.JSON(201, output)
+}
+
+func (controller *OrderControllerImpl) ListOrders(c *gin.Context) {
+	identity, exists := auth.GetIdentity(c)
+	if !exists {
+		httperror.AbortUnauthorized(c, "unauthorized")
+		return
+	}
+
+	output, err := controller.ListOrdersUseCase.Execute(
+		c.Request.Context(),
+		&usecases.ListOrdersUseCaseInput{
+			UserID: identity.GetUserID(),
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+func (controller *OrderControllerImpl) ShowOrder(c *gin.Context) {
+	identity, exists := auth.GetIdentity(c)
+	if !exists {
+		httperror.AbortUnauthorized(c, "unauthorized")
+		return
+	}
+
+	output, err := controller.ShowOrderUseCase.Execute(
+		c.Request.Context(),
+		&usecases.ShowOrderUseCaseInput{
+			UserID:  identity.GetUserID(),
+			OrderID: c.Param("orderID"),
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+func (controller *OrderControllerImpl) CancelOrder(c *gin.Context) {
+	identity, exists := auth.GetIdentity(c)
+	if !exists {
+		httperror.AbortUnauthorized(c, "unauthorized")
+		return
+	}
+
+	output, err := controller.CancelOrderUseCase.Execute(
+		c.Request.Context(),
+		&usecases.CancelOrderUseCaseInput{
+			UserID:  identity.GetUserID(),
+			OrderID: c.Param("orderID"),
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderControllerRouter interface {
+	RegisterRoutes(router gin.IRouter) error
+}
+
+var _ OrderControllerRouter = (*OrderControllerRouterImpl)(nil)
+
+type OrderControllerRouterImpl struct {
+	orderController OrderController
+}
+
+func NewOrderControllerRouter(orderController OrderController) OrderControllerRouter {
+	return &OrderControllerRouterImpl{
+		orderController: orderController,
+	}
+}
+
+func (controllerRouter *OrderControllerRouterImpl) RegisterRoutes(router gin.IRouter) error {
+	if router == nil {
+		return fmt.Errorf("router is nil")
+	}
+
+	router.POST("/checkout", controllerRouter.orderController.Checkout)
+	router.GET("/orders", controllerRouter.orderController.ListOrders)
+	router.GET("/orders/:orderID", controllerRouter.orderController.ShowOrder)
+	router.POST("/orders/:orderID/cancel", controllerRouter.orderController.CancelOrder)
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+// Order is created at checkout and never changes its items or prices afterwards
+type Order struct {
+	Id     string
+	UserID string
+	Items  []*OrderItem
+	// Discounts contains the discounts of the coupons applied at checkout
+	Discounts []*OrderDiscount
+	// Totals contains one total per currency after the discounts, sorted by currency
+	Totals []money.Money
+	// TaxCountry is the country of the tax rates
+	TaxCountry string
+	// TaxTotals contains the net, tax and gross amounts per currency after the discounts, sorted by currency
+	TaxTotals []*OrderTaxTotal
+	CreatedAt time.Time
+	Status    OrderStatus
+	// StatusHistory contains every status of the order including the current one, oldest first
+	StatusHistory []*OrderStatusChange
+	// Shipments contains the units per warehouse location which ships them,
+	// orders created before there were several locations have no shipments
+	Shipments []*OrderShipment
+}
+
+// OrderItem contains a snapshot of the product at checkout
+type OrderItem struct {
+	ProductID   string
+	ProductName string
+	Count       int
+	Price       money.Money
+	Subtotal    money.Money
+	// TaxClass, TaxBasisPoints, Net and Tax split the subtotal
+	TaxClass       tax.TaxClass
+	TaxBasisPoints int64
+	Net            money.Money
+	Tax            money.Money
+	// Backorder is set if units of the item are not shipped with the shipments
+	Backorder *OrderBackorder
+}
+
+// OrderBackorder contains the units of an item which were sold without stock from the default warehouse location
+type OrderBackorder struct {
+	// Type is the stock policy of the product at checkout, backorder or preorder
+	Type string
+	// Count is the number of units which were not in stock, all units of a pre-order are shipped on the release date
+	Count int
+	// EstimatedDate is the expected restock date or the release date, it is zero if the date was unknown
+	EstimatedDate time.Time
+}
+
+// OrderShipment contains the units shipped from one warehouse location
+type OrderShipment struct {
+	LocationID string
+	Items      []*OrderShipmentItem
+}
+
+type OrderShipmentItem struct {
+	ProductID string
+	Count     int
+}
+
+type OrderDiscount struct {
+	Code   string
+	Reason string
+	// ProductID is set if the discount was only taken off the price of this product
+	ProductID string
+	Amount    money.Money
+}
+
+type OrderTaxTotal struct {
+	Net   money.Money
+	Tax   money.Money
+	Gross money.Money
+	// Classes contains the amounts per tax class, sorted by class
+	Classes []*OrderTax
+}
+
+// OrderTax splits the gross amount of one tax class into net and tax
+type OrderTax struct {
+	Class       tax.TaxClass
+	BasisPoints int64
+	Net         money.Money
+	Tax         money.Money
+	Gross       money.Money
+}
+
+func (order *Order) GetID() string {
+	return order.Id
+}
+
+func (order *Order) SetID(id string) {
+	order.Id = id
+}
+
+func (order *Order) GetUserID() string {
+	return order.UserID
+}
+
+func (order *Order) GetItems() []*OrderItem {
+	return order.Items
+}
+
+func (order *Order) GetDiscounts() []*OrderDiscount {
+	return order.Discounts
+}
+
+// ApplyDiscounts takes the discounts off the totals of their currencies
+func (order *Order) ApplyDiscounts(discounts []*OrderDiscount) error {
+	totals := make([]money.Money, len(order.Totals))
+	copy(totals, order.Totals)
+
+	for _, discount := range discounts {
+		applied := false
+		for i, total := range totals {
+			if total.GetCurrency() != discount.Amount.GetCurrency() {
+				continue
+			}
+
+			discounted, err := total.Subtract(discount.Amount)
+			if err != nil {
+				return err
+			} else if discounted.IsNegative() {
+				return fmt.Errorf("discount %s exceeds the order total %s", discount.Code, total)
+			}
+
+			totals[i] = discounted
+			applied = true
+		}
+
+		if !applied {
+			return fmt.Errorf("discount %s has no total in %s", discount.Code, discount.Amount.GetCurrency())
+		}
+	}
+
+	order.Discounts = append(order.Discounts, discounts...)
+	order.Totals = totals
+
+	return nil
+}
+
+func (order *Order) GetTotals() []money.Money {
+	return order.Totals
+}
+
+func (order *Order) GetTaxCountry() string {
+	return order.TaxCountry
+}
+
+func (order *Order) GetTaxTotals() []*OrderTaxTotal {
+	return order.TaxTotals
+}
+
+func (order *Order) SetTaxTotals(country string, taxTotals []*OrderTaxTotal) {
+	order.TaxCountry = country
+	order.TaxTotals = taxTotals
+}
+
+func (order *Order) GetCreatedAt() time.Time {
+	return order.CreatedAt
+}
+
+func (order *Order) GetStatus() OrderStatus {
+	return order.Status
+}
+
+func (order *Order) GetStatusHistory() []*OrderStatusChange {
+	return order.StatusHistory
+}
+
+func (order *Order) GetShipments() []*OrderShipment {
+	return order.Shipments
+}
+
+// TransitionTo changes the status of the order if the transition is allowed and records the time of the change
+func (order *Order) TransitionTo(status OrderStatus, changedAt time.Time) error {
+	if !order.Status.CanTransitionTo(status) {
+		return NewInvalidOrderStatusTransitionError(order.Status, status)
+	}
+
+	order.Status = status
+	order.StatusHistory = append(order.StatusHistory, &OrderStatusChange{
+		Status:    status,
+		ChangedAt: changedAt.UTC().Truncate(time.Millisecond),
+	})
+
+	return nil
+}
+
+func (orderItem *OrderItem) GetProductID() string {
+	return orderItem.ProductID
+}
+
+func (orderItem *OrderItem) GetProductName() string {
+	return orderItem.ProductName
+}
+
+func (orderItem *OrderItem) GetCount() int {
+	return orderItem.Count
+}
+
+func (orderItem *OrderItem) GetPrice() money.Money {
+	return orderItem.Price
+}
+
+func (orderItem *OrderItem) GetSubtotal() money.Money {
+	return orderItem.Subtotal
+}
+
+func (orderItem *OrderItem) GetTaxClass() tax.TaxClass {
+	return orderItem.TaxClass
+}
+
+func (orderItem *OrderItem) GetTaxBasisPoints() int64 {
+	return orderItem.TaxBasisPoints
+}
+
+func (orderItem *OrderItem) GetNet() money.Money {
+	return orderItem.Net
+}
+
+func (orderItem *OrderItem) GetTax() money.Money {
+	return orderItem.Tax
+}
+
+func (orderItem *OrderItem) GetBackorder() *OrderBackorder {
+	return orderItem.Backorder
+}
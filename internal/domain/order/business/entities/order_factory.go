@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+type OrderFactory interface {
+	NewOrder(userID string, items []*OrderItem) (*Order, error)
+}
+
+var _ OrderFactory = (*OrderFactoryImpl)(nil)
+
+type OrderFactoryImpl struct {
+	now func() time.Time
+}
+
+func NewOrderFactory() OrderFactory {
+	return &OrderFactoryImpl{
+		now: time.Now,
+	}
+}
+
+// NewOrder calculates the subtotals of the items and the totals per currency
+func (factory *OrderFactoryImpl) NewOrder(userID string, items []*OrderItem) (*Order, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID cannot be empty")
+	} else if len(items) == 0 {
+		return nil, fmt.Errorf("items cannot be empty")
+	}
+
+	totals := map[string]money.Money{}
+
+	for _, item := range items {
+		if item == nil {
+			return nil, fmt.Errorf("item cannot be nil")
+		} else if item.ProductID == "" {
+			return nil, fmt.Errorf("item productID cannot be empty")
+		} else if item.Count <= 0 {
+			return nil, fmt.Errorf("item count of product %s must be greater than 0", item.ProductID)
+		} else if item.Price.GetCurrency() == "" || item.Price.IsNegative() {
+			return nil, fmt.Errorf("item price of product %s is invalid", item.ProductID)
+		}
+
+		subtotal, subtotalErr := item.Price.Multiply(int64(item.Count))
+		if subtotalErr != nil {
+			return nil, subtotalErr
+		}
+		item.Subtotal = subtotal
+
+		total, totalExists := totals[item.Subtotal.GetCurrency()]
+		if !totalExists {
+			total = money.Zero(item.Subtotal.GetCurrency())
+		}
+
+		total, err := total.Add(item.Subtotal)
+		if err != nil {
+			return nil, err
+		}
+		totals[item.Subtotal.GetCurrency()] = total
+	}
+
+	currencies := make([]string, 0, len(totals))
+	for currency := range totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	orderTotals := make([]money.Money, 0, len(currencies))
+	for _, currency := range currencies {
+		orderTotals = append(orderTotals, totals[currency])
+	}
+
+	// databases like MongoDB only store milliseconds
+	createdAt := factory.now().UTC().Truncate(time.Millisecond)
+
+	return &Order{
+		UserID:    userID,
+		Items:     items,
+		Totals:    orderTotals,
+		CreatedAt: createdAt,
+		Status:    OrderStatusPending,
+		StatusHistory: []*OrderStatusChange{
+			{Status: OrderStatusPending, ChangedAt: createdAt},
+		},
+	}, nil
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_OrderFactory_NewOrder_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		userID string
+		items  []*OrderItem
+	}{
+		"userID cannot be empty": {
+			userID: "",
+		},
+		"items cannot be empty": {
+			userID: "1337",
+		},
+		"item count of product 1 must be greater than 0": {
+			userID: "1337",
+			items:  []*OrderItem{{ProductID: "1", Count: 0, Price: money.New(100, "EUR")}},
+		},
+		"item price of product 1 is invalid": {
+			userID: "1337",
+			items:  []*OrderItem{{ProductID: "1", Count: 1}},
+		},
+		"amount overflows int64": {
+			userID: "1337",
+			items:  []*OrderItem{{ProductID: "1", Count: 2, Price: money.New(math.MaxInt64/2+1, "EUR")}},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			order, err := NewOrderFactory().NewOrder(testCase.userID, testCase.items)
+
+			require.ErrorContains(t, err, errorString)
+			require.Nil(t, order)
+		})
+	}
+}
+
+func Test_OrderFactory_NewOrder(t *testing.T) {
+	// arrange
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 6_000_007, time.UTC)
+
+	factory := &OrderFactoryImpl{
+		now: func() time.Time {
+			return now
+		},
+	}
+
+	items := []*OrderItem{
+		{ProductID: "1", ProductName: "Product 1", Count: 2, Price: money.New(1199, "EUR")},
+		{ProductID: "2", ProductName: "Product 2", Count: 1, Price: money.New(500, "USD")},
+		{ProductID: "3", ProductName: "Product 3", Count: 3, Price: money.New(100, "EUR")},
+	}
+
+	// act
+
+	order, err := factory.NewOrder("1337", items)
+
+	// assert
+
+	require.NoError(t, err)
+	require.Equal(t, "1337", order.GetUserID())
+	require.Empty(t, order.GetID())
+	require.Equal(t, now.Truncate(time.Millisecond), order.GetCreatedAt())
+	require.Equal(t, OrderStatusPending, order.GetStatus())
+	require.Len(t, order.GetStatusHistory(), 1)
+	require.Equal(t, money.New(2398, "EUR"), order.GetItems()[0].GetSubtotal())
+	require.Equal(t, []money.Money{money.New(2698, "EUR"), money.New(500, "USD")}, order.GetTotals())
+}
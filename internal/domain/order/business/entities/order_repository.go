@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"context"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+//go:generate mockgen -source=order_repository.go -destination=order_repository_mock.go -package=entities
+
+type OrderRepository interface {
+	Find(ctx context.Context, id string) (*Order, error)
+	FindByUserId(ctx context.Context, userId string) ([]*Order, error)
+	Save(ctx context.Context, order *Order) (string, error)
+	// UpdateStatus stores the status and the status history of the order only if the stored order still has expectedStatus.
+	// It returns the error of NewOrderStatusConflictError if the status was changed by someone else after the order was read.
+	UpdateStatus(ctx context.Context, order *Order, expectedStatus OrderStatus) error
+	Delete(ctx context.Context, id string) error
+}
+
+// OrderResource is the resource of the domainerror.NotFoundError returned if an order does not exist
+const OrderResource = "order"
+
+// NewOrderStatusConflictError is returned by the repositories if the status of the order was changed concurrently
+func NewOrderStatusConflictError(orderID string, expectedStatus OrderStatus) *domainerror.ConflictError {
+	return domainerror.NewConflictError("order %s does not have status %s anymore", orderID, expectedStatus)
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusPaid      OrderStatus = "paid"
+	OrderStatusShipped   OrderStatus = "shipped"
+	OrderStatusDelivered OrderStatus = "delivered"
+	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusRefunded  OrderStatus = "refunded"
+)
+
+// orderStatusTransitions contains the allowed target statuses per status,
+// cancelled and refunded are final
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:   {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:      {OrderStatusShipped, OrderStatusRefunded},
+	OrderStatusShipped:   {OrderStatusDelivered},
+	OrderStatusDelivered: {OrderStatusRefunded},
+}
+
+func (status OrderStatus) CanTransitionTo(target OrderStatus) bool {
+	for _, allowedStatus := range orderStatusTransitions[status] {
+		if allowedStatus == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OrderStatusChange records when the order reached a status
+type OrderStatusChange struct {
+	Status    OrderStatus
+	ChangedAt time.Time
+}
+
+func (change *OrderStatusChange) GetStatus() OrderStatus {
+	return change.Status
+}
+
+func (change *OrderStatusChange) GetChangedAt() time.Time {
+	return change.ChangedAt
+}
+
+// NewInvalidOrderStatusTransitionError returns the conflict of a status change which is not allowed
+func NewInvalidOrderStatusTransitionError(from OrderStatus, to OrderStatus) *domainerror.ConflictError {
+	return domainerror.NewConflictError("order status cannot change from %s to %s", from, to)
+}
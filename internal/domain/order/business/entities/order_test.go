@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_Order_TransitionTo(t *testing.T) {
+	testCases := map[string]struct {
+		from    OrderStatus
+		to      OrderStatus
+		allowed bool
+	}{
+		"pending to paid":        {from: OrderStatusPending, to: OrderStatusPaid, allowed: true},
+		"pending to cancelled":   {from: OrderStatusPending, to: OrderStatusCancelled, allowed: true},
+		"pending to shipped":     {from: OrderStatusPending, to: OrderStatusShipped, allowed: false},
+		"paid to shipped":        {from: OrderStatusPaid, to: OrderStatusShipped, allowed: true},
+		"paid to refunded":       {from: OrderStatusPaid, to: OrderStatusRefunded, allowed: true},
+		"paid to cancelled":      {from: OrderStatusPaid, to: OrderStatusCancelled, allowed: false},
+		"shipped to delivered":   {from: OrderStatusShipped, to: OrderStatusDelivered, allowed: true},
+		"shipped to cancelled":   {from: OrderStatusShipped, to: OrderStatusCancelled, allowed: false},
+		"delivered to refunded":  {from: OrderStatusDelivered, to: OrderStatusRefunded, allowed: true},
+		"cancelled to pending":   {from: OrderStatusCancelled, to: OrderStatusPending, allowed: false},
+		"refunded to paid":       {from: OrderStatusRefunded, to: OrderStatusPaid, allowed: false},
+		"cancelled to cancelled": {from: OrderStatusCancelled, to: OrderStatusCancelled, allowed: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			order := &Order{
+				Status: testCase.from,
+			}
+			changedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+			err := order.TransitionTo(testCase.to, changedAt)
+
+			if testCase.allowed {
+				require.NoError(t, err)
+				require.Equal(t, testCase.to, order.GetStatus())
+				require.Len(t, order.GetStatusHistory(), 1)
+				require.Equal(t, testCase.to, order.GetStatusHistory()[0].GetStatus())
+				require.Equal(t, changedAt, order.GetStatusHistory()[0].GetChangedAt())
+			} else {
+				require.ErrorAs(t, err, new(*domainerror.ConflictError))
+				require.Equal(t, testCase.from, order.GetStatus())
+				require.Empty(t, order.GetStatusHistory())
+			}
+		})
+	}
+}
+
+func Test_Order_ApplyDiscounts(t *testing.T) {
+	order := &Order{
+		Totals: []money.Money{money.New(1000, "EUR"), money.New(500, "USD")},
+	}
+
+	err := order.ApplyDiscounts([]*OrderDiscount{
+		{Code: "TEN", Amount: money.New(100, "EUR")},
+		{Code: "FIVE", Amount: money.New(500, "USD")},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, order.GetDiscounts(), 2)
+	require.Equal(t, []money.Money{money.New(900, "EUR"), money.New(0, "USD")}, order.GetTotals())
+}
+
+func Test_Order_ApplyDiscounts_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		discount *OrderDiscount
+	}{
+		"discount BIG exceeds the order total 10.00 EUR": {
+			discount: &OrderDiscount{Code: "BIG", Amount: money.New(1001, "EUR")},
+		},
+		"discount CHF has no total in CHF": {
+			discount: &OrderDiscount{Code: "CHF", Amount: money.New(100, "CHF")},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			order := &Order{
+				Totals: []money.Money{money.New(1000, "EUR")},
+			}
+
+			err := order.ApplyDiscounts([]*OrderDiscount{testCase.discount})
+
+			require.ErrorContains(t, err, errorString)
+			require.Empty(t, order.GetDiscounts())
+			require.Equal(t, []money.Money{money.New(1000, "EUR")}, order.GetTotals())
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/helper"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type CancelOrderUseCaseInput struct {
+	UserID  string
+	OrderID string
+}
+
+type CancelOrderUseCaseOutput struct {
+	Order *dto.OrderDTO
+}
+
+type CancelOrderUseCase interface {
+	Execute(ctx context.Context, input *CancelOrderUseCaseInput) (*CancelOrderUseCaseOutput, error)
+}
+
+func NewCancelOrderUseCaseImpl(
+	orderOutputService helper.OrderOutputService,
+	orderRepository entities.OrderRepository,
+	stockLedgerService warehousehelper.StockLedgerService,
+) CancelOrderUseCase {
+	return &CancelOrderUseCaseImpl{
+		orderOutputService: orderOutputService,
+		orderRepository:    orderRepository,
+		stockLedgerService: stockLedgerService,
+		now:                time.Now,
+	}
+}
+
+var _ CancelOrderUseCase = (*CancelOrderUseCaseImpl)(nil)
+
+type CancelOrderUseCaseImpl struct {
+	orderOutputService helper.OrderOutputService
+	orderRepository    entities.OrderRepository
+	stockLedgerService warehousehelper.StockLedgerService
+	now                func() time.Time
+}
+
+func (useCase *CancelOrderUseCaseImpl) validate(input *CancelOrderUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.UserID == "" {
+		return fmt.Errorf("UserID is empty")
+	} else if input.OrderID == "" {
+		return fmt.Errorf("OrderID is empty")
+	}
+
+	return nil
+}
+
+// Execute cancels a pending order and returns its items to the stock of the locations which would have shipped them,
+// with a cancellation per item and location
+func (useCase *CancelOrderUseCaseImpl) Execute(ctx context.Context, input *CancelOrderUseCaseInput) (*CancelOrderUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	order, orderErr := findUserOrder(ctx, useCase.orderRepository, input.UserID, input.OrderID)
+	if orderErr != nil {
+		return nil, orderErr
+	}
+
+	if !order.GetStatus().CanTransitionTo(entities.OrderStatusCancelled) {
+		return nil, entities.NewInvalidOrderStatusTransitionError(order.GetStatus(), entities.OrderStatusCancelled)
+	}
+
+	previousStatus := order.Status
+	previousStatusHistory := order.StatusHistory
+
+	transitionErr := order.TransitionTo(entities.OrderStatusCancelled, useCase.now())
+	if transitionErr != nil {
+		return nil, transitionErr
+	}
+
+	// the conditional update fails if the order was changed concurrently, e.g. by another server,
+	// so only one of two concurrent cancellations returns the stock
+	updateStatusErr := useCase.orderRepository.UpdateStatus(ctx, order, previousStatus)
+	if updateStatusErr != nil {
+		order.Status = previousStatus
+		order.StatusHistory = previousStatusHistory
+
+		return nil, updateStatusErr
+	}
+
+	allocations := orderAllocations(order)
+	cancellations := make([]*warehouse.StockMovement, 0, len(allocations))
+	for _, allocation := range allocations {
+		cancellation := &warehouse.StockMovement{
+			ProductID:  allocation.ProductID,
+			Type:       warehouse.StockMovementTypeCancellation,
+			Quantity:   allocation.Count,
+			LocationID: allocation.LocationID,
+			Reference:  order.GetID(),
+		}
+
+		recordErr := useCase.stockLedgerService.Record(ctx, cancellation)
+		if recordErr != nil {
+			restoreStockErr := restoreStock(ctx, useCase.stockLedgerService, cancellations)
+
+			order.Status = previousStatus
+			order.StatusHistory = previousStatusHistory
+
+			return nil, errors.Join(recordErr, restoreStockErr, restoreStatus(ctx, useCase.orderRepository, order))
+		}
+
+		cancellations = append(cancellations, cancellation)
+	}
+
+	orderDTO, orderOutputServiceErr := useCase.orderOutputService.CreateOrderDTO(order)
+	if orderOutputServiceErr != nil {
+		return nil, orderOutputServiceErr
+	}
+
+	output := &CancelOrderUseCaseOutput{
+		Order: orderDTO,
+	}
+
+	return output, nil
+}
+
+// restoreStatus stores the previous status of the order again after the stock of a cancelled order could not be returned
+func restoreStatus(ctx context.Context, orderRepository entities.OrderRepository, order *entities.Order) error {
+	err := orderRepository.UpdateStatus(context.WithoutCancel(ctx), order, entities.OrderStatusCancelled)
+	if err != nil {
+		return fmt.Errorf("failed to restore the status of order %s: %w", order.GetID(), err)
+	}
+
+	return nil
+}
+
+// orderAllocations returns the units of the order per location,
+// the units of an order without shipments were sold before there were several locations, so they return to the default location.
+// The backordered units are not part of a shipment, they were sold from the default location.
+func orderAllocations(order *entities.Order) []*warehouse.Allocation {
+	allocations := make([]*warehouse.Allocation, 0, len(order.GetItems()))
+	if len(order.GetShipments()) == 0 && !hasBackorder(order) {
+		for _, orderItem := range order.GetItems() {
+			allocations = append(allocations, &warehouse.Allocation{
+				ProductID:  orderItem.GetProductID(),
+				LocationID: warehouse.DefaultLocationID,
+				Count:      orderItem.GetCount(),
+			})
+		}
+
+		return allocations
+	}
+
+	for _, shipment := range order.GetShipments() {
+		for _, item := range shipment.Items {
+			allocations = append(allocations, &warehouse.Allocation{
+				ProductID:  item.ProductID,
+				LocationID: shipment.LocationID,
+				Count:      item.Count,
+			})
+		}
+	}
+
+	for _, orderItem := range order.GetItems() {
+		backorder := orderItem.GetBackorder()
+		if backorder == nil || backorder.Count == 0 {
+			continue
+		}
+
+		allocations = append(allocations, &warehouse.Allocation{
+			ProductID:  orderItem.GetProductID(),
+			LocationID: warehouse.DefaultLocationID,
+			Count:      backorder.Count,
+		})
+	}
+
+	return allocations
+}
+
+func hasBackorder(order *entities.Order) bool {
+	for _, orderItem := range order.GetItems() {
+		if orderItem.GetBackorder() != nil && orderItem.GetBackorder().Count > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,267 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/helper"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_CancelOrderUseCase_NewCancelOrderUseCaseImpl_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *CancelOrderUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"UserID is empty": {
+			input: &CancelOrderUseCaseInput{},
+		},
+		"OrderID is empty": {
+			input: &CancelOrderUseCaseInput{
+				UserID: "1337",
+			},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := NewCancelOrderUseCaseImpl(helper.NewOrderOutputService(), entities.NewMockOrderRepository(ctrl), newStockLedgerStub())
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.Error(t, err)
+			require.ErrorContains(t, err, errorString)
+		})
+	}
+}
+
+func newCancelOrderTestProducts() (*warehouse.Product, *warehouse.Product) {
+	return &warehouse.Product{ID: "1", Name: "Product 1", Stock: 8, Price: money.New(1337, "EUR")},
+		&warehouse.Product{ID: "2", Name: "Product 2", Stock: 0, Price: money.New(420, "EUR")}
+}
+
+func Test_CancelOrderUseCase(t *testing.T) {
+	// arrange
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	order := newTestOrder(t, "order-1", "1337")
+	product1, product2 := newCancelOrderTestProducts()
+
+	orderRepositoryMock := entities.NewMockOrderRepository(ctrl)
+	orderRepositoryMock.EXPECT().Find(gomock.Any(), "order-1").Return(order, nil)
+	orderRepositoryMock.EXPECT().UpdateStatus(gomock.Any(), order, entities.OrderStatusPending).Return(nil)
+
+	stockLedger := newStockLedgerStub(product1, product2)
+
+	cancelledAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	useCase := NewCancelOrderUseCaseImpl(helper.NewOrderOutputService(), orderRepositoryMock, stockLedger)
+	useCase.(*CancelOrderUseCaseImpl).now = func() time.Time {
+		return cancelledAt
+	}
+
+	// act
+
+	output, err := useCase.Execute(t.Context(), &CancelOrderUseCaseInput{UserID: "1337", OrderID: "order-1"})
+
+	// assert
+
+	require.NoError(t, err)
+	require.Equal(t, string(entities.OrderStatusCancelled), output.Order.Status)
+	require.Len(t, output.Order.StatusHistory, 2)
+	require.Equal(t, cancelledAt, output.Order.StatusHistory[1].ChangedAt)
+
+	require.Equal(t, 10, product1.Stock)
+	require.Equal(t, 1, product2.Stock)
+	// the order has no shipments, so its units return to the default location
+	require.Equal(t, []*warehouse.StockMovement{
+		{ProductID: "1", Type: warehouse.StockMovementTypeCancellation, Quantity: 2, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+		{ProductID: "2", Type: warehouse.StockMovementTypeCancellation, Quantity: 1, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+	}, stockLedger.movements)
+}
+
+func Test_CancelOrderUseCase_Shipments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	order := newTestOrder(t, "order-1", "1337")
+	order.Shipments = []*entities.OrderShipment{
+		{LocationID: "hamburg", Items: []*entities.OrderShipmentItem{{ProductID: "1", Count: 1}, {ProductID: "2", Count: 1}}},
+		{LocationID: warehouse.DefaultLocationID, Items: []*entities.OrderShipmentItem{{ProductID: "1", Count: 1}}},
+	}
+	product1, product2 := newCancelOrderTestProducts()
+
+	orderRepositoryMock := entities.NewMockOrderRepository(ctrl)
+	orderRepositoryMock.EXPECT().Find(gomock.Any(), "order-1").Return(order, nil)
+	orderRepositoryMock.EXPECT().UpdateStatus(gomock.Any(), order, entities.OrderStatusPending).Return(nil)
+
+	stockLedger := newStockLedgerStub(product1, product2)
+
+	useCase := NewCancelOrderUseCaseImpl(helper.NewOrderOutputService(), orderRepositoryMock, stockLedger)
+
+	_, err := useCase.Execute(t.Context(), &CancelOrderUseCaseInput{UserID: "1337", OrderID: "order-1"})
+
+	require.NoError(t, err)
+
+	// the units return to the locations which shipped them
+	require.Equal(t, []*warehouse.StockMovement{
+		{ProductID: "1", Type: warehouse.StockMovementTypeCancellation, Quantity: 1, LocationID: "hamburg", Reference: "order-1"},
+		{ProductID: "2", Type: warehouse.StockMovementTypeCancellation, Quantity: 1, LocationID: "hamburg", Reference: "order-1"},
+		{ProductID: "1", Type: warehouse.StockMovementTypeCancellation, Quantity: 1, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+	}, stockLedger.movements)
+}
+
+func Test_CancelOrderUseCase_Backorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	order := newTestOrder(t, "order-1", "1337")
+	order.Shipments = []*entities.OrderShipment{
+		{LocationID: "hamburg", Items: []*entities.OrderShipmentItem{{ProductID: "1", Count: 2}}},
+	}
+	order.GetItems()[1].Backorder = &entities.OrderBackorder{Type: "backorder", Count: 1}
+	product1, product2 := newCancelOrderTestProducts()
+	product2.Stock = -1
+
+	orderRepositoryMock := entities.NewMockOrderRepository(ctrl)
+	orderRepositoryMock.EXPECT().Find(gomock.Any(), "order-1").Return(order, nil)
+	orderRepositoryMock.EXPECT().UpdateStatus(gomock.Any(), order, entities.OrderStatusPending).Return(nil)
+
+	stockLedger := newStockLedgerStub(product1, product2)
+
+	useCase := NewCancelOrderUseCaseImpl(helper.NewOrderOutputService(), orderRepositoryMock, stockLedger)
+
+	_, err := useCase.Execute(t.Context(), &CancelOrderUseCaseInput{UserID: "1337", OrderID: "order-1"})
+
+	require.NoError(t, err)
+
+	// the backordered units were sold from the default location
+	require.Equal(t, 0, product2.Stock)
+	require.Equal(t, []*warehouse.StockMovement{
+		{ProductID: "1", Type: warehouse.StockMovementTypeCancellation, Quantity: 2, LocationID: "hamburg", Reference: "order-1"},
+		{ProductID: "2", Type: warehouse.StockMovementTypeCancellation, Quantity: 1, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+	}, stockLedger.movements)
+}
+
+func Test_CancelOrderUseCase_InvalidStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	order := newTestOrder(t, "order-1", "1337")
+	require.NoError(t, order.TransitionTo(entities.OrderStatusPaid, time.Now()))
+
+	orderRepositoryMock := entities.NewMockOrderRepository(ctrl)
+	orderRepositoryMock.EXPECT().Find(gomock.Any(), "order-1").Return(order, nil)
+
+	useCase := NewCancelOrderUseCaseImpl(helper.NewOrderOutputService(), orderRepositoryMock, newStockLedgerStub())
+
+	output, err := useCase.Execute(t.Context(), &CancelOrderUseCaseInput{UserID: "1337", OrderID: "order-1"})
+
+	require.ErrorAs(t, err, new(*domainerror.ConflictError))
+	require.Nil(t, output)
+	require.Equal(t, entities.OrderStatusPaid, order.GetStatus())
+}
+
+func Test_CancelOrderUseCase_StatusChangedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	order := newTestOrder(t, "order-1", "1337")
+	product1, product2 := newCancelOrderTestProducts()
+
+	// another request cancelled the order after it was read
+	orderRepositoryMock := entities.NewMockOrderRepository(ctrl)
+	orderRepositoryMock.EXPECT().Find(gomock.Any(), "order-1").Return(order, nil)
+	orderRepositoryMock.EXPECT().UpdateStatus(gomock.Any(), order, entities.OrderStatusPending).Return(entities.NewOrderStatusConflictError("order-1", entities.OrderStatusPending))
+
+	stockLedger := newStockLedgerStub(product1, product2)
+
+	useCase := NewCancelOrderUseCaseImpl(helper.NewOrderOutputService(), orderRepositoryMock, stockLedger)
+
+	output, err := useCase.Execute(t.Context(), &CancelOrderUseCaseInput{UserID: "1337", OrderID: "order-1"})
+
+	require.ErrorAs(t, err, new(*domainerror.ConflictError))
+	require.Nil(t, output)
+
+	// the stock is only returned by the request which cancelled the order
+	require.Equal(t, entities.OrderStatusPending, order.GetStatus())
+	require.Len(t, order.GetStatusHistory(), 1)
+	require.Empty(t, stockLedger.movements)
+	require.Equal(t, 8, product1.Stock)
+	require.Equal(t, 0, product2.Stock)
+}
+
+func Test_CancelOrderUseCase_OrderRepositoryUpdateStatusFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	order := newTestOrder(t, "order-1", "1337")
+	product1, product2 := newCancelOrderTestProducts()
+
+	orderRepositoryMock := entities.NewMockOrderRepository(ctrl)
+	orderRepositoryMock.EXPECT().Find(gomock.Any(), "order-1").Return(order, nil)
+	orderRepositoryMock.EXPECT().UpdateStatus(gomock.Any(), order, entities.OrderStatusPending).Return(fmt.Errorf("order repository error"))
+
+	useCase := NewCancelOrderUseCaseImpl(helper.NewOrderOutputService(), orderRepositoryMock, newStockLedgerStub(product1, product2))
+
+	output, err := useCase.Execute(t.Context(), &CancelOrderUseCaseInput{UserID: "1337", OrderID: "order-1"})
+
+	require.ErrorContains(t, err, "order repository error")
+	require.Nil(t, output)
+
+	require.Equal(t, entities.OrderStatusPending, order.GetStatus())
+	require.Len(t, order.GetStatusHistory(), 1)
+	require.Equal(t, 8, product1.Stock)
+	require.Equal(t, 0, product2.Stock)
+}
+
+func Test_CancelOrderUseCase_RecordFailsRestoresStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	order := newTestOrder(t, "order-1", "1337")
+	product1, product2 := newCancelOrderTestProducts()
+
+	// the order is cancelled first and gets its previous status back after the stock of product 2 could not be returned
+	orderRepositoryMock := entities.NewMockOrderRepository(ctrl)
+	orderRepositoryMock.EXPECT().Find(gomock.Any(), "order-1").Return(order, nil)
+	gomock.InOrder(
+		orderRepositoryMock.EXPECT().UpdateStatus(gomock.Any(), order, entities.OrderStatusPending).Return(nil),
+		orderRepositoryMock.EXPECT().UpdateStatus(gomock.Any(), order, entities.OrderStatusCancelled).DoAndReturn(func(ctx context.Context, order *entities.Order, expectedStatus entities.OrderStatus) error {
+			require.Equal(t, entities.OrderStatusPending, order.GetStatus())
+
+			return fmt.Errorf("order repository error")
+		}),
+	)
+
+	stockLedger := newStockLedgerStub(product1, product2)
+	stockLedger.errs["2"] = fmt.Errorf("ledger error")
+
+	useCase := NewCancelOrderUseCaseImpl(helper.NewOrderOutputService(), orderRepositoryMock, stockLedger)
+
+	output, err := useCase.Execute(t.Context(), &CancelOrderUseCaseInput{UserID: "1337", OrderID: "order-1"})
+
+	require.ErrorContains(t, err, "ledger error")
+	require.ErrorContains(t, err, "failed to restore the status of order order-1: order repository error")
+	require.Nil(t, output)
+
+	require.Equal(t, entities.OrderStatusPending, order.GetStatus())
+	require.Len(t, order.GetStatusHistory(), 1)
+	require.Equal(t, 8, product1.Stock)
+	require.Equal(t, 0, product2.Stock)
+}
@@ -0,0 +1,438 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	basket "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/helper"
+	promotion "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	promotionhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/usecases/helper"
+	taxhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/usecases/helper"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type CheckoutUseCaseInput struct {
+	UserID string
+	// ExpectedBasketVersion is the basket version the client has seen, nil orders the current basket
+	ExpectedBasketVersion *int64
+	// Destination is optional, the warehouse locations nearest to it ship the order
+	Destination *warehouse.Position
+}
+
+type CheckoutUseCaseOutput struct {
+	Order *dto.OrderDTO
+}
+
+type CheckoutUseCase interface {
+	Execute(ctx context.Context, input *CheckoutUseCaseInput) (*CheckoutUseCaseOutput, error)
+}
+
+func NewCheckoutUseCaseImpl(
+	orderFactory entities.OrderFactory,
+	orderOutputService helper.OrderOutputService,
+	orderRepository entities.OrderRepository,
+	basketRepository basket.BasketRepository,
+	productRepository warehouse.ProductRepository,
+	stockReservationService warehousehelper.StockReservationService,
+	stockLedgerService warehousehelper.StockLedgerService,
+	stockAllocationService warehousehelper.StockAllocationService,
+	promotionEngine promotionhelper.PromotionEngine,
+	taxCalculationService taxhelper.TaxCalculationService,
+) CheckoutUseCase {
+	return &CheckoutUseCaseImpl{
+		orderFactory:            orderFactory,
+		orderOutputService:      orderOutputService,
+		orderRepository:         orderRepository,
+		basketRepository:        basketRepository,
+		productRepository:       productRepository,
+		stockReservationService: stockReservationService,
+		stockLedgerService:      stockLedgerService,
+		stockAllocationService:  stockAllocationService,
+		promotionEngine:         promotionEngine,
+		taxCalculationService:   taxCalculationService,
+	}
+}
+
+var _ CheckoutUseCase = (*CheckoutUseCaseImpl)(nil)
+
+type CheckoutUseCaseImpl struct {
+	orderFactory            entities.OrderFactory
+	orderOutputService      helper.OrderOutputService
+	orderRepository         entities.OrderRepository
+	basketRepository        basket.BasketRepository
+	productRepository       warehouse.ProductRepository
+	stockReservationService warehousehelper.StockReservationService
+	stockLedgerService      warehousehelper.StockLedgerService
+	stockAllocationService  warehousehelper.StockAllocationService
+	promotionEngine         promotionhelper.PromotionEngine
+	taxCalculationService   taxhelper.TaxCalculationService
+
+	// mutex serializes checkouts so that the stock check and the stock update cannot interleave
+	mutex sync.Mutex
+}
+
+func (useCase *CheckoutUseCaseImpl) validate(input *CheckoutUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.UserID == "" {
+		return fmt.Errorf("UserID is empty")
+	} else if input.Destination != nil {
+		destinationErr := input.Destination.Validate()
+		if destinationErr != nil {
+			return fmt.Errorf("input parameter Destination is invalid: %w", destinationErr)
+		}
+	}
+
+	return nil
+}
+
+// Execute creates the order with the discounts of the applicable coupons, records the sales in the stock ledger and clears the basket.
+// The sales are split between the warehouse locations picked by the allocation strategy, the order lists them as shipments.
+// The units which are not in stock are backordered from the default location if the stock policy of the product allows it.
+// If any of these steps fails the previous steps are undone, so no order is created and the stock is unchanged.
+// A basket which was changed concurrently is not ordered, the domainerror.VersionConflictError is returned instead.
+func (useCase *CheckoutUseCaseImpl) Execute(ctx context.Context, input *CheckoutUseCaseInput) (*CheckoutUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	useCase.mutex.Lock()
+	defer useCase.mutex.Unlock()
+
+	userBasket, userBasketErr := useCase.basketRepository.FindByUserId(ctx, input.UserID)
+	if userBasketErr != nil {
+		if domainerror.IsNotFound(userBasketErr, basket.BasketResource) {
+			return nil, domainerror.NewValidationError("basket is empty")
+		}
+		return nil, userBasketErr
+	}
+
+	if input.ExpectedBasketVersion != nil && *input.ExpectedBasketVersion != userBasket.GetVersion() {
+		return nil, &domainerror.VersionConflictError{Resource: basket.BasketResource, ID: userBasket.GetID(), Version: *input.ExpectedBasketVersion}
+	}
+
+	if len(userBasket.GetItems()) == 0 {
+		return nil, domainerror.NewValidationError("basket is empty")
+	}
+
+	productIDs := make([]string, 0, len(userBasket.GetItems()))
+	for productID := range userBasket.GetItems() {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Strings(productIDs)
+
+	orderItems := make([]*entities.OrderItem, 0, len(productIDs))
+	allocationItems := make([]*warehouse.AllocationItem, 0, len(productIDs))
+	backorderAllocations := make([]*warehouse.Allocation, 0)
+	promotionItems := make([]*promotion.PromotionItem, 0, len(productIDs))
+	taxableAmounts := make([]*taxhelper.TaxableAmount, 0, len(productIDs))
+
+	for _, productID := range productIDs {
+		basketItem := userBasket.GetItems()[productID]
+
+		product, productErr := useCase.productRepository.Find(ctx, productID)
+		if productErr != nil {
+			return nil, productErr
+		}
+
+		if !product.IsActive() {
+			return nil, warehouse.NewProductDeactivatedError(productID)
+		}
+
+		// the units in the baskets of other users are not available
+		sellableStock, sellableStockErr := useCase.stockReservationService.SellableStock(ctx, productID, input.UserID)
+		if sellableStockErr != nil {
+			return nil, sellableStockErr
+		}
+
+		if sellableStock < basketItem.GetCount() {
+			return nil, &domainerror.OutOfStockError{
+				ProductID: productID,
+				Available: sellableStock,
+				Requested: basketItem.GetCount(),
+			}
+		}
+
+		backorder, backorderErr := useCase.newOrderBackorder(ctx, input.UserID, product, basketItem.GetCount())
+		if backorderErr != nil {
+			return nil, backorderErr
+		}
+
+		subtotal, subtotalErr := product.Price.Multiply(int64(basketItem.GetCount()))
+		if subtotalErr != nil {
+			return nil, subtotalErr
+		}
+
+		itemTax, itemTaxErr := useCase.taxCalculationService.Calculate(product.TaxClass, subtotal)
+		if itemTaxErr != nil {
+			return nil, itemTaxErr
+		}
+
+		orderItems = append(orderItems, &entities.OrderItem{
+			ProductID:      product.ID,
+			ProductName:    product.Name,
+			Count:          basketItem.GetCount(),
+			Price:          product.Price,
+			TaxClass:       itemTax.Class,
+			TaxBasisPoints: itemTax.BasisPoints,
+			Net:            itemTax.Net,
+			Tax:            itemTax.Tax,
+			Backorder:      backorder,
+		})
+
+		// the backordered units are sold from the default location, the stock is allocated to the locations
+		if backorder != nil && backorder.Count > 0 {
+			backorderAllocations = append(backorderAllocations, &warehouse.Allocation{
+				ProductID:  product.ID,
+				LocationID: warehouse.DefaultLocationID,
+				Count:      backorder.Count,
+			})
+		}
+		if stockCount := basketItem.GetCount() - backorderCount(backorder); stockCount > 0 {
+			allocationItems = append(allocationItems, &warehouse.AllocationItem{
+				ProductID: product.ID,
+				Count:     stockCount,
+			})
+		}
+		promotionItems = append(promotionItems, &promotion.PromotionItem{
+			ProductID: product.ID,
+			Count:     basketItem.GetCount(),
+			Price:     product.Price,
+		})
+		taxableAmounts = append(taxableAmounts, &taxhelper.TaxableAmount{
+			ProductID: product.ID,
+			Class:     itemTax.Class,
+			Gross:     subtotal,
+		})
+	}
+
+	allocations := make([]*warehouse.Allocation, 0, len(allocationItems))
+	if len(allocationItems) > 0 {
+		var allocationErr error
+		allocations, allocationErr = useCase.stockAllocationService.Allocate(ctx, allocationItems, input.Destination)
+		if allocationErr != nil {
+			return nil, allocationErr
+		}
+	}
+
+	order, orderErr := useCase.orderFactory.NewOrder(input.UserID, orderItems)
+	if orderErr != nil {
+		return nil, orderErr
+	}
+	// the backordered units are not shipped yet, so they are part of the items but not of the shipments
+	order.Shipments = newOrderShipments(allocations)
+	allocations = append(allocations, backorderAllocations...)
+
+	// coupons which cannot be applied anymore are ignored, the basket output already informed the user about them
+	promotionResult, promotionErr := useCase.promotionEngine.Evaluate(ctx, userBasket.GetCoupons(), promotionItems)
+	if promotionErr != nil {
+		return nil, promotionErr
+	}
+
+	orderDiscounts := make([]*entities.OrderDiscount, 0, len(promotionResult.Discounts))
+	taxableDiscounts := make([]*taxhelper.TaxableDiscount, 0, len(promotionResult.Discounts))
+	for _, discount := range promotionResult.Discounts {
+		orderDiscounts = append(orderDiscounts, &entities.OrderDiscount{
+			Code:      discount.Code,
+			Reason:    discount.Reason,
+			ProductID: discount.ProductID,
+			Amount:    discount.Amount,
+		})
+		taxableDiscounts = append(taxableDiscounts, &taxhelper.TaxableDiscount{
+			ProductID: discount.ProductID,
+			Amount:    discount.Amount,
+		})
+	}
+
+	applyDiscountsErr := order.ApplyDiscounts(orderDiscounts)
+	if applyDiscountsErr != nil {
+		return nil, applyDiscountsErr
+	}
+
+	taxTotals, taxTotalsErr := useCase.taxCalculationService.CalculateTotals(taxableAmounts, taxableDiscounts)
+	if taxTotalsErr != nil {
+		return nil, taxTotalsErr
+	}
+	order.SetTaxTotals(useCase.taxCalculationService.GetCountry(), newOrderTaxTotals(taxTotals))
+
+	orderID, orderRepositorySaveErr := useCase.orderRepository.Save(ctx, order)
+	if orderRepositorySaveErr != nil {
+		return nil, orderRepositorySaveErr
+	}
+
+	// the sales reference the order, so the order is saved first and removed again if a sale fails
+	sales := make([]*warehouse.StockMovement, 0, len(allocations))
+	for _, allocation := range allocations {
+		sale := &warehouse.StockMovement{
+			ProductID:  allocation.ProductID,
+			Type:       warehouse.StockMovementTypeSale,
+			Quantity:   -allocation.Count,
+			LocationID: allocation.LocationID,
+			Reference:  orderID,
+		}
+
+		recordErr := useCase.stockLedgerService.Record(ctx, sale)
+		if recordErr != nil {
+			restoreStockErr := restoreStock(ctx, useCase.stockLedgerService, sales)
+			orderRepositoryDeleteErr := useCase.orderRepository.Delete(context.WithoutCancel(ctx), orderID)
+
+			return nil, errors.Join(recordErr, restoreStockErr, orderRepositoryDeleteErr)
+		}
+
+		sales = append(sales, sale)
+	}
+
+	basketItems := userBasket.Items
+	basketCoupons := userBasket.Coupons
+	userBasket.Clear()
+
+	_, basketRepositorySaveErr := useCase.basketRepository.Save(ctx, userBasket)
+	if basketRepositorySaveErr != nil {
+		userBasket.Items = basketItems
+		userBasket.Coupons = basketCoupons
+		restoreStockErr := restoreStock(ctx, useCase.stockLedgerService, sales)
+
+		// like the stock, the order is removed even if the context is already cancelled
+		orderRepositoryDeleteErr := useCase.orderRepository.Delete(context.WithoutCancel(ctx), orderID)
+
+		return nil, errors.Join(basketRepositorySaveErr, restoreStockErr, orderRepositoryDeleteErr)
+	}
+
+	// the ordered units left the stock, so the reservations are not needed anymore
+	releaseErr := useCase.stockReservationService.ReleaseAll(ctx, input.UserID)
+	if releaseErr != nil {
+		log.Printf("failed to release the reservations of user %s: %v", input.UserID, releaseErr)
+	}
+
+	orderDTO, orderOutputServiceErr := useCase.orderOutputService.CreateOrderDTO(order)
+	if orderOutputServiceErr != nil {
+		return nil, orderOutputServiceErr
+	}
+
+	output := &CheckoutUseCaseOutput{
+		Order: orderDTO,
+	}
+
+	return output, nil
+}
+
+// newOrderBackorder returns nil if the units are in stock and the product is not a pre-order,
+// the units in the baskets of other users are not in stock
+func (useCase *CheckoutUseCaseImpl) newOrderBackorder(ctx context.Context, userID string, product *warehouse.Product, count int) (*entities.OrderBackorder, error) {
+	if !product.StockPolicy.AllowsBackorder() {
+		return nil, nil
+	}
+
+	availableStock, availableStockErr := useCase.stockReservationService.AvailableStock(ctx, product.ID, userID)
+	if availableStockErr != nil {
+		return nil, availableStockErr
+	}
+
+	backordered := max(count-availableStock, 0)
+	if backordered == 0 && product.StockPolicy.Type != warehouse.StockPolicyTypePreorder {
+		return nil, nil
+	}
+
+	return &entities.OrderBackorder{
+		Type:          string(product.StockPolicy.Type),
+		Count:         backordered,
+		EstimatedDate: product.StockPolicy.RestockDate,
+	}, nil
+}
+
+func backorderCount(backorder *entities.OrderBackorder) int {
+	if backorder == nil {
+		return 0
+	}
+
+	return backorder.Count
+}
+
+// restoreStock undoes the given sales or cancellations by recording their reverse movements,
+// even if the context is already cancelled, because a cancelled request must not lose stock.
+// All movements are tried, the returned error contains every failed product.
+func restoreStock(ctx context.Context, stockLedgerService warehousehelper.StockLedgerService, movements []*warehouse.StockMovement) error {
+	ctx = context.WithoutCancel(ctx)
+
+	var errs []error
+	for _, movement := range movements {
+		err := stockLedgerService.Record(ctx, reverseMovement(movement))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore the stock of product %s: %w", movement.ProductID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reverseMovement returns the movement which undoes a sale or a cancellation,
+// the entries of the ledger are never changed
+func reverseMovement(movement *warehouse.StockMovement) *warehouse.StockMovement {
+	reverseType := warehouse.StockMovementTypeCancellation
+	if movement.Type == warehouse.StockMovementTypeCancellation {
+		reverseType = warehouse.StockMovementTypeSale
+	}
+
+	return &warehouse.StockMovement{
+		ProductID:  movement.ProductID,
+		Type:       reverseType,
+		Quantity:   -movement.Quantity,
+		LocationID: movement.LocationID,
+		Reference:  movement.Reference,
+	}
+}
+
+// newOrderShipments groups the allocations by location, the locations keep the order of the allocations
+func newOrderShipments(allocations []*warehouse.Allocation) []*entities.OrderShipment {
+	shipments := make([]*entities.OrderShipment, 0)
+	shipmentsByLocation := make(map[string]*entities.OrderShipment)
+	for _, allocation := range allocations {
+		shipment, shipmentExists := shipmentsByLocation[allocation.LocationID]
+		if !shipmentExists {
+			shipment = &entities.OrderShipment{LocationID: allocation.LocationID}
+			shipmentsByLocation[allocation.LocationID] = shipment
+			shipments = append(shipments, shipment)
+		}
+
+		shipment.Items = append(shipment.Items, &entities.OrderShipmentItem{
+			ProductID: allocation.ProductID,
+			Count:     allocation.Count,
+		})
+	}
+
+	return shipments
+}
+
+func newOrderTaxTotals(taxTotals []*taxhelper.TaxTotal) []*entities.OrderTaxTotal {
+	orderTaxTotals := make([]*entities.OrderTaxTotal, 0, len(taxTotals))
+	for _, taxTotal := range taxTotals {
+		orderTaxTotal := &entities.OrderTaxTotal{
+			Net:     taxTotal.Net,
+			Tax:     taxTotal.Tax,
+			Gross:   taxTotal.Gross,
+			Classes: make([]*entities.OrderTax, 0, len(taxTotal.Classes)),
+		}
+		for _, classTax := range taxTotal.Classes {
+			orderTaxTotal.Classes = append(orderTaxTotal.Classes, &entities.OrderTax{
+				Class:       classTax.Class,
+				BasisPoints: classTax.BasisPoints,
+				Net:         classTax.Net,
+				Tax:         classTax.Tax,
+				Gross:       classTax.Gross,
+			})
+		}
+		orderTaxTotals = append(orderTaxTotals, orderTaxTotal)
+	}
+
+	return orderTaxTotals
+}
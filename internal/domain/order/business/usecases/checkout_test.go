@@ -0,0 +1,677 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	basket "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/basket/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/helper"
+	promotion "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	promotionhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/usecases/helper"
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	taxhelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/usecases/helper"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	warehousehelper "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+// newTestTaxCalculationService returns a tax calculation service with the german standard rate
+func newTestTaxCalculationService(t *testing.T) taxhelper.TaxCalculationService {
+	taxCalculationService, err := taxhelper.NewTaxCalculationService("DE", []*tax.TaxRate{
+		{Country: "DE", Class: tax.TaxClassStandard, BasisPoints: 1900},
+	})
+	require.NoError(t, err)
+
+	return taxCalculationService
+}
+
+func Test_CheckoutUseCase_NewCheckoutUseCaseImpl_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *CheckoutUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"UserID is empty": {
+			input: &CheckoutUseCaseInput{},
+		},
+		"input parameter Destination is invalid: latitude 100 must be between -90 and 90": {
+			input: &CheckoutUseCaseInput{UserID: "1337", Destination: &warehouse.Position{Latitude: 100}},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := NewCheckoutUseCaseImpl(
+				entities.NewOrderFactory(),
+				helper.NewOrderOutputService(),
+				entities.NewMockOrderRepository(ctrl),
+				basket.NewMockBasketRepository(ctrl),
+				warehouse.NewMockProductRepository(ctrl),
+				warehousehelper.NewMockStockReservationService(ctrl),
+				warehousehelper.NewMockStockLedgerService(ctrl),
+				warehousehelper.NewMockStockAllocationService(ctrl),
+				promotionhelper.NewPromotionEngine(promotion.NewMockPromotionRepository(ctrl)),
+				newTestTaxCalculationService(t),
+			)
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.Error(t, err)
+			require.ErrorContains(t, err, errorString)
+		})
+	}
+}
+
+// stockLedgerStub changes the stock of its products like the stock ledger and remembers the recorded movements
+type stockLedgerStub struct {
+	products map[string]*warehouse.Product
+	// locations contains the stock per product at the locations besides the default location,
+	// the rest of the stock of a product is at the default location
+	locations map[string]map[string]int
+	movements []*warehouse.StockMovement
+	// errs contains the errors returned per product
+	errs map[string]error
+}
+
+func newStockLedgerStub(products ...*warehouse.Product) *stockLedgerStub {
+	ledger := &stockLedgerStub{
+		products:  map[string]*warehouse.Product{},
+		locations: map[string]map[string]int{},
+		errs:      map[string]error{},
+	}
+	for _, product := range products {
+		ledger.products[product.ID] = product
+	}
+
+	return ledger
+}
+
+func (ledger *stockLedgerStub) Record(ctx context.Context, movement *warehouse.StockMovement) error {
+	err := ledger.errs[movement.ProductID]
+	if err != nil {
+		return err
+	}
+
+	product, productExists := ledger.products[movement.ProductID]
+	if !productExists {
+		return &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: movement.ProductID}
+	}
+
+	product.Stock += movement.Quantity
+	if movement.LocationID != "" && movement.LocationID != warehouse.DefaultLocationID {
+		ledger.setLocationStock(movement.ProductID, movement.LocationID, ledger.locations[movement.ProductID][movement.LocationID]+movement.Quantity)
+	}
+	ledger.movements = append(ledger.movements, movement)
+
+	return nil
+}
+
+func (ledger *stockLedgerStub) Balance(ctx context.Context, productID string) (*warehouse.StockBalance, error) {
+	product, productExists := ledger.products[productID]
+	if !productExists {
+		return nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: productID}
+	}
+
+	balance := &warehouse.StockBalance{Stock: product.Stock, Locations: map[string]int{warehouse.DefaultLocationID: product.Stock}}
+	for locationID, stock := range ledger.locations[productID] {
+		balance.Locations[locationID] = stock
+		balance.Locations[warehouse.DefaultLocationID] -= stock
+	}
+
+	return balance, nil
+}
+
+// setLocationStock moves stock of the product from the default location to the location
+func (ledger *stockLedgerStub) setLocationStock(productID string, locationID string, stock int) {
+	if ledger.locations[productID] == nil {
+		ledger.locations[productID] = map[string]int{}
+	}
+	ledger.locations[productID][locationID] = stock
+}
+
+func (ledger *stockLedgerStub) RecordOpeningBalances(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (ledger *stockLedgerStub) UpdateProduct(ctx context.Context, productID string, update func(product *warehouse.Product) error) (*warehouse.Product, error) {
+	product, productExists := ledger.products[productID]
+	if !productExists {
+		return nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: productID}
+	}
+
+	updateErr := update(product)
+	if updateErr != nil {
+		return nil, updateErr
+	}
+
+	return product, nil
+}
+
+var (
+	berlin  = &warehouse.Location{ID: warehouse.DefaultLocationID, Position: warehouse.Position{Latitude: 52.52, Longitude: 13.405}}
+	hamburg = &warehouse.Location{ID: "hamburg", Position: warehouse.Position{Latitude: 53.551, Longitude: 9.994}}
+)
+
+type checkoutTestFixture struct {
+	userBasket            *basket.Basket
+	product1              *warehouse.Product
+	product2              *warehouse.Product
+	orderRepositoryMock   *entities.MockOrderRepository
+	basketRepositoryMock  *basket.MockBasketRepository
+	productRepositoryMock *warehouse.MockProductRepository
+	// promotionRepositoryMock is used for the coupons of the basket
+	promotionRepositoryMock *promotion.MockPromotionRepository
+	// reservedByOthers contains the units per product reserved by other users
+	reservedByOthers map[string]int
+	stockLedger      *stockLedgerStub
+	useCase          CheckoutUseCase
+}
+
+func newCheckoutTestFixture(t *testing.T, ctrl *gomock.Controller) *checkoutTestFixture {
+	product1 := &warehouse.Product{
+		ID:    "1",
+		Name:  "Product 1",
+		Stock: 10,
+		Price: money.New(1337, "EUR"),
+	}
+	product2 := &warehouse.Product{
+		ID:    "2",
+		Name:  "Product 2",
+		Stock: 3,
+		Price: money.New(420, "EUR"),
+	}
+
+	userBasket, err := basket.NewBasketFactory().NewBasketWithID("1", "1337")
+	require.NoError(t, err)
+	userBasket.AddItem(product1.ID, 2)
+	userBasket.AddItem(product2.ID, 3)
+
+	orderRepositoryMock := entities.NewMockOrderRepository(ctrl)
+	basketRepositoryMock := basket.NewMockBasketRepository(ctrl)
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	promotionRepositoryMock := promotion.NewMockPromotionRepository(ctrl)
+
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product1.ID).Return(product1, nil).AnyTimes()
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product2.ID).Return(product2, nil).AnyTimes()
+
+	fixture := &checkoutTestFixture{
+		userBasket:              userBasket,
+		product1:                product1,
+		product2:                product2,
+		orderRepositoryMock:     orderRepositoryMock,
+		basketRepositoryMock:    basketRepositoryMock,
+		productRepositoryMock:   productRepositoryMock,
+		promotionRepositoryMock: promotionRepositoryMock,
+		reservedByOthers:        map[string]int{},
+		stockLedger:             newStockLedgerStub(product1, product2),
+	}
+
+	stockReservationServiceMock := warehousehelper.NewMockStockReservationService(ctrl)
+	stockReservationServiceMock.EXPECT().AvailableStock(gomock.Any(), gomock.Any(), "1337").DoAndReturn(func(ctx context.Context, productID string, holderID string) (int, error) {
+		product, err := productRepositoryMock.Find(ctx, productID)
+		if err != nil {
+			return 0, err
+		}
+		return max(product.Stock-fixture.reservedByOthers[productID], 0), nil
+	}).AnyTimes()
+	stockReservationServiceMock.EXPECT().SellableStock(gomock.Any(), gomock.Any(), "1337").DoAndReturn(func(ctx context.Context, productID string, holderID string) (int, error) {
+		product, err := productRepositoryMock.Find(ctx, productID)
+		if err != nil {
+			return 0, err
+		}
+		return max(product.SellableStock()-fixture.reservedByOthers[productID], 0), nil
+	}).AnyTimes()
+	stockReservationServiceMock.EXPECT().ReleaseAll(gomock.Any(), "1337").Return(nil).AnyTimes()
+
+	locationRepositoryMock := warehouse.NewMockLocationRepository(ctrl)
+	locationRepositoryMock.EXPECT().Find(gomock.Any(), warehouse.DefaultLocationID).Return(berlin, nil).AnyTimes()
+	locationRepositoryMock.EXPECT().FindAll(gomock.Any()).Return([]*warehouse.Location{hamburg, berlin}, nil).AnyTimes()
+
+	stockAllocationService, err := warehousehelper.NewStockAllocationService(locationRepositoryMock, fixture.stockLedger, &warehouse.NearestAllocationStrategy{})
+	require.NoError(t, err)
+
+	fixture.useCase = NewCheckoutUseCaseImpl(
+		entities.NewOrderFactory(),
+		helper.NewOrderOutputService(),
+		orderRepositoryMock,
+		basketRepositoryMock,
+		productRepositoryMock,
+		stockReservationServiceMock,
+		fixture.stockLedger,
+		stockAllocationService,
+		promotionhelper.NewPromotionEngine(promotionRepositoryMock),
+		newTestTaxCalculationService(t),
+	)
+
+	return fixture
+}
+
+func Test_CheckoutUseCase(t *testing.T) {
+	// arrange
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+
+	var savedOrder *entities.Order
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+	fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.userBasket).Return(fixture.userBasket.GetID(), nil)
+	fixture.orderRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, order *entities.Order) (string, error) {
+		savedOrder = order
+		order.SetID("order-1")
+		return order.GetID(), nil
+	})
+
+	// act
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	// assert
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+
+	require.Equal(t, "order-1", output.Order.ID)
+	require.Equal(t, 5, output.Order.TotalItems)
+	require.Len(t, output.Order.Totals, 1)
+	require.Equal(t, "39.34", output.Order.Totals[0].Value)
+	require.Empty(t, output.Order.Discounts)
+
+	require.Equal(t, "DE", output.Order.TaxCountry)
+	require.Len(t, output.Order.TaxTotals, 1)
+	require.Equal(t, "33.06", output.Order.TaxTotals[0].Net.Value)
+	require.Equal(t, "6.28", output.Order.TaxTotals[0].Tax.Value)
+	require.Equal(t, "39.34", output.Order.TaxTotals[0].Gross.Value)
+	require.Equal(t, "19%", output.Order.Items[0].Tax.Rate)
+
+	require.NotNil(t, savedOrder)
+	require.Len(t, savedOrder.GetItems(), 2)
+	require.Equal(t, fixture.product1.Price, savedOrder.GetItems()[0].GetPrice())
+
+	require.Equal(t, 8, fixture.product1.Stock)
+	require.Equal(t, 0, fixture.product2.Stock)
+	require.Empty(t, fixture.userBasket.GetItems())
+
+	// the sales reference the order
+	require.Equal(t, []*warehouse.StockMovement{
+		{ProductID: "1", Type: warehouse.StockMovementTypeSale, Quantity: -2, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+		{ProductID: "2", Type: warehouse.StockMovementTypeSale, Quantity: -3, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+	}, fixture.stockLedger.movements)
+}
+
+func Test_CheckoutUseCase_Backorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	restockDate := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+	fixture.product2.StockPolicy = warehouse.StockPolicy{Type: warehouse.StockPolicyTypeBackorder, RestockDate: restockDate, MaxBackorder: 5}
+	// 1 of the 3 units in stock is in the basket of another user
+	fixture.reservedByOthers[fixture.product2.ID] = 1
+	fixture.userBasket.AddItem(fixture.product2.ID, 3)
+
+	var savedOrder *entities.Order
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+	fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.userBasket).Return(fixture.userBasket.GetID(), nil)
+	fixture.orderRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, order *entities.Order) (string, error) {
+		savedOrder = order
+		order.SetID("order-1")
+		return order.GetID(), nil
+	})
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	require.NoError(t, err)
+	require.Nil(t, output.Order.Items[0].Backorder)
+	require.Equal(t, &dto.Backorder{Type: "backorder", Count: 4, EstimatedDate: &restockDate}, output.Order.Items[1].Backorder)
+	require.Equal(t, &entities.OrderBackorder{Type: "backorder", Count: 4, EstimatedDate: restockDate}, savedOrder.GetItems()[1].GetBackorder())
+
+	// the backordered units are not shipped yet
+	require.Equal(t, []*entities.OrderShipment{
+		{LocationID: warehouse.DefaultLocationID, Items: []*entities.OrderShipmentItem{{ProductID: "1", Count: 2}, {ProductID: "2", Count: 2}}},
+	}, savedOrder.GetShipments())
+
+	require.Equal(t, -3, fixture.product2.Stock)
+	require.Equal(t, []*warehouse.StockMovement{
+		{ProductID: "1", Type: warehouse.StockMovementTypeSale, Quantity: -2, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+		{ProductID: "2", Type: warehouse.StockMovementTypeSale, Quantity: -2, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+		{ProductID: "2", Type: warehouse.StockMovementTypeSale, Quantity: -4, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+	}, fixture.stockLedger.movements)
+}
+
+func Test_CheckoutUseCase_Backorder_OutOfStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+	fixture.product2.StockPolicy = warehouse.StockPolicy{Type: warehouse.StockPolicyTypeBackorder, MaxBackorder: 2}
+	fixture.userBasket.AddItem(fixture.product2.ID, 3)
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	require.Equal(t, &domainerror.OutOfStockError{ProductID: "2", Available: 5, Requested: 6}, err)
+	require.Nil(t, output)
+	require.Empty(t, fixture.stockLedger.movements)
+}
+
+func Test_CheckoutUseCase_Shipments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+	// 2 units of product 2 are in hamburg and 1 unit at the default location in berlin
+	fixture.stockLedger.setLocationStock(fixture.product2.ID, "hamburg", 2)
+
+	var savedOrder *entities.Order
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+	fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.userBasket).Return(fixture.userBasket.GetID(), nil)
+	fixture.orderRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, order *entities.Order) (string, error) {
+		savedOrder = order
+		return "order-1", nil
+	})
+
+	// without destination the default location ships first
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	require.NoError(t, err)
+	require.Equal(t, []*entities.OrderShipment{
+		{LocationID: warehouse.DefaultLocationID, Items: []*entities.OrderShipmentItem{{ProductID: "1", Count: 2}, {ProductID: "2", Count: 1}}},
+		{LocationID: "hamburg", Items: []*entities.OrderShipmentItem{{ProductID: "2", Count: 2}}},
+	}, savedOrder.GetShipments())
+	require.Len(t, output.Order.Shipments, 2)
+	require.Equal(t, "hamburg", output.Order.Shipments[1].LocationID)
+
+	require.Equal(t, []*warehouse.StockMovement{
+		{ProductID: "1", Type: warehouse.StockMovementTypeSale, Quantity: -2, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+		{ProductID: "2", Type: warehouse.StockMovementTypeSale, Quantity: -1, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+		{ProductID: "2", Type: warehouse.StockMovementTypeSale, Quantity: -2, LocationID: "hamburg", Reference: "order-1"},
+	}, fixture.stockLedger.movements)
+}
+
+func Test_CheckoutUseCase_Destination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+	fixture.stockLedger.setLocationStock(fixture.product2.ID, "hamburg", 2)
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+	fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.userBasket).Return(fixture.userBasket.GetID(), nil)
+	fixture.orderRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return("order-1", nil)
+
+	// bremen is nearer to hamburg than to berlin
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337", Destination: &warehouse.Position{Latitude: 53.079, Longitude: 8.801}})
+
+	require.NoError(t, err)
+	require.Len(t, output.Order.Shipments, 2)
+	require.Equal(t, "hamburg", output.Order.Shipments[0].LocationID)
+	require.Equal(t, warehouse.DefaultLocationID, output.Order.Shipments[1].LocationID)
+
+	balance, err := fixture.stockLedger.Balance(t.Context(), fixture.product2.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{warehouse.DefaultLocationID: 0, "hamburg": 0}, balance.Locations)
+}
+
+func Test_CheckoutUseCase_Coupons(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+	fixture.userBasket.AddCoupon("TEN")
+	fixture.userBasket.AddCoupon("BIG")
+
+	fixture.promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "TEN").Return(&promotion.Promotion{
+		Code:       "TEN",
+		Type:       promotion.PromotionTypePercentage,
+		Percentage: 10,
+	}, nil)
+	fixture.promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "BIG").Return(&promotion.Promotion{
+		Code:         "BIG",
+		Type:         promotion.PromotionTypeFixedAmount,
+		Amount:       money.New(1000, "EUR"),
+		MinimumTotal: money.New(10000, "EUR"),
+	}, nil)
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+	fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.userBasket).Return(fixture.userBasket.GetID(), nil)
+	fixture.orderRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return("order-1", nil)
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	require.NoError(t, err)
+
+	// only the applicable coupon is part of the order
+	require.Len(t, output.Order.Discounts, 1)
+	require.Equal(t, "TEN", output.Order.Discounts[0].Code)
+	require.Equal(t, "3.93", output.Order.Discounts[0].Amount.Value)
+	require.Equal(t, "35.41", output.Order.Totals[0].Value)
+
+	require.Equal(t, "29.76", output.Order.TaxTotals[0].Net.Value)
+	require.Equal(t, "5.65", output.Order.TaxTotals[0].Tax.Value)
+	require.Equal(t, "35.41", output.Order.TaxTotals[0].Gross.Value)
+
+	require.Empty(t, fixture.userBasket.GetCoupons())
+}
+
+func Test_CheckoutUseCase_EmptyBasket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(nil, &domainerror.NotFoundError{Resource: basket.BasketResource})
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	require.ErrorAs(t, err, new(*domainerror.ValidationError))
+	require.Nil(t, output)
+}
+
+func Test_CheckoutUseCase_ExpectedBasketVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+	fixture.userBasket.Version = 3
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+
+	// the basket was changed after the user has seen version 2
+	expectedBasketVersion := int64(2)
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337", ExpectedBasketVersion: &expectedBasketVersion})
+
+	require.EqualError(t, err, "basket 1 was modified concurrently, version 2 is outdated")
+	require.True(t, domainerror.IsVersionConflict(err))
+	require.Nil(t, output)
+
+	require.Equal(t, 10, fixture.product1.Stock)
+	require.Equal(t, 3, fixture.product2.Stock)
+}
+
+func Test_CheckoutUseCase_BasketVersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+	fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.userBasket).Return("", basket.NewVersionConflictError(fixture.userBasket))
+	fixture.orderRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return("order-1", nil)
+	fixture.orderRepositoryMock.EXPECT().Delete(gomock.Any(), "order-1").Return(nil)
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	// the basket changed during the checkout, so the order is undone instead of ordering an outdated basket
+	require.True(t, domainerror.IsVersionConflict(err))
+	require.Nil(t, output)
+
+	require.Equal(t, 10, fixture.product1.Stock)
+	require.Equal(t, 3, fixture.product2.Stock)
+}
+
+func Test_CheckoutUseCase_InsufficientStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+	fixture.product2.Stock = 2
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	require.ErrorAs(t, err, new(*domainerror.OutOfStockError))
+	require.Nil(t, output)
+
+	require.Equal(t, 10, fixture.product1.Stock)
+	require.Equal(t, 2, fixture.product2.Stock)
+	require.Len(t, fixture.userBasket.GetItems(), 2)
+}
+
+func Test_CheckoutUseCase_ProductDeactivated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+	fixture.product2.Deactivate()
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	require.EqualError(t, err, "product 2 is not sold anymore")
+	require.ErrorAs(t, err, new(*domainerror.ConflictError))
+	require.Nil(t, output)
+
+	require.Equal(t, 10, fixture.product1.Stock)
+	require.Len(t, fixture.userBasket.GetItems(), 2)
+}
+
+func Test_CheckoutUseCase_ReservedByOtherUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+	fixture.reservedByOthers[fixture.product1.ID] = 9
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	var outOfStockErr *domainerror.OutOfStockError
+	require.ErrorAs(t, err, &outOfStockErr)
+	require.Equal(t, 1, outOfStockErr.Available)
+	require.Nil(t, output)
+
+	require.Equal(t, 10, fixture.product1.Stock)
+}
+
+func Test_CheckoutUseCase_OrderRepositorySaveFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+	fixture.orderRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return("", fmt.Errorf("order repository error"))
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	require.ErrorContains(t, err, "order repository error")
+	require.Nil(t, output)
+
+	require.Equal(t, 10, fixture.product1.Stock)
+	require.Equal(t, 3, fixture.product2.Stock)
+	require.Empty(t, fixture.stockLedger.movements)
+	require.Len(t, fixture.userBasket.GetItems(), 2)
+}
+
+func Test_CheckoutUseCase_RecordSaleFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+	fixture.stockLedger.errs[fixture.product2.ID] = fmt.Errorf("stock ledger error")
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+	fixture.orderRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return("order-1", nil)
+	fixture.orderRepositoryMock.EXPECT().Delete(gomock.Any(), "order-1").Return(nil)
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	require.ErrorContains(t, err, "stock ledger error")
+	require.Nil(t, output)
+
+	// the sale of the first product is undone by a cancellation and the order is removed
+	require.Equal(t, 10, fixture.product1.Stock)
+	require.Equal(t, 3, fixture.product2.Stock)
+	require.Equal(t, []*warehouse.StockMovement{
+		{ProductID: "1", Type: warehouse.StockMovementTypeSale, Quantity: -2, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+		{ProductID: "1", Type: warehouse.StockMovementTypeCancellation, Quantity: 2, LocationID: warehouse.DefaultLocationID, Reference: "order-1"},
+	}, fixture.stockLedger.movements)
+	require.Len(t, fixture.userBasket.GetItems(), 2)
+}
+
+func Test_CheckoutUseCase_RestoreStockFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+	fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.userBasket).DoAndReturn(func(ctx context.Context, userBasket *basket.Basket) (string, error) {
+		fixture.stockLedger.errs[fixture.product1.ID] = fmt.Errorf("stock ledger error")
+		return "", fmt.Errorf("basket repository error")
+	})
+	fixture.orderRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return("order-1", nil)
+	fixture.orderRepositoryMock.EXPECT().Delete(gomock.Any(), "order-1").Return(nil)
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	require.ErrorContains(t, err, "basket repository error")
+	require.ErrorContains(t, err, "failed to restore the stock of product 1: stock ledger error")
+	require.Nil(t, output)
+}
+
+func Test_CheckoutUseCase_BasketRepositorySaveFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixture := newCheckoutTestFixture(t, ctrl)
+	fixture.userBasket.AddCoupon("TEN")
+
+	fixture.promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "TEN").Return(nil, &domainerror.NotFoundError{Resource: promotion.PromotionResource, ID: "TEN"})
+	fixture.basketRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(fixture.userBasket, nil)
+	fixture.basketRepositoryMock.EXPECT().Save(gomock.Any(), fixture.userBasket).Return("", fmt.Errorf("basket repository error"))
+	fixture.orderRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return("order-1", nil)
+	fixture.orderRepositoryMock.EXPECT().Delete(gomock.Any(), "order-1").Return(nil)
+
+	output, err := fixture.useCase.Execute(t.Context(), &CheckoutUseCaseInput{UserID: "1337"})
+
+	require.ErrorContains(t, err, "basket repository error")
+	require.Nil(t, output)
+
+	require.Equal(t, 10, fixture.product1.Stock)
+	require.Equal(t, 3, fixture.product2.Stock)
+	require.Len(t, fixture.userBasket.GetItems(), 2)
+	require.Equal(t, []string{"TEN"}, fixture.userBasket.GetCoupons())
+}
@@ -0,0 +1,82 @@
+package dto
+
+import "time"
+
+type OrderDTO struct {
+	ID            string
+	CreatedAt     time.Time
+	Status        string
+	StatusHistory []*StatusChange
+	Items         []*OrderItem
+	Discounts     []*Discount
+	// Totals contains one total per currency after the discounts, sorted by currency
+	Totals     []*Price
+	TaxCountry string
+	TaxTotals  []*TaxTotal
+	TotalItems int
+	// Shipments contains the units per warehouse location, it is empty for orders created before there were several locations
+	Shipments []*Shipment
+}
+
+type StatusChange struct {
+	Status    string
+	ChangedAt time.Time
+}
+
+type OrderItem struct {
+	ProductID   string
+	ProductName string
+	Count       int
+	Price       *Price
+	Subtotal    *Price
+	// Tax splits the subtotal into net and tax
+	Tax *Tax
+	// Backorder is set if units of the item are shipped after the restock or on the release date
+	Backorder *Backorder
+}
+
+type Backorder struct {
+	// Type is backorder or preorder
+	Type  string
+	Count int
+	// EstimatedDate is nil if the date is unknown
+	EstimatedDate *time.Time
+}
+
+type Shipment struct {
+	LocationID string
+	Items      []*ShipmentItem
+}
+
+type ShipmentItem struct {
+	ProductID string
+	Count     int
+}
+
+type Discount struct {
+	Code   string
+	Reason string
+	Amount *Price
+}
+
+// Tax splits a gross amount of one tax class into net and tax
+type Tax struct {
+	Class string
+	Rate  string
+	Net   *Price
+	Tax   *Price
+	Gross *Price
+}
+
+type TaxTotal struct {
+	Net   *Price
+	Tax   *Price
+	Gross *Price
+	// Classes contains the amounts per tax class, sorted by class
+	Classes []*Tax
+}
+
+type Price struct {
+	Value    string
+	Currency string
+}
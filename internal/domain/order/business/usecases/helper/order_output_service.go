@@ -0,0 +1,143 @@
+package helper
+
+import (
+	"fmt"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/dto"
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+type OrderOutputService interface {
+	CreateOrderDTO(order *entities.Order) (*dto.OrderDTO, error)
+}
+
+var _ OrderOutputService = (*OrderOutputServiceImpl)(nil)
+
+type OrderOutputServiceImpl struct {
+}
+
+func NewOrderOutputService() OrderOutputService {
+	return &OrderOutputServiceImpl{}
+}
+
+// CreateOrderDTO only uses the snapshots stored in the order, the current products are not relevant anymore
+func (service *OrderOutputServiceImpl) CreateOrderDTO(order *entities.Order) (*dto.OrderDTO, error) {
+	if order == nil {
+		return nil, fmt.Errorf("order is nil")
+	}
+
+	orderDTO := &dto.OrderDTO{
+		ID:            order.GetID(),
+		CreatedAt:     order.GetCreatedAt(),
+		Status:        string(order.GetStatus()),
+		StatusHistory: make([]*dto.StatusChange, 0, len(order.GetStatusHistory())),
+		Items:         make([]*dto.OrderItem, 0, len(order.GetItems())),
+		Discounts:     make([]*dto.Discount, 0, len(order.GetDiscounts())),
+		Totals:        make([]*dto.Price, 0, len(order.GetTotals())),
+		TaxCountry:    order.GetTaxCountry(),
+		TaxTotals:     make([]*dto.TaxTotal, 0, len(order.GetTaxTotals())),
+		Shipments:     make([]*dto.Shipment, 0, len(order.GetShipments())),
+	}
+
+	for _, change := range order.GetStatusHistory() {
+		orderDTO.StatusHistory = append(orderDTO.StatusHistory, &dto.StatusChange{
+			Status:    string(change.GetStatus()),
+			ChangedAt: change.GetChangedAt(),
+		})
+	}
+
+	for _, item := range order.GetItems() {
+		orderDTO.Items = append(orderDTO.Items, &dto.OrderItem{
+			ProductID:   item.GetProductID(),
+			ProductName: item.GetProductName(),
+			Count:       item.GetCount(),
+			Price:       newPriceDTO(item.GetPrice()),
+			Subtotal:    newPriceDTO(item.GetSubtotal()),
+			Tax:         newTaxDTO(item.GetTaxClass(), item.GetTaxBasisPoints(), item.GetNet(), item.GetTax(), item.GetSubtotal()),
+			Backorder:   newBackorderDTO(item.GetBackorder()),
+		})
+		orderDTO.TotalItems += item.GetCount()
+	}
+
+	for _, discount := range order.GetDiscounts() {
+		orderDTO.Discounts = append(orderDTO.Discounts, &dto.Discount{
+			Code:   discount.Code,
+			Reason: discount.Reason,
+			Amount: newPriceDTO(discount.Amount),
+		})
+	}
+
+	for _, total := range order.GetTotals() {
+		orderDTO.Totals = append(orderDTO.Totals, newPriceDTO(total))
+	}
+
+	for _, taxTotal := range order.GetTaxTotals() {
+		taxTotalDTO := &dto.TaxTotal{
+			Net:     newPriceDTO(taxTotal.Net),
+			Tax:     newPriceDTO(taxTotal.Tax),
+			Gross:   newPriceDTO(taxTotal.Gross),
+			Classes: make([]*dto.Tax, 0, len(taxTotal.Classes)),
+		}
+		for _, classTax := range taxTotal.Classes {
+			taxTotalDTO.Classes = append(taxTotalDTO.Classes, newTaxDTO(classTax.Class, classTax.BasisPoints, classTax.Net, classTax.Tax, classTax.Gross))
+		}
+		orderDTO.TaxTotals = append(orderDTO.TaxTotals, taxTotalDTO)
+	}
+
+	for _, shipment := range order.GetShipments() {
+		shipmentDTO := &dto.Shipment{
+			LocationID: shipment.LocationID,
+			Items:      make([]*dto.ShipmentItem, 0, len(shipment.Items)),
+		}
+		for _, item := range shipment.Items {
+			shipmentDTO.Items = append(shipmentDTO.Items, &dto.ShipmentItem{
+				ProductID: item.ProductID,
+				Count:     item.Count,
+			})
+		}
+		orderDTO.Shipments = append(orderDTO.Shipments, shipmentDTO)
+	}
+
+	return orderDTO, nil
+}
+
+// newTaxDTO returns nil for orders created before taxes were part of the order
+func newTaxDTO(class tax.TaxClass, basisPoints int64, net money.Money, taxAmount money.Money, gross money.Money) *dto.Tax {
+	if class == "" {
+		return nil
+	}
+
+	return &dto.Tax{
+		Class: string(class),
+		Rate:  tax.FormatBasisPoints(basisPoints),
+		Net:   newPriceDTO(net),
+		Tax:   newPriceDTO(taxAmount),
+		Gross: newPriceDTO(gross),
+	}
+}
+
+func newBackorderDTO(backorder *entities.OrderBackorder) *dto.Backorder {
+	if backorder == nil {
+		return nil
+	}
+
+	backorderDTO := &dto.Backorder{
+		Type:  backorder.Type,
+		Count: backorder.Count,
+	}
+	if !backorder.EstimatedDate.IsZero() {
+		estimatedDate := backorder.EstimatedDate
+		backorderDTO.EstimatedDate = &estimatedDate
+	}
+
+	return backorderDTO
+}
+
+func newPriceDTO(price money.Money) *dto.Price {
+	return &dto.Price{
+		Value:    price.FormatAmount(),
+		Currency: price.GetCurrency(),
+	}
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type ListOrdersUseCaseInput struct {
+	UserID string
+}
+
+type ListOrdersUseCaseOutput struct {
+	Orders []*dto.OrderDTO
+}
+
+type ListOrdersUseCase interface {
+	Execute(ctx context.Context, input *ListOrdersUseCaseInput) (*ListOrdersUseCaseOutput, error)
+}
+
+func NewListOrdersUseCaseImpl(orderOutputService helper.OrderOutputService, orderRepository entities.OrderRepository) ListOrdersUseCase {
+	return &ListOrdersUseCaseImpl{
+		orderOutputService: orderOutputService,
+		orderRepository:    orderRepository,
+	}
+}
+
+var _ ListOrdersUseCase = (*ListOrdersUseCaseImpl)(nil)
+
+type ListOrdersUseCaseImpl struct {
+	orderOutputService helper.OrderOutputService
+	orderRepository    entities.OrderRepository
+}
+
+func (useCase *ListOrdersUseCaseImpl) validate(input *ListOrdersUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.UserID == "" {
+		return fmt.Errorf("UserID is empty")
+	}
+
+	return nil
+}
+
+func (useCase *ListOrdersUseCaseImpl) Execute(ctx context.Context, input *ListOrdersUseCaseInput) (*ListOrdersUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	orders, ordersErr := useCase.orderRepository.FindByUserId(ctx, input.UserID)
+	if ordersErr != nil {
+		return nil, ordersErr
+	}
+
+	orderDTOs := make([]*dto.OrderDTO, 0, len(orders))
+	for _, order := range orders {
+		orderDTO, orderOutputServiceErr := useCase.orderOutputService.CreateOrderDTO(order)
+		if orderOutputServiceErr != nil {
+			return nil, orderOutputServiceErr
+		}
+		orderDTOs = append(orderDTOs, orderDTO)
+	}
+
+	output := &ListOrdersUseCaseOutput{
+		Orders: orderDTOs,
+	}
+
+	return output, nil
+}
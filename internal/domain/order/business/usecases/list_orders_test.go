@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func newTestOrder(t *testing.T, orderID string, userID string) *entities.Order {
+	order, err := entities.NewOrderFactory().NewOrder(userID, []*entities.OrderItem{
+		{ProductID: "1", ProductName: "Product 1", Count: 2, Price: money.New(1337, "EUR")},
+		{ProductID: "2", ProductName: "Product 2", Count: 1, Price: money.New(420, "EUR")},
+	})
+	require.NoError(t, err)
+
+	order.SetID(orderID)
+
+	return order
+}
+
+func Test_ListOrdersUseCase_NewListOrdersUseCaseImpl_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := NewListOrdersUseCaseImpl(helper.NewOrderOutputService(), entities.NewMockOrderRepository(ctrl))
+
+	_, err := useCase.Execute(t.Context(), nil)
+	require.ErrorContains(t, err, "input is nil")
+
+	_, err = useCase.Execute(t.Context(), &ListOrdersUseCaseInput{})
+	require.ErrorContains(t, err, "UserID is empty")
+}
+
+func Test_ListOrdersUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orders := []*entities.Order{
+		newTestOrder(t, "order-2", "1337"),
+		newTestOrder(t, "order-1", "1337"),
+	}
+
+	orderRepositoryMock := entities.NewMockOrderRepository(ctrl)
+	orderRepositoryMock.EXPECT().FindByUserId(gomock.Any(), "1337").Return(orders, nil)
+
+	useCase := NewListOrdersUseCaseImpl(helper.NewOrderOutputService(), orderRepositoryMock)
+
+	output, err := useCase.Execute(t.Context(), &ListOrdersUseCaseInput{UserID: "1337"})
+
+	require.NoError(t, err)
+	require.Len(t, output.Orders, 2)
+	require.Equal(t, "order-2", output.Orders[0].ID)
+	require.Equal(t, string(entities.OrderStatusPending), output.Orders[0].Status)
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type ShowOrderUseCaseInput struct {
+	UserID  string
+	OrderID string
+}
+
+type ShowOrderUseCaseOutput struct {
+	Order *dto.OrderDTO
+}
+
+type ShowOrderUseCase interface {
+	Execute(ctx context.Context, input *ShowOrderUseCaseInput) (*ShowOrderUseCaseOutput, error)
+}
+
+func NewShowOrderUseCaseImpl(orderOutputService helper.OrderOutputService, orderRepository entities.OrderRepository) ShowOrderUseCase {
+	return &ShowOrderUseCaseImpl{
+		orderOutputService: orderOutputService,
+		orderRepository:    orderRepository,
+	}
+}
+
+var _ ShowOrderUseCase = (*ShowOrderUseCaseImpl)(nil)
+
+type ShowOrderUseCaseImpl struct {
+	orderOutputService helper.OrderOutputService
+	orderRepository    entities.OrderRepository
+}
+
+func (useCase *ShowOrderUseCaseImpl) validate(input *ShowOrderUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.UserID == "" {
+		return fmt.Errorf("UserID is empty")
+	} else if input.OrderID == "" {
+		return fmt.Errorf("OrderID is empty")
+	}
+
+	return nil
+}
+
+func (useCase *ShowOrderUseCaseImpl) Execute(ctx context.Context, input *ShowOrderUseCaseInput) (*ShowOrderUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	order, orderErr := findUserOrder(ctx, useCase.orderRepository, input.UserID, input.OrderID)
+	if orderErr != nil {
+		return nil, orderErr
+	}
+
+	orderDTO, orderOutputServiceErr := useCase.orderOutputService.CreateOrderDTO(order)
+	if orderOutputServiceErr != nil {
+		return nil, orderOutputServiceErr
+	}
+
+	output := &ShowOrderUseCaseOutput{
+		Order: orderDTO,
+	}
+
+	return output, nil
+}
+
+// findUserOrder handles orders of other users like missing orders, so their ids are not revealed
+func findUserOrder(ctx context.Context, orderRepository entities.OrderRepository, userID string, orderID string) (*entities.Order, error) {
+	order, orderErr := orderRepository.Find(ctx, orderID)
+	if orderErr != nil {
+		return nil, orderErr
+	}
+
+	if order.GetUserID() != userID {
+		return nil, &domainerror.NotFoundError{Resource: entities.OrderResource, ID: order.GetID()}
+	}
+
+	return order, nil
+}
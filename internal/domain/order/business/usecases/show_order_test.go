@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+func Test_ShowOrderUseCase_NewShowOrderUseCaseImpl_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *ShowOrderUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"UserID is empty": {
+			input: &ShowOrderUseCaseInput{},
+		},
+		"OrderID is empty": {
+			input: &ShowOrderUseCaseInput{
+				UserID: "1337",
+			},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := NewShowOrderUseCaseImpl(helper.NewOrderOutputService(), entities.NewMockOrderRepository(ctrl))
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.Error(t, err)
+			require.ErrorContains(t, err, errorString)
+		})
+	}
+}
+
+func Test_ShowOrderUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	order := newTestOrder(t, "order-1", "1337")
+
+	orderRepositoryMock := entities.NewMockOrderRepository(ctrl)
+	orderRepositoryMock.EXPECT().Find(gomock.Any(), "order-1").Return(order, nil)
+
+	useCase := NewShowOrderUseCaseImpl(helper.NewOrderOutputService(), orderRepositoryMock)
+
+	output, err := useCase.Execute(t.Context(), &ShowOrderUseCaseInput{UserID: "1337", OrderID: "order-1"})
+
+	require.NoError(t, err)
+	require.Equal(t, "order-1", output.Order.ID)
+	require.Len(t, output.Order.Items, 2)
+	require.Equal(t, 3, output.Order.TotalItems)
+}
+
+func Test_ShowOrderUseCase_OrderOfOtherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	order := newTestOrder(t, "order-1", "1338")
+
+	orderRepositoryMock := entities.NewMockOrderRepository(ctrl)
+	orderRepositoryMock.EXPECT().Find(gomock.Any(), "order-1").Return(order, nil)
+
+	useCase := NewShowOrderUseCaseImpl(helper.NewOrderOutputService(), orderRepositoryMock)
+
+	output, err := useCase.Execute(t.Context(), &ShowOrderUseCaseInput{UserID: "1337", OrderID: "order-1"})
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+	require.Nil(t, output)
+}
@@ -0,0 +1,123 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+var _ entities.OrderRepository = (*InMemoryOrderRepository)(nil)
+
+// InMemoryOrderRepository stores copies of the orders, so like with the mongodb driver
+// the status of a stored order only changes by calling Save or UpdateStatus
+type InMemoryOrderRepository struct {
+	mutex  sync.RWMutex
+	orders map[string]*entities.Order
+}
+
+func NewInMemoryOrderRepository() entities.OrderRepository {
+	return &InMemoryOrderRepository{
+		orders: make(map[string]*entities.Order),
+	}
+}
+
+func (repository *InMemoryOrderRepository) Save(ctx context.Context, order *entities.Order) (string, error) {
+	if order == nil {
+		return "", fmt.Errorf("order is nil")
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	if order.GetID() == "" {
+		order.SetID(uuid.NewString())
+	}
+
+	repository.orders[order.GetID()] = copyOrder(order)
+
+	return order.GetID(), nil
+}
+
+func (repository *InMemoryOrderRepository) UpdateStatus(ctx context.Context, order *entities.Order, expectedStatus entities.OrderStatus) error {
+	if order == nil {
+		return fmt.Errorf("order is nil")
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	storedOrder, orderExists := repository.orders[order.GetID()]
+	if !orderExists {
+		return &domainerror.NotFoundError{Resource: entities.OrderResource, ID: order.GetID()}
+	}
+
+	if storedOrder.GetStatus() != expectedStatus {
+		return entities.NewOrderStatusConflictError(order.GetID(), expectedStatus)
+	}
+
+	updatedOrder := *storedOrder
+	updatedOrder.Status = order.GetStatus()
+	updatedOrder.StatusHistory = slices.Clone(order.GetStatusHistory())
+	repository.orders[order.GetID()] = &updatedOrder
+
+	return nil
+}
+
+func (repository *InMemoryOrderRepository) Find(ctx context.Context, id string) (*entities.Order, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	order, orderExists := repository.orders[id]
+	if !orderExists {
+		return nil, &domainerror.NotFoundError{Resource: entities.OrderResource, ID: id}
+	}
+
+	return copyOrder(order), nil
+}
+
+// FindByUserId returns the orders of the user, newest first
+func (repository *InMemoryOrderRepository) FindByUserId(ctx context.Context, userId string) ([]*entities.Order, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	orders := make([]*entities.Order, 0)
+	for _, order := range repository.orders {
+		if order.GetUserID() == userId {
+			orders = append(orders, copyOrder(order))
+		}
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].GetCreatedAt().After(orders[j].GetCreatedAt())
+	})
+
+	return orders, nil
+}
+
+func (repository *InMemoryOrderRepository) Delete(ctx context.Context, id string) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	if _, orderExists := repository.orders[id]; !orderExists {
+		return &domainerror.NotFoundError{Resource: entities.OrderResource, ID: id}
+	}
+
+	delete(repository.orders, id)
+
+	return nil
+}
+
+// copyOrder copies the status history, because only the status of an order changes after the checkout
+func copyOrder(order *entities.Order) *entities.Order {
+	orderCopy := *order
+	orderCopy.StatusHistory = slices.Clone(order.StatusHistory)
+
+	return &orderCopy
+}
@@ -0,0 +1,116 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func newOrder(t *testing.T, userID string) *entities.Order {
+	order, err := entities.NewOrderFactory().NewOrder(userID, []*entities.OrderItem{
+		{ProductID: "A12345", ProductName: "Product", Count: 2, Price: money.New(1199, "EUR")},
+	})
+	require.NoError(t, err)
+
+	return order
+}
+
+func Test_InMemoryOrderRepository_SaveAndFind(t *testing.T) {
+	repository := NewInMemoryOrderRepository()
+
+	order, err := repository.Find(t.Context(), "1")
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+	require.Nil(t, order)
+
+	newOrder := newOrder(t, "1337")
+
+	orderID, err := repository.Save(t.Context(), newOrder)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, orderID)
+
+	order, err = repository.Find(t.Context(), orderID)
+
+	require.NoError(t, err)
+	require.Equal(t, newOrder, order)
+}
+
+func Test_InMemoryOrderRepository_FindByUserId(t *testing.T) {
+	repository := NewInMemoryOrderRepository()
+
+	orders, err := repository.FindByUserId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Empty(t, orders)
+
+	_, err = repository.Save(t.Context(), newOrder(t, "1337"))
+	require.NoError(t, err)
+	_, err = repository.Save(t.Context(), newOrder(t, "1337"))
+	require.NoError(t, err)
+	_, err = repository.Save(t.Context(), newOrder(t, "1338"))
+	require.NoError(t, err)
+
+	orders, err = repository.FindByUserId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+}
+
+func Test_InMemoryOrderRepository_Delete(t *testing.T) {
+	repository := NewInMemoryOrderRepository()
+
+	err := repository.Delete(t.Context(), "1")
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+
+	orderID, err := repository.Save(t.Context(), newOrder(t, "1337"))
+	require.NoError(t, err)
+
+	err = repository.Delete(t.Context(), orderID)
+
+	require.NoError(t, err)
+
+	_, err = repository.Find(t.Context(), orderID)
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+}
+
+func Test_InMemoryOrderRepository_UpdateStatus(t *testing.T) {
+	repository := NewInMemoryOrderRepository()
+
+	order := newOrder(t, "1337")
+
+	err := repository.UpdateStatus(t.Context(), order, entities.OrderStatusPending)
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+
+	orderID, err := repository.Save(t.Context(), order)
+	require.NoError(t, err)
+
+	// two requests read the pending order and cancel it, only the first update matches the stored status
+	firstOrder, err := repository.Find(t.Context(), orderID)
+	require.NoError(t, err)
+	secondOrder, err := repository.Find(t.Context(), orderID)
+	require.NoError(t, err)
+
+	require.NoError(t, firstOrder.TransitionTo(entities.OrderStatusCancelled, time.Now()))
+	require.NoError(t, secondOrder.TransitionTo(entities.OrderStatusCancelled, time.Now()))
+
+	require.NoError(t, repository.UpdateStatus(t.Context(), firstOrder, entities.OrderStatusPending))
+
+	err = repository.UpdateStatus(t.Context(), secondOrder, entities.OrderStatusPending)
+
+	require.ErrorAs(t, err, new(*domainerror.ConflictError))
+
+	storedOrder, err := repository.Find(t.Context(), orderID)
+
+	require.NoError(t, err)
+	require.Equal(t, firstOrder, storedOrder)
+	require.Equal(t, entities.OrderStatusPending, order.GetStatus())
+}
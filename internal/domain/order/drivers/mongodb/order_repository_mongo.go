@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+const (
+	DatabaseName         = "ecommerce"
+	OrdersCollectionName = "orders"
+)
+
+var _ entities.OrderRepository = (*MongoOrderRepository)(nil)
+
+type MongoOrderRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoOrderRepository(collection *mongo.Collection) entities.OrderRepository {
+	return &MongoOrderRepository{
+		collection: collection,
+	}
+}
+
+func (repository *MongoOrderRepository) Save(ctx context.Context, order *entities.Order) (string, error) {
+	if order == nil {
+		return "", fmt.Errorf("order is nil")
+	}
+
+	if order.GetID() == "" {
+		order.SetID(uuid.NewString())
+	}
+
+	_, replaceErr := repository.collection.ReplaceOne(ctx, bson.M{"id": order.GetID()}, order, options.Replace().SetUpsert(true))
+	if replaceErr != nil {
+		return "", replaceErr
+	}
+
+	return order.GetID(), nil
+}
+
+// UpdateStatus filters by the expected status, so of two concurrent updates of the same status only one matches the order
+func (repository *MongoOrderRepository) UpdateStatus(ctx context.Context, order *entities.Order, expectedStatus entities.OrderStatus) error {
+	if order == nil {
+		return fmt.Errorf("order is nil")
+	}
+
+	result, updateErr := repository.collection.UpdateOne(ctx, bson.M{"id": order.GetID(), "status": expectedStatus}, bson.M{
+		"$set": bson.M{"status": order.GetStatus(), "statushistory": order.GetStatusHistory()},
+	})
+	if updateErr != nil {
+		return updateErr
+	}
+
+	if result.MatchedCount == 0 {
+		// the order does not exist or has another status
+		_, findErr := repository.Find(ctx, order.GetID())
+		if findErr != nil {
+			return findErr
+		}
+
+		return entities.NewOrderStatusConflictError(order.GetID(), expectedStatus)
+	}
+
+	return nil
+}
+
+func (repository *MongoOrderRepository) Find(ctx context.Context, id string) (*entities.Order, error) {
+	result := repository.collection.FindOne(ctx, bson.M{"id": id})
+	if result.Err() != nil {
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return nil, &domainerror.NotFoundError{Resource: entities.OrderResource, ID: id}
+		}
+		return nil, result.Err()
+	}
+
+	var order entities.Order
+	decodeErr := result.Decode(&order)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	return &order, nil
+}
+
+// FindByUserId returns the orders of the user, newest first
+func (repository *MongoOrderRepository) FindByUserId(ctx context.Context, userId string) ([]*entities.Order, error) {
+	cursor, findErr := repository.collection.Find(ctx, bson.M{"userid": userId}, options.Find().SetSort(bson.D{{Key: "createdat", Value: -1}}))
+	if findErr != nil {
+		return nil, findErr
+	}
+
+	orders := make([]*entities.Order, 0)
+	decodeErr := cursor.All(ctx, &orders)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	return orders, nil
+}
+
+func (repository *MongoOrderRepository) Delete(ctx context.Context, id string) error {
+	result, deleteErr := repository.collection.DeleteOne(ctx, bson.M{"id": id})
+	if deleteErr != nil {
+		return deleteErr
+	}
+
+	if result.DeletedCount == 0 {
+		return &domainerror.NotFoundError{Resource: entities.OrderResource, ID: id}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/mongodb"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/order/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func initTestcontainers(t *testing.T) (string, func()) {
+	ctx := context.Background()
+
+	mongodbContainer, err := mongodb.Run(ctx, "mongodb/mongodb-community-server:8.0-ubi8")
+	stop := func() {
+		if err := testcontainers.TerminateContainer(mongodbContainer); err != nil {
+			log.Printf("failed to terminate container: %s", err)
+		}
+	}
+
+	require.NoError(t, err)
+	require.NotNil(t, mongodbContainer)
+
+	endpoint, err := mongodbContainer.ConnectionString(ctx)
+	if err != nil {
+		log.Fatalf("failed to get connection string: %s", err)
+	}
+
+	return endpoint, stop
+}
+
+func Test_MongoOrderRepository(t *testing.T) {
+	endpoint, stop := initTestcontainers(t)
+	defer stop()
+
+	clientOpts := options.Client().ApplyURI(endpoint)
+	mongoClient, mongoClientErr := mongo.Connect(clientOpts)
+	if mongoClientErr != nil {
+		panic(mongoClientErr)
+	}
+	defer func() {
+		if mongoClientErr = mongoClient.Disconnect(context.TODO()); mongoClientErr != nil {
+			panic(mongoClientErr)
+		}
+	}()
+
+	ordersCollection := mongoClient.Database(DatabaseName).Collection(OrdersCollectionName)
+	repository := NewMongoOrderRepository(ordersCollection)
+
+	foundOrder, err := repository.Find(t.Context(), "1")
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+	require.Nil(t, foundOrder)
+
+	userID := "1337"
+
+	order, err := entities.NewOrderFactory().NewOrder(userID, []*entities.OrderItem{
+		{ProductID: "A12345", ProductName: "Product", Count: 2, Price: money.New(1199, "EUR")},
+	})
+	require.NoError(t, err)
+
+	orderID, err := repository.Save(t.Context(), order)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, orderID)
+
+	foundOrder, err = repository.Find(t.Context(), orderID)
+
+	require.NoError(t, err)
+	require.Equal(t, order, foundOrder)
+
+	orders, err := repository.FindByUserId(t.Context(), userID)
+
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	require.Equal(t, order, orders[0])
+
+	// the second update still expects the pending status, so it conflicts
+	require.NoError(t, order.TransitionTo(entities.OrderStatusCancelled, order.GetCreatedAt()))
+	require.NoError(t, repository.UpdateStatus(t.Context(), order, entities.OrderStatusPending))
+	require.ErrorAs(t, repository.UpdateStatus(t.Context(), order, entities.OrderStatusPending), new(*domainerror.ConflictError))
+
+	foundOrder, err = repository.Find(t.Context(), orderID)
+
+	require.NoError(t, err)
+	require.Equal(t, order, foundOrder)
+
+	err = repository.Delete(t.Context(), orderID)
+
+	require.NoError(t, err)
+
+	err = repository.Delete(t.Context(), orderID)
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+
+	err = repository.UpdateStatus(t.Context(), order, entities.OrderStatusCancelled)
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+}
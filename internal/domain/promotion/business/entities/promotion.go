@@ -0,0 +1,251 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+type PromotionType string
+
+const (
+	// PromotionTypePercentage takes a percentage off the basket total or off one product
+	PromotionTypePercentage PromotionType = "percentage"
+	// PromotionTypeFixedAmount takes a fixed amount off the basket total of the amount currency
+	PromotionTypeFixedAmount PromotionType = "fixed_amount"
+	// PromotionTypeBuyXGetY makes FreeCount of every BuyCount+FreeCount units of a product free
+	PromotionTypeBuyXGetY PromotionType = "buy_x_get_y"
+)
+
+// Promotion is redeemed with its coupon code
+type Promotion struct {
+	Code        string
+	Description string
+	Type        PromotionType
+	// Percentage is used by PromotionTypePercentage, e.g. 10 for 10 %
+	Percentage int64
+	// Amount is used by PromotionTypeFixedAmount
+	Amount money.Money
+	// ProductID restricts PromotionTypePercentage to a product and is required for PromotionTypeBuyXGetY
+	ProductID string
+	BuyCount  int
+	FreeCount int
+	// MinimumTotal is the basket total of its currency needed to apply the promotion, there is no threshold if its currency is empty
+	MinimumTotal money.Money
+}
+
+// PromotionItem is a basket item with its current price
+type PromotionItem struct {
+	ProductID string
+	Count     int
+	Price     money.Money
+}
+
+// Discount is the amount a promotion takes off the basket
+type Discount struct {
+	Code   string
+	Reason string
+	// ProductID is set if the discount is only taken off the price of this product
+	ProductID string
+	Amount    money.Money
+}
+
+var _ error = (*PromotionNotApplicableError)(nil)
+
+type PromotionNotApplicableError struct {
+	Code   string
+	Reason string
+}
+
+func (err *PromotionNotApplicableError) Error() string {
+	return fmt.Sprintf("coupon %s is not applicable: %s", err.Code, err.Reason)
+}
+
+// NormalizeCode makes coupon codes case-insensitive
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (promotion *Promotion) GetCode() string {
+	return promotion.Code
+}
+
+// GetReason returns the description or a generated description of the promotion
+func (promotion *Promotion) GetReason() string {
+	if promotion.Description != "" {
+		return promotion.Description
+	}
+
+	var reason string
+	switch promotion.Type {
+	case PromotionTypePercentage:
+		if promotion.ProductID != "" {
+			reason = fmt.Sprintf("%d%% off product %s", promotion.Percentage, promotion.ProductID)
+		} else {
+			reason = fmt.Sprintf("%d%% off", promotion.Percentage)
+		}
+	case PromotionTypeFixedAmount:
+		reason = fmt.Sprintf("%s off", promotion.Amount)
+	case PromotionTypeBuyXGetY:
+		reason = fmt.Sprintf("Buy %d get %d free on product %s", promotion.BuyCount, promotion.FreeCount, promotion.ProductID)
+	default:
+		reason = promotion.Code
+	}
+
+	if promotion.MinimumTotal.GetCurrency() != "" {
+		reason += fmt.Sprintf(" from %s", promotion.MinimumTotal)
+	}
+
+	return reason
+}
+
+// Calculate returns the discounts of the promotion for the given items,
+// totals contains the basket total per currency before any discount
+func (promotion *Promotion) Calculate(items []*PromotionItem, totals map[string]money.Money) ([]*Discount, error) {
+	if promotion.MinimumTotal.GetCurrency() != "" {
+		total, totalExists := totals[promotion.MinimumTotal.GetCurrency()]
+		if !totalExists {
+			total = money.Zero(promotion.MinimumTotal.GetCurrency())
+		}
+
+		compare, compareErr := total.Compare(promotion.MinimumTotal)
+		if compareErr != nil {
+			return nil, compareErr
+		}
+
+		if compare < 0 {
+			return nil, promotion.notApplicable(fmt.Sprintf("the basket total must be at least %s", promotion.MinimumTotal))
+		}
+	}
+
+	switch promotion.Type {
+	case PromotionTypePercentage:
+		return promotion.calculatePercentage(items, totals)
+	case PromotionTypeFixedAmount:
+		return promotion.calculateFixedAmount(totals)
+	case PromotionTypeBuyXGetY:
+		return promotion.calculateBuyXGetY(items)
+	default:
+		return nil, fmt.Errorf("promotion %s has unknown type %s", promotion.Code, promotion.Type)
+	}
+}
+
+func (promotion *Promotion) calculatePercentage(items []*PromotionItem, totals map[string]money.Money) ([]*Discount, error) {
+	if promotion.Percentage <= 0 || promotion.Percentage > 100 {
+		return nil, fmt.Errorf("promotion %s has invalid percentage %d", promotion.Code, promotion.Percentage)
+	}
+
+	if promotion.ProductID == "" {
+		discounts := make([]*Discount, 0, len(totals))
+		for _, currency := range sortedCurrencies(totals) {
+			amount, amountErr := totals[currency].MultiplyRatio(promotion.Percentage, 100)
+			if amountErr != nil {
+				return nil, amountErr
+			}
+			discounts = append(discounts, promotion.newDiscount(amount))
+		}
+
+		return discounts, nil
+	}
+
+	item := findItem(items, promotion.ProductID)
+	if item == nil {
+		return nil, promotion.notApplicable(fmt.Sprintf("product %s is not in the basket", promotion.ProductID))
+	}
+
+	subtotal, subtotalErr := item.Price.Multiply(int64(item.Count))
+	if subtotalErr != nil {
+		return nil, subtotalErr
+	}
+
+	amount, amountErr := subtotal.MultiplyRatio(promotion.Percentage, 100)
+	if amountErr != nil {
+		return nil, amountErr
+	}
+
+	return []*Discount{promotion.newProductDiscount(amount)}, nil
+}
+
+func (promotion *Promotion) calculateFixedAmount(totals map[string]money.Money) ([]*Discount, error) {
+	if !promotion.Amount.IsPositive() {
+		return nil, fmt.Errorf("promotion %s has invalid amount %s", promotion.Code, promotion.Amount)
+	}
+
+	if _, totalExists := totals[promotion.Amount.GetCurrency()]; !totalExists {
+		return nil, promotion.notApplicable(fmt.Sprintf("the basket has no products in %s", promotion.Amount.GetCurrency()))
+	}
+
+	return []*Discount{promotion.newDiscount(promotion.Amount)}, nil
+}
+
+func (promotion *Promotion) calculateBuyXGetY(items []*PromotionItem) ([]*Discount, error) {
+	if promotion.ProductID == "" || promotion.BuyCount <= 0 || promotion.FreeCount <= 0 {
+		return nil, fmt.Errorf("promotion %s has an invalid buy x get y configuration", promotion.Code)
+	}
+
+	item := findItem(items, promotion.ProductID)
+
+	groupSize := promotion.BuyCount + promotion.FreeCount
+	if item == nil || item.Count < groupSize {
+		return nil, promotion.notApplicable(fmt.Sprintf("the basket must contain at least %d of product %s", groupSize, promotion.ProductID))
+	}
+
+	freeUnits := item.Count / groupSize * promotion.FreeCount
+
+	amount, amountErr := item.Price.Multiply(int64(freeUnits))
+	if amountErr != nil {
+		return nil, amountErr
+	}
+
+	return []*Discount{promotion.newProductDiscount(amount)}, nil
+}
+
+func (promotion *Promotion) newDiscount(amount money.Money) *Discount {
+	return &Discount{
+		Code:   promotion.Code,
+		Reason: promotion.GetReason(),
+		Amount: amount,
+	}
+}
+
+func (promotion *Promotion) newProductDiscount(amount money.Money) *Discount {
+	discount := promotion.newDiscount(amount)
+	discount.ProductID = promotion.ProductID
+
+	return discount
+}
+
+func (promotion *Promotion) notApplicable(reason string) error {
+	return &PromotionNotApplicableError{
+		Code:   promotion.Code,
+		Reason: reason,
+	}
+}
+
+// findItem sums up the counts in case the product is contained more than once
+func findItem(items []*PromotionItem, productID string) *PromotionItem {
+	var found *PromotionItem
+	for _, item := range items {
+		if item.ProductID != productID {
+			continue
+		}
+		if found == nil {
+			found = &PromotionItem{ProductID: item.ProductID, Price: item.Price}
+		}
+		found.Count += item.Count
+	}
+
+	return found
+}
+
+func sortedCurrencies(totals map[string]money.Money) []string {
+	currencies := make([]string, 0, len(totals))
+	for currency := range totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	return currencies
+}
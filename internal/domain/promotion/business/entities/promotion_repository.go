@@ -0,0 +1,15 @@
+package entities
+
+import "context"
+
+//go:generate mockgen -source=promotion_repository.go -destination=promotion_repository_mock.go -package=entities
+
+type PromotionRepository interface {
+	// FindByCode expects a normalized code
+	FindByCode(ctx context.Context, code string) (*Promotion, error)
+	FindAll(ctx context.Context) ([]*Promotion, error)
+	Save(ctx context.Context, promotion *Promotion) error
+}
+
+// PromotionResource is the resource of the domainerror.NotFoundError returned if no promotion has the coupon code
+const PromotionResource = "coupon"
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_Promotion_Calculate(t *testing.T) {
+	items := []*PromotionItem{
+		{ProductID: "1", Count: 5, Price: money.New(1000, "EUR")},
+		{ProductID: "2", Count: 1, Price: money.New(999, "USD")},
+	}
+	totals := map[string]money.Money{
+		"EUR": money.New(5000, "EUR"),
+		"USD": money.New(999, "USD"),
+	}
+
+	testCases := map[string]struct {
+		promotion *Promotion
+		discounts []money.Money
+	}{
+		"percentage off the basket": {
+			promotion: &Promotion{Code: "P", Type: PromotionTypePercentage, Percentage: 10},
+			discounts: []money.Money{money.New(500, "EUR"), money.New(100, "USD")},
+		},
+		"percentage off a product": {
+			promotion: &Promotion{Code: "P", Type: PromotionTypePercentage, Percentage: 15, ProductID: "2"},
+			discounts: []money.Money{money.New(150, "USD")},
+		},
+		"fixed amount off": {
+			promotion: &Promotion{Code: "F", Type: PromotionTypeFixedAmount, Amount: money.New(250, "EUR")},
+			discounts: []money.Money{money.New(250, "EUR")},
+		},
+		"buy 2 get 1": {
+			promotion: &Promotion{Code: "B", Type: PromotionTypeBuyXGetY, ProductID: "1", BuyCount: 2, FreeCount: 1},
+			discounts: []money.Money{money.New(1000, "EUR")},
+		},
+		"threshold reached": {
+			promotion: &Promotion{Code: "T", Type: PromotionTypeFixedAmount, Amount: money.New(500, "EUR"), MinimumTotal: money.New(5000, "EUR")},
+			discounts: []money.Money{money.New(500, "EUR")},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			discounts, err := testCase.promotion.Calculate(items, totals)
+
+			require.NoError(t, err)
+			require.Len(t, discounts, len(testCase.discounts))
+			for i, discount := range discounts {
+				require.Equal(t, testCase.discounts[i], discount.Amount)
+				require.Equal(t, testCase.promotion.GetCode(), discount.Code)
+				require.Equal(t, testCase.promotion.ProductID, discount.ProductID)
+				require.NotEmpty(t, discount.Reason)
+			}
+		})
+	}
+}
+
+func Test_Promotion_Calculate_NotApplicable(t *testing.T) {
+	items := []*PromotionItem{
+		{ProductID: "1", Count: 2, Price: money.New(1000, "EUR")},
+	}
+	totals := map[string]money.Money{
+		"EUR": money.New(2000, "EUR"),
+	}
+
+	testCases := map[string]*Promotion{
+		"the basket total must be at least 50.00 EUR": {Code: "T", Type: PromotionTypePercentage, Percentage: 10, MinimumTotal: money.New(5000, "EUR")},
+		"product 2 is not in the basket":              {Code: "P", Type: PromotionTypePercentage, Percentage: 10, ProductID: "2"},
+		"the basket has no products in USD":           {Code: "F", Type: PromotionTypeFixedAmount, Amount: money.New(500, "USD")},
+		"must contain at least 3 of product 1":        {Code: "B", Type: PromotionTypeBuyXGetY, ProductID: "1", BuyCount: 2, FreeCount: 1},
+	}
+
+	for reason, promotion := range testCases {
+		t.Run(reason, func(t *testing.T) {
+			discounts, err := promotion.Calculate(items, totals)
+
+			require.ErrorAs(t, err, new(*PromotionNotApplicableError))
+			require.ErrorContains(t, err, reason)
+			require.Nil(t, discounts)
+		})
+	}
+}
+
+func Test_Promotion_Calculate_InvalidConfiguration(t *testing.T) {
+	promotions := []*Promotion{
+		{Code: "P", Type: PromotionTypePercentage, Percentage: 101},
+		{Code: "F", Type: PromotionTypeFixedAmount},
+		{Code: "B", Type: PromotionTypeBuyXGetY, ProductID: "1"},
+		{Code: "U", Type: "unknown"},
+	}
+
+	for _, promotion := range promotions {
+		_, err := promotion.Calculate(nil, map[string]money.Money{})
+
+		require.Error(t, err)
+		require.NotErrorAs(t, err, new(*PromotionNotApplicableError))
+	}
+}
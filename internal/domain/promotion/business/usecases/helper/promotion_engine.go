@@ -0,0 +1,138 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+type PromotionResult struct {
+	// Discounts contains the applied discounts in the order of the coupon codes
+	Discounts []*entities.Discount
+	// Rejected contains the reason per coupon code which could not be applied
+	Rejected map[string]string
+	// Totals contains the total per currency after the discounts
+	Totals map[string]money.Money
+}
+
+// PromotionEngine evaluates the coupons of a basket
+type PromotionEngine interface {
+	Evaluate(ctx context.Context, codes []string, items []*entities.PromotionItem) (*PromotionResult, error)
+}
+
+var _ PromotionEngine = (*PromotionEngineImpl)(nil)
+
+type PromotionEngineImpl struct {
+	promotionRepository entities.PromotionRepository
+}
+
+func NewPromotionEngine(promotionRepository entities.PromotionRepository) PromotionEngine {
+	return &PromotionEngineImpl{
+		promotionRepository: promotionRepository,
+	}
+}
+
+// Evaluate applies the coupons in the given order,
+// every promotion is checked against the totals before any discount and a total never gets negative
+func (engine *PromotionEngineImpl) Evaluate(ctx context.Context, codes []string, items []*entities.PromotionItem) (*PromotionResult, error) {
+	totals := map[string]money.Money{}
+	for _, item := range items {
+		subtotal, subtotalErr := item.Price.Multiply(int64(item.Count))
+		if subtotalErr != nil {
+			return nil, subtotalErr
+		}
+
+		total, totalExists := totals[subtotal.GetCurrency()]
+		if !totalExists {
+			total = money.Zero(subtotal.GetCurrency())
+		}
+
+		total, totalErr := total.Add(subtotal)
+		if totalErr != nil {
+			return nil, totalErr
+		}
+		totals[subtotal.GetCurrency()] = total
+	}
+
+	result := &PromotionResult{
+		Discounts: []*entities.Discount{},
+		Rejected:  map[string]string{},
+		Totals:    map[string]money.Money{},
+	}
+	for currency, total := range totals {
+		result.Totals[currency] = total
+	}
+
+	for _, code := range codes {
+		if len(totals) == 0 {
+			result.Rejected[code] = "the basket is empty"
+			continue
+		}
+
+		promotion, promotionErr := engine.promotionRepository.FindByCode(ctx, code)
+		if promotionErr != nil {
+			if domainerror.IsNotFound(promotionErr, entities.PromotionResource) {
+				result.Rejected[code] = "the coupon does not exist anymore"
+				continue
+			}
+			return nil, promotionErr
+		}
+
+		discounts, discountsErr := promotion.Calculate(items, totals)
+		if discountsErr != nil {
+			var promotionNotApplicableErr *entities.PromotionNotApplicableError
+			if errors.As(discountsErr, &promotionNotApplicableErr) {
+				result.Rejected[code] = promotionNotApplicableErr.Reason
+				continue
+			}
+			return nil, discountsErr
+		}
+
+		applied := false
+		for _, discount := range discounts {
+			remaining := result.Totals[discount.Amount.GetCurrency()]
+
+			compare, compareErr := discount.Amount.Compare(remaining)
+			if compareErr != nil {
+				return nil, compareErr
+			}
+			if compare > 0 {
+				discount.Amount = remaining
+			}
+
+			if !discount.Amount.IsPositive() {
+				continue
+			}
+
+			remaining, remainingErr := remaining.Subtract(discount.Amount)
+			if remainingErr != nil {
+				return nil, remainingErr
+			}
+			result.Totals[discount.Amount.GetCurrency()] = remaining
+
+			result.Discounts = append(result.Discounts, discount)
+			applied = true
+		}
+
+		if !applied {
+			result.Rejected[code] = "the basket total is already fully discounted"
+		}
+	}
+
+	return result, nil
+}
+
+// SortedCurrencies returns the currencies of the totals in a stable order
+func (result *PromotionResult) SortedCurrencies() []string {
+	currencies := make([]string, 0, len(result.Totals))
+	for currency := range result.Totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	return currencies
+}
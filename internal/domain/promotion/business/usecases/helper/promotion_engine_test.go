@@ -0,0 +1,109 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_PromotionEngine_Evaluate(t *testing.T) {
+	// arrange
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	promotionRepositoryMock := entities.NewMockPromotionRepository(ctrl)
+	promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "B2G1").Return(&entities.Promotion{
+		Code:      "B2G1",
+		Type:      entities.PromotionTypeBuyXGetY,
+		ProductID: "1",
+		BuyCount:  2,
+		FreeCount: 1,
+	}, nil)
+	promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "TEN").Return(&entities.Promotion{
+		Code:       "TEN",
+		Type:       entities.PromotionTypePercentage,
+		Percentage: 10,
+	}, nil)
+	promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "BIG").Return(&entities.Promotion{
+		Code:         "BIG",
+		Type:         entities.PromotionTypeFixedAmount,
+		Amount:       money.New(500, "EUR"),
+		MinimumTotal: money.New(100000, "EUR"),
+	}, nil)
+	promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "GONE").Return(nil, &domainerror.NotFoundError{Resource: entities.PromotionResource, ID: "GONE"})
+
+	engine := NewPromotionEngine(promotionRepositoryMock)
+
+	items := []*entities.PromotionItem{
+		{ProductID: "1", Count: 3, Price: money.New(1000, "EUR")},
+		{ProductID: "2", Count: 1, Price: money.New(500, "EUR")},
+	}
+
+	// act
+
+	result, err := engine.Evaluate(t.Context(), []string{"B2G1", "TEN", "BIG", "GONE"}, items)
+
+	// assert
+
+	require.NoError(t, err)
+	require.Len(t, result.Discounts, 2)
+
+	// one of three units of product 1 is free
+	require.Equal(t, "B2G1", result.Discounts[0].Code)
+	require.Equal(t, money.New(1000, "EUR"), result.Discounts[0].Amount)
+
+	// 10 % of the total before any discount
+	require.Equal(t, "TEN", result.Discounts[1].Code)
+	require.Equal(t, money.New(350, "EUR"), result.Discounts[1].Amount)
+
+	require.Equal(t, money.New(2150, "EUR"), result.Totals["EUR"])
+
+	require.Len(t, result.Rejected, 2)
+	require.Contains(t, result.Rejected["BIG"], "at least 1000.00 EUR")
+	require.Contains(t, result.Rejected, "GONE")
+}
+
+func Test_PromotionEngine_Evaluate_TotalNeverNegative(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	promotionRepositoryMock := entities.NewMockPromotionRepository(ctrl)
+	promotionRepositoryMock.EXPECT().FindByCode(gomock.Any(), "FIVE").Return(&entities.Promotion{
+		Code:   "FIVE",
+		Type:   entities.PromotionTypeFixedAmount,
+		Amount: money.New(500, "EUR"),
+	}, nil).Times(2)
+
+	engine := NewPromotionEngine(promotionRepositoryMock)
+
+	items := []*entities.PromotionItem{
+		{ProductID: "1", Count: 1, Price: money.New(300, "EUR")},
+	}
+
+	result, err := engine.Evaluate(t.Context(), []string{"FIVE", "FIVE"}, items)
+
+	require.NoError(t, err)
+	require.Len(t, result.Discounts, 1)
+	require.Equal(t, money.New(300, "EUR"), result.Discounts[0].Amount)
+	require.True(t, result.Totals["EUR"].IsZero())
+	require.Contains(t, result.Rejected, "FIVE")
+}
+
+func Test_PromotionEngine_Evaluate_EmptyBasket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	engine := NewPromotionEngine(entities.NewMockPromotionRepository(ctrl))
+
+	result, err := engine.Evaluate(t.Context(), []string{"TEN"}, nil)
+
+	require.NoError(t, err)
+	require.Empty(t, result.Discounts)
+	require.Equal(t, "the basket is empty", result.Rejected["TEN"])
+}
@@ -0,0 +1,68 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+var _ entities.PromotionRepository = (*InMemoryPromotionRepository)(nil)
+
+type InMemoryPromotionRepository struct {
+	mutex      sync.RWMutex
+	promotions map[string]*entities.Promotion
+}
+
+func NewInMemoryPromotionRepository() entities.PromotionRepository {
+	return &InMemoryPromotionRepository{
+		promotions: make(map[string]*entities.Promotion),
+	}
+}
+
+func (repository *InMemoryPromotionRepository) FindByCode(ctx context.Context, code string) (*entities.Promotion, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	promotion, promotionExists := repository.promotions[code]
+	if !promotionExists {
+		return nil, &domainerror.NotFoundError{Resource: entities.PromotionResource, ID: code}
+	}
+
+	return promotion, nil
+}
+
+func (repository *InMemoryPromotionRepository) FindAll(ctx context.Context) ([]*entities.Promotion, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	promotions := make([]*entities.Promotion, 0, len(repository.promotions))
+	for _, promotion := range repository.promotions {
+		promotions = append(promotions, promotion)
+	}
+
+	sort.Slice(promotions, func(i, j int) bool {
+		return promotions[i].GetCode() < promotions[j].GetCode()
+	})
+
+	return promotions, nil
+}
+
+func (repository *InMemoryPromotionRepository) Save(ctx context.Context, promotion *entities.Promotion) error {
+	if promotion == nil {
+		return fmt.Errorf("promotion is nil")
+	} else if promotion.GetCode() == "" {
+		return fmt.Errorf("promotion code is empty")
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	promotion.Code = entities.NormalizeCode(promotion.GetCode())
+	repository.promotions[promotion.GetCode()] = promotion
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/promotion/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_InMemoryPromotionRepository(t *testing.T) {
+	repository := NewInMemoryPromotionRepository()
+
+	promotion, err := repository.FindByCode(t.Context(), "TEN")
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+	require.Nil(t, promotion)
+
+	err = repository.Save(t.Context(), &entities.Promotion{Code: " ten ", Type: entities.PromotionTypePercentage, Percentage: 10})
+	require.NoError(t, err)
+	err = repository.Save(t.Context(), &entities.Promotion{Code: "FIVE", Type: entities.PromotionTypeFixedAmount, Amount: money.New(500, "EUR")})
+	require.NoError(t, err)
+
+	promotion, err = repository.FindByCode(t.Context(), "TEN")
+
+	require.NoError(t, err)
+	require.Equal(t, int64(10), promotion.Percentage)
+
+	promotions, err := repository.FindAll(t.Context())
+
+	require.NoError(t, err)
+	require.Len(t, promotions, 2)
+	require.Equal(t, "FIVE", promotions[0].GetCode())
+}
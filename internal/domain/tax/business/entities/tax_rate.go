@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+type TaxClass string
+
+const (
+	TaxClassStandard TaxClass = "standard"
+	TaxClassReduced  TaxClass = "reduced"
+	TaxClassZero     TaxClass = "zero"
+)
+
+// basisPointsPerUnit is 100 %
+const basisPointsPerUnit = 10000
+
+// TaxRate is the VAT rate of a tax class in a country
+type TaxRate struct {
+	// Country is the ISO 3166-1 alpha-2 code, e.g. DE
+	Country string
+	Class   TaxClass
+	// BasisPoints is the rate in hundredths of a percent, e.g. 1900 for 19 %
+	BasisPoints int64
+}
+
+var _ error = (*TaxRateNotFoundError)(nil)
+
+type TaxRateNotFoundError struct {
+	Country string
+	Class   TaxClass
+}
+
+func (err *TaxRateNotFoundError) Error() string {
+	return fmt.Sprintf("tax rate of class %s in country %s not found", err.Class, err.Country)
+}
+
+// NormalizeClass returns the standard class for products without a tax class
+func NormalizeClass(class TaxClass) TaxClass {
+	if class == "" {
+		return TaxClassStandard
+	}
+
+	return class
+}
+
+// NormalizeCountry makes country codes case-insensitive
+func NormalizeCountry(country string) string {
+	return strings.ToUpper(strings.TrimSpace(country))
+}
+
+func (rate *TaxRate) GetCountry() string {
+	return rate.Country
+}
+
+func (rate *TaxRate) GetClass() TaxClass {
+	return rate.Class
+}
+
+func (rate *TaxRate) GetBasisPoints() int64 {
+	return rate.BasisPoints
+}
+
+// Format returns the rate as percentage, e.g. "19%" or "5.5%"
+func (rate *TaxRate) Format() string {
+	return FormatBasisPoints(rate.BasisPoints)
+}
+
+// SplitGross splits a gross amount, which includes the tax, into net and tax.
+// The net amount is rounded, so net and tax always add up to the gross amount.
+func (rate *TaxRate) SplitGross(gross money.Money) (money.Money, money.Money, error) {
+	net, netErr := gross.MultiplyRatio(basisPointsPerUnit, basisPointsPerUnit+rate.BasisPoints)
+	if netErr != nil {
+		return money.Money{}, money.Money{}, netErr
+	}
+
+	tax, taxErr := gross.Subtract(net)
+	if taxErr != nil {
+		return money.Money{}, money.Money{}, taxErr
+	}
+
+	return net, tax, nil
+}
+
+// FormatBasisPoints returns basis points as percentage without trailing zeros
+func FormatBasisPoints(basisPoints int64) string {
+	percentage := fmt.Sprintf("%d.%02d", basisPoints/100, basisPoints%100)
+	percentage = strings.TrimRight(strings.TrimRight(percentage, "0"), ".")
+
+	return percentage + "%"
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_TaxRate_SplitGross(t *testing.T) {
+	testCases := map[string]struct {
+		basisPoints int64
+		gross       money.Money
+		net         money.Money
+		tax         money.Money
+	}{
+		"19 %": {
+			basisPoints: 1900,
+			gross:       money.New(1190, "EUR"),
+			net:         money.New(1000, "EUR"),
+			tax:         money.New(190, "EUR"),
+		},
+		"7 % rounded": {
+			basisPoints: 700,
+			gross:       money.New(1199, "EUR"),
+			net:         money.New(1121, "EUR"),
+			tax:         money.New(78, "EUR"),
+		},
+		"0 %": {
+			basisPoints: 0,
+			gross:       money.New(1199, "EUR"),
+			net:         money.New(1199, "EUR"),
+			tax:         money.New(0, "EUR"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rate := &TaxRate{Country: "DE", Class: TaxClassStandard, BasisPoints: testCase.basisPoints}
+
+			net, tax, err := rate.SplitGross(testCase.gross)
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.net, net)
+			require.Equal(t, testCase.tax, tax)
+		})
+	}
+}
+
+func Test_TaxRate_Format(t *testing.T) {
+	require.Equal(t, "19%", (&TaxRate{BasisPoints: 1900}).Format())
+	require.Equal(t, "5.5%", (&TaxRate{BasisPoints: 550}).Format())
+	require.Equal(t, "8.1%", (&TaxRate{BasisPoints: 810}).Format())
+	require.Equal(t, "0%", (&TaxRate{BasisPoints: 0}).Format())
+}
+
+func Test_NormalizeClass(t *testing.T) {
+	require.Equal(t, TaxClassStandard, NormalizeClass(""))
+	require.Equal(t, TaxClassReduced, NormalizeClass(TaxClassReduced))
+}
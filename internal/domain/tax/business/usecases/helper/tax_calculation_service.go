@@ -0,0 +1,271 @@
+package helper
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+// TaxableAmount is a gross amount of one tax class, e.g. the subtotal of a basket line
+type TaxableAmount struct {
+	ProductID string
+	Class     entities.TaxClass
+	Gross     money.Money
+}
+
+// TaxableDiscount reduces the gross amounts, only the amount of the product if ProductID is set
+type TaxableDiscount struct {
+	ProductID string
+	Amount    money.Money
+}
+
+// TaxAmount splits a gross amount of one tax class into net and tax
+type TaxAmount struct {
+	Class       entities.TaxClass
+	BasisPoints int64
+	Net         money.Money
+	Tax         money.Money
+	Gross       money.Money
+}
+
+// TaxTotal is the tax of all amounts of one currency
+type TaxTotal struct {
+	Net   money.Money
+	Tax   money.Money
+	Gross money.Money
+	// Classes contains the amounts per tax class, sorted by class
+	Classes []*TaxAmount
+}
+
+// TaxCalculationService calculates the tax of gross prices with the rates of one country
+type TaxCalculationService interface {
+	GetCountry() string
+	// Calculate splits the gross amount of a tax class into net and tax
+	Calculate(class entities.TaxClass, gross money.Money) (*TaxAmount, error)
+	// CalculateTotal sums up the gross amounts per tax class and splits every class into net and tax.
+	// The discount is split across the classes in proportion to their gross amounts.
+	CalculateTotal(amounts []*TaxableAmount, discount money.Money) (*TaxTotal, error)
+	// CalculateTotals calculates one total per currency after the discounts, sorted by currency.
+	// A product discount reduces the amount of its product, the other discounts are split across all amounts of their currency.
+	CalculateTotals(amounts []*TaxableAmount, discounts []*TaxableDiscount) ([]*TaxTotal, error)
+}
+
+var _ TaxCalculationService = (*TaxCalculationServiceImpl)(nil)
+
+type TaxCalculationServiceImpl struct {
+	country string
+	rates   map[entities.TaxClass]*entities.TaxRate
+}
+
+// NewTaxCalculationService uses the rates of the given country, rates of other countries are ignored
+func NewTaxCalculationService(country string, rates []*entities.TaxRate) (TaxCalculationService, error) {
+	country = entities.NormalizeCountry(country)
+	if country == "" {
+		return nil, fmt.Errorf("country is empty")
+	}
+
+	countryRates := map[entities.TaxClass]*entities.TaxRate{}
+	for _, rate := range rates {
+		if rate == nil || entities.NormalizeCountry(rate.GetCountry()) != country {
+			continue
+		}
+		if rate.GetBasisPoints() < 0 {
+			return nil, fmt.Errorf("tax rate of class %s in country %s is negative", rate.GetClass(), country)
+		}
+
+		countryRates[entities.NormalizeClass(rate.GetClass())] = rate
+	}
+
+	if len(countryRates) == 0 {
+		return nil, fmt.Errorf("no tax rates for country %s", country)
+	}
+
+	return &TaxCalculationServiceImpl{
+		country: country,
+		rates:   countryRates,
+	}, nil
+}
+
+func (service *TaxCalculationServiceImpl) GetCountry() string {
+	return service.country
+}
+
+func (service *TaxCalculationServiceImpl) Calculate(class entities.TaxClass, gross money.Money) (*TaxAmount, error) {
+	class = entities.NormalizeClass(class)
+
+	rate, rateExists := service.rates[class]
+	if !rateExists {
+		return nil, &entities.TaxRateNotFoundError{Country: service.country, Class: class}
+	}
+
+	net, tax, splitErr := rate.SplitGross(gross)
+	if splitErr != nil {
+		return nil, splitErr
+	}
+
+	return &TaxAmount{
+		Class:       class,
+		BasisPoints: rate.GetBasisPoints(),
+		Net:         net,
+		Tax:         tax,
+		Gross:       gross,
+	}, nil
+}
+
+func (service *TaxCalculationServiceImpl) CalculateTotal(amounts []*TaxableAmount, discount money.Money) (*TaxTotal, error) {
+	currency := discount.GetCurrency()
+	if currency == "" {
+		return nil, fmt.Errorf("discount currency is empty")
+	}
+
+	// sum up the gross amounts per class
+	classGross := map[entities.TaxClass]money.Money{}
+	total := money.Zero(currency)
+	for _, amount := range amounts {
+		class := entities.NormalizeClass(amount.Class)
+
+		gross, grossExists := classGross[class]
+		if !grossExists {
+			gross = money.Zero(currency)
+		}
+
+		gross, grossErr := gross.Add(amount.Gross)
+		if grossErr != nil {
+			return nil, grossErr
+		}
+		classGross[class] = gross
+
+		var totalErr error
+		total, totalErr = total.Add(amount.Gross)
+		if totalErr != nil {
+			return nil, totalErr
+		}
+	}
+
+	compare, compareErr := discount.Compare(total)
+	if compareErr != nil {
+		return nil, compareErr
+	}
+	if discount.IsNegative() || compare > 0 {
+		return nil, fmt.Errorf("discount %s must be between 0 and the total %s", discount, total)
+	}
+
+	classes := make([]entities.TaxClass, 0, len(classGross))
+	for class := range classGross {
+		classes = append(classes, class)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i] < classes[j]
+	})
+
+	taxTotal := &TaxTotal{
+		Net:     money.Zero(currency),
+		Tax:     money.Zero(currency),
+		Gross:   money.Zero(currency),
+		Classes: make([]*TaxAmount, 0, len(classes)),
+	}
+
+	// the last class gets the rounding difference of the discount
+	remainingDiscount := discount
+	for i, class := range classes {
+		gross := classGross[class]
+
+		classDiscount := remainingDiscount
+		if i < len(classes)-1 {
+			var classDiscountErr error
+			classDiscount, classDiscountErr = discount.MultiplyRatio(gross.GetAmount(), total.GetAmount())
+			if classDiscountErr != nil {
+				return nil, classDiscountErr
+			}
+		}
+
+		remainingDiscount, _ = remainingDiscount.Subtract(classDiscount)
+
+		gross, grossErr := gross.Subtract(classDiscount)
+		if grossErr != nil {
+			return nil, grossErr
+		}
+
+		taxAmount, taxAmountErr := service.Calculate(class, gross)
+		if taxAmountErr != nil {
+			return nil, taxAmountErr
+		}
+		taxTotal.Classes = append(taxTotal.Classes, taxAmount)
+
+		taxTotal.Net, _ = taxTotal.Net.Add(taxAmount.Net)
+		taxTotal.Tax, _ = taxTotal.Tax.Add(taxAmount.Tax)
+		taxTotal.Gross, _ = taxTotal.Gross.Add(taxAmount.Gross)
+	}
+
+	return taxTotal, nil
+}
+
+func (service *TaxCalculationServiceImpl) CalculateTotals(amounts []*TaxableAmount, discounts []*TaxableDiscount) ([]*TaxTotal, error) {
+	// copy the amounts because the product discounts change them
+	discountedAmounts := make([]*TaxableAmount, 0, len(amounts))
+	currencyDiscounts := map[string]money.Money{}
+	for _, amount := range amounts {
+		discountedAmount := *amount
+		discountedAmounts = append(discountedAmounts, &discountedAmount)
+
+		currencyDiscounts[amount.Gross.GetCurrency()] = money.Zero(amount.Gross.GetCurrency())
+	}
+
+	for _, discount := range discounts {
+		currencyDiscount := discount.Amount
+
+		if discount.ProductID != "" {
+			for _, amount := range discountedAmounts {
+				if amount.ProductID != discount.ProductID || amount.Gross.GetCurrency() != currencyDiscount.GetCurrency() {
+					continue
+				}
+
+				// an amount cannot get negative, the rest is split across all amounts
+				productDiscount := currencyDiscount
+				if compare, _ := productDiscount.Compare(amount.Gross); compare > 0 {
+					productDiscount = amount.Gross
+				}
+
+				amount.Gross, _ = amount.Gross.Subtract(productDiscount)
+				currencyDiscount, _ = currencyDiscount.Subtract(productDiscount)
+			}
+		}
+
+		total, totalExists := currencyDiscounts[currencyDiscount.GetCurrency()]
+		if !totalExists {
+			return nil, fmt.Errorf("discount %s has no amounts of its currency", discount.Amount)
+		}
+
+		total, totalErr := total.Add(currencyDiscount)
+		if totalErr != nil {
+			return nil, totalErr
+		}
+		currencyDiscounts[currencyDiscount.GetCurrency()] = total
+	}
+
+	currencies := make([]string, 0, len(currencyDiscounts))
+	for currency := range currencyDiscounts {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	taxTotals := make([]*TaxTotal, 0, len(currencies))
+	for _, currency := range currencies {
+		currencyAmounts := make([]*TaxableAmount, 0, len(discountedAmounts))
+		for _, amount := range discountedAmounts {
+			if amount.Gross.GetCurrency() == currency {
+				currencyAmounts = append(currencyAmounts, amount)
+			}
+		}
+
+		taxTotal, taxTotalErr := service.CalculateTotal(currencyAmounts, currencyDiscounts[currency])
+		if taxTotalErr != nil {
+			return nil, taxTotalErr
+		}
+		taxTotals = append(taxTotals, taxTotal)
+	}
+
+	return taxTotals, nil
+}
@@ -0,0 +1,141 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+var testTaxRates = []*entities.TaxRate{
+	{Country: "DE", Class: entities.TaxClassStandard, BasisPoints: 1900},
+	{Country: "DE", Class: entities.TaxClassReduced, BasisPoints: 700},
+	{Country: "AT", Class: entities.TaxClassStandard, BasisPoints: 2000},
+}
+
+func Test_TaxCalculationService_NewTaxCalculationService_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		country string
+	}{
+		"country is empty": {
+			country: " ",
+		},
+		"no tax rates for country FR": {
+			country: "fr",
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			service, err := NewTaxCalculationService(testCase.country, testTaxRates)
+
+			require.ErrorContains(t, err, errorString)
+			require.Nil(t, service)
+		})
+	}
+}
+
+func Test_TaxCalculationService_Calculate(t *testing.T) {
+	service, err := NewTaxCalculationService("de", testTaxRates)
+	require.NoError(t, err)
+	require.Equal(t, "DE", service.GetCountry())
+
+	// products without tax class use the standard class
+	taxAmount, err := service.Calculate("", money.New(2380, "EUR"))
+
+	require.NoError(t, err)
+	require.Equal(t, entities.TaxClassStandard, taxAmount.Class)
+	require.Equal(t, int64(1900), taxAmount.BasisPoints)
+	require.Equal(t, money.New(2000, "EUR"), taxAmount.Net)
+	require.Equal(t, money.New(380, "EUR"), taxAmount.Tax)
+	require.Equal(t, money.New(2380, "EUR"), taxAmount.Gross)
+
+	_, err = service.Calculate(entities.TaxClassZero, money.New(100, "EUR"))
+
+	require.ErrorAs(t, err, new(*entities.TaxRateNotFoundError))
+}
+
+func Test_TaxCalculationService_CalculateTotal(t *testing.T) {
+	service, err := NewTaxCalculationService("DE", testTaxRates)
+	require.NoError(t, err)
+
+	amounts := []*TaxableAmount{
+		{Class: entities.TaxClassStandard, Gross: money.New(2380, "EUR")},
+		{Class: entities.TaxClassReduced, Gross: money.New(1070, "EUR")},
+		{Class: entities.TaxClassStandard, Gross: money.New(1190, "EUR")},
+	}
+
+	// 10 % off
+	taxTotal, err := service.CalculateTotal(amounts, money.New(464, "EUR"))
+
+	require.NoError(t, err)
+	require.Len(t, taxTotal.Classes, 2)
+
+	// 1070 - 107
+	require.Equal(t, entities.TaxClassReduced, taxTotal.Classes[0].Class)
+	require.Equal(t, money.New(963, "EUR"), taxTotal.Classes[0].Gross)
+	require.Equal(t, money.New(900, "EUR"), taxTotal.Classes[0].Net)
+	require.Equal(t, money.New(63, "EUR"), taxTotal.Classes[0].Tax)
+
+	// 3570 - 357
+	require.Equal(t, entities.TaxClassStandard, taxTotal.Classes[1].Class)
+	require.Equal(t, money.New(3213, "EUR"), taxTotal.Classes[1].Gross)
+	require.Equal(t, money.New(2700, "EUR"), taxTotal.Classes[1].Net)
+	require.Equal(t, money.New(513, "EUR"), taxTotal.Classes[1].Tax)
+
+	require.Equal(t, money.New(4176, "EUR"), taxTotal.Gross)
+	require.Equal(t, money.New(3600, "EUR"), taxTotal.Net)
+	require.Equal(t, money.New(576, "EUR"), taxTotal.Tax)
+}
+
+func Test_TaxCalculationService_CalculateTotal_ReturnsError(t *testing.T) {
+	service, err := NewTaxCalculationService("DE", testTaxRates)
+	require.NoError(t, err)
+
+	amounts := []*TaxableAmount{
+		{Class: entities.TaxClassStandard, Gross: money.New(1190, "EUR")},
+	}
+
+	_, err = service.CalculateTotal(amounts, money.New(1191, "EUR"))
+	require.ErrorContains(t, err, "discount 11.91 EUR must be between 0 and the total 11.90 EUR")
+
+	_, err = service.CalculateTotal(amounts, money.New(0, "USD"))
+	require.ErrorAs(t, err, new(*money.CurrencyMismatchError))
+}
+
+func Test_TaxCalculationService_CalculateTotals(t *testing.T) {
+	service, err := NewTaxCalculationService("DE", testTaxRates)
+	require.NoError(t, err)
+
+	amounts := []*TaxableAmount{
+		{ProductID: "1", Class: entities.TaxClassStandard, Gross: money.New(2380, "EUR")},
+		{ProductID: "2", Class: entities.TaxClassReduced, Gross: money.New(1070, "EUR")},
+		{ProductID: "3", Class: entities.TaxClassStandard, Gross: money.New(1190, "USD")},
+	}
+	discounts := []*TaxableDiscount{
+		// product 2 is free
+		{ProductID: "2", Amount: money.New(1070, "EUR")},
+		{Amount: money.New(119, "USD")},
+	}
+
+	taxTotals, err := service.CalculateTotals(amounts, discounts)
+
+	require.NoError(t, err)
+	require.Len(t, taxTotals, 2)
+
+	// the product discount only reduces the reduced class
+	require.Equal(t, money.New(2380, "EUR"), taxTotals[0].Gross)
+	require.Equal(t, money.New(2000, "EUR"), taxTotals[0].Net)
+	require.Equal(t, money.New(380, "EUR"), taxTotals[0].Tax)
+	require.Len(t, taxTotals[0].Classes, 2)
+	require.Equal(t, money.New(0, "EUR"), taxTotals[0].Classes[0].Gross)
+
+	require.Equal(t, money.New(1071, "USD"), taxTotals[1].Gross)
+	require.Equal(t, money.New(900, "USD"), taxTotals[1].Net)
+	require.Equal(t, money.New(171, "USD"), taxTotals[1].Tax)
+
+	// the amounts are not changed
+	require.Equal(t, money.New(1070, "EUR"), amounts[1].Gross)
+}
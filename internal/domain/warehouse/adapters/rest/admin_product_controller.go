@@ -0,0 +1,290 @@
+package rest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/httperror"
+)
+
+type AdminProductController interface {
+	CreateProduct(c *gin.Context)
+	ShowProduct(c *gin.Context)
+	UpdateProduct(c *gin.Context)
+	SetProductPrice(c *gin.Context)
+	SetProductStockPolicy(c *gin.Context)
+	AdjustProductStock(c *gin.Context)
+	DeactivateProduct(c *gin.Context)
+	ReportStockMovements(c *gin.Context)
+}
+
+var _ AdminProductController = (*AdminProductControllerImpl)(nil)
+
+type AdminProductControllerImpl struct {
+	usecases.CreateProductUseCase
+	usecases.ShowAdminProductUseCase
+	usecases.UpdateProductUseCase
+	usecases.SetProductPriceUseCase
+	usecases.SetProductStockPolicyUseCase
+	usecases.AdjustProductStockUseCase
+	usecases.DeactivateProductUseCase
+	usecases.ReportStockMovementsUseCase
+}
+
+// the prices are strings, so they are parsed as decimals without the rounding errors of floats
+type createProductRequest struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Price      string `json:"price"`
+	Currency   string `json:"currency"`
+	Stock      int    `json:"stock"`
+	LocationID string `json:"locationId"`
+	TaxClass   string `json:"taxClass"`
+}
+
+type updateProductRequest struct {
+	Name     string `json:"name"`
+	TaxClass string `json:"taxClass"`
+}
+
+type setProductPriceRequest struct {
+	Price    string `json:"price"`
+	Currency string `json:"currency"`
+}
+
+type setProductStockPolicyRequest struct {
+	StockPolicy  string `json:"stockPolicy"`
+	RestockDate  string `json:"restockDate"`
+	MaxBackorder int    `json:"maxBackorder"`
+}
+
+type adjustProductStockRequest struct {
+	Count      int    `json:"count"`
+	Reason     string `json:"reason"`
+	LocationID string `json:"locationId"`
+}
+
+func NewAdminProductController(
+	createProductUseCase usecases.CreateProductUseCase,
+	showAdminProductUseCase usecases.ShowAdminProductUseCase,
+	updateProductUseCase usecases.UpdateProductUseCase,
+	setProductPriceUseCase usecases.SetProductPriceUseCase,
+	setProductStockPolicyUseCase usecases.SetProductStockPolicyUseCase,
+	adjustProductStockUseCase usecases.AdjustProductStockUseCase,
+	deactivateProductUseCase usecases.DeactivateProductUseCase,
+	reportStockMovementsUseCase usecases.ReportStockMovementsUseCase,
+) *AdminProductControllerImpl {
+	return &AdminProductControllerImpl{
+		CreateProductUseCase:         createProductUseCase,
+		ShowAdminProductUseCase:      showAdminProductUseCase,
+		UpdateProductUseCase:         updateProductUseCase,
+		SetProductPriceUseCase:       setProductPriceUseCase,
+		SetProductStockPolicyUseCase: setProductStockPolicyUseCase,
+		AdjustProductStockUseCase:    adjustProductStockUseCase,
+		DeactivateProductUseCase:     deactivateProductUseCase,
+		ReportStockMovementsUseCase:  reportStockMovementsUseCase,
+	}
+}
+
+func (controller *AdminProductControllerImpl) CreateProduct(c *gin.Context) {
+	var request createProductRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	output, err := controller.CreateProductUseCase.Execute(
+		c.Request.Context(),
+		&usecases.CreateProductUseCaseInput{
+			ProductID:  request.ID,
+			Name:       request.Name,
+			Price:      request.Price,
+			Currency:   request.Currency,
+			Stock:      request.Stock,
+			LocationID: request.LocationID,
+			TaxClass:   request.TaxClass,
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(201, output)
+}
+
+func (controller *AdminProductControllerImpl) ShowProduct(c *gin.Context) {
+	output, err := controller.ShowAdminProductUseCase.Execute(
+		c.Request.Context(),
+		&usecases.ShowAdminProductUseCaseInput{
+			ProductID: c.Param("productID"),
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+func (controller *AdminProductControllerImpl) UpdateProduct(c *gin.Context) {
+	var request updateProductRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	output, err := controller.UpdateProductUseCase.Execute(
+		c.Request.Context(),
+		&usecases.UpdateProductUseCaseInput{
+			ProductID: c.Param("productID"),
+			Name:      request.Name,
+			TaxClass:  request.TaxClass,
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+func (controller *AdminProductControllerImpl) SetProductPrice(c *gin.Context) {
+	var request setProductPriceRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	output, err := controller.SetProductPriceUseCase.Execute(
+		c.Request.Context(),
+		&usecases.SetProductPriceUseCaseInput{
+			ProductID: c.Param("productID"),
+			Price:     request.Price,
+			Currency:  request.Currency,
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+func (controller *AdminProductControllerImpl) SetProductStockPolicy(c *gin.Context) {
+	var request setProductStockPolicyRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	output, err := controller.SetProductStockPolicyUseCase.Execute(
+		c.Request.Context(),
+		&usecases.SetProductStockPolicyUseCaseInput{
+			ProductID:    c.Param("productID"),
+			StockPolicy:  request.StockPolicy,
+			RestockDate:  request.RestockDate,
+			MaxBackorder: request.MaxBackorder,
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+func (controller *AdminProductControllerImpl) AdjustProductStock(c *gin.Context) {
+	var request adjustProductStockRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	output, err := controller.AdjustProductStockUseCase.Execute(
+		c.Request.Context(),
+		&usecases.AdjustProductStockUseCaseInput{
+			ProductID:  c.Param("productID"),
+			Count:      request.Count,
+			Reason:     request.Reason,
+			LocationID: request.LocationID,
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+func (controller *AdminProductControllerImpl) DeactivateProduct(c *gin.Context) {
+	output, err := controller.DeactivateProductUseCase.Execute(
+		c.Request.Context(),
+		&usecases.DeactivateProductUseCaseInput{
+			ProductID: c.Param("productID"),
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+func (controller *AdminProductControllerImpl) ReportStockMovements(c *gin.Context) {
+	from, err := queryTime(c, "from")
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	to, err := queryTime(c, "to")
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	output, err := controller.ReportStockMovementsUseCase.Execute(
+		c.Request.Context(),
+		&usecases.ReportStockMovementsUseCaseInput{
+			ProductID: c.Param("productID"),
+			From:      from,
+			To:        to,
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// queryTime returns the zero time for a missing query parameter, the value is a RFC 3339 time like 2025-03-01T00:00:00Z
+func queryTime(c *gin.Context, name string) (time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s %q is not a RFC 3339 time", name, value)
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,41 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminProductControllerRouter interface {
+	RegisterRoutes(router gin.IRouter) error
+}
+
+var _ AdminProductControllerRouter = (*AdminProductControllerRouterImpl)(nil)
+
+type AdminProductControllerRouterImpl struct {
+	adminProductController AdminProductController
+}
+
+func NewAdminProductControllerRouter(adminProductController AdminProductController) AdminProductControllerRouter {
+	return &AdminProductControllerRouterImpl{
+		adminProductController: adminProductController,
+	}
+}
+
+// RegisterRoutes registers the product management routes, the router has to authorize the admins
+func (controllerRouter *AdminProductControllerRouterImpl) RegisterRoutes(router gin.IRouter) error {
+	if router == nil {
+		return fmt.Errorf("router is nil")
+	}
+
+	router.POST("/products", controllerRouter.adminProductController.CreateProduct)
+	router.GET("/products/:productID", controllerRouter.adminProductController.ShowProduct)
+	router.PUT("/products/:productID", controllerRouter.adminProductController.UpdateProduct)
+	router.PUT("/products/:productID/price", controllerRouter.adminProductController.SetProductPrice)
+	router.PUT("/products/:productID/stock-policy", controllerRouter.adminProductController.SetProductStockPolicy)
+	router.POST("/products/:productID/stock-adjustments", controllerRouter.adminProductController.AdjustProductStock)
+	router.POST("/products/:productID/deactivate", controllerRouter.adminProductController.DeactivateProduct)
+	router.GET("/products/:productID/stock-movements", controllerRouter.adminProductController.ReportStockMovements)
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/httperror"
+)
+
+type ProductController interface {
+	ListProducts(c *gin.Context)
+	ShowProduct(c *gin.Context)
+}
+
+var _ ProductController = (*ProductControllerImpl)(nil)
+
+type ProductControllerImpl struct {
+	usecases.ListProductsUseCase
+	usecases.ShowProductUseCase
+}
+
+func NewProductController(
+	listProductsUseCase usecases.ListProductsUseCase,
+	showProductUseCase usecases.ShowProductUseCase,
+) *ProductControllerImpl {
+	return &ProductControllerImpl{
+		ListProductsUseCase: listProductsUseCase,
+		ShowProductUseCase:  showProductUseCase,
+	}
+}
+
+// ListProducts reads the search, the sort and the page from the query parameters q, sort, page and pageSize
+func (controller *ProductControllerImpl) ListProducts(c *gin.Context) {
+	page, err := queryInt(c, "page")
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	pageSize, err := queryInt(c, "pageSize")
+	if err != nil {
+		httperror.WriteBadRequest(c, err)
+		return
+	}
+
+	output, err := controller.ListProductsUseCase.Execute(
+		c.Request.Context(),
+		&usecases.ListProductsUseCaseInput{
+			Query:    c.Query("q"),
+			Sort:     usecases.ProductSort(c.Query("sort")),
+			Page:     page,
+			PageSize: pageSize,
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+func (controller *ProductControllerImpl) ShowProduct(c *gin.Context) {
+	output, err := controller.ShowProductUseCase.Execute(
+		c.Request.Context(),
+		&usecases.ShowProductUseCaseInput{
+			ProductID: c.Param("productID"),
+		},
+	)
+	if err != nil {
+		httperror.Write(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// queryInt returns 0 for a missing query parameter, the use case replaces it with its default
+func queryInt(c *gin.Context, name string) (int, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	integer, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s %q is not a number", name, value)
+	}
+
+	return integer, nil
+}
@@ -0,0 +1,35 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductControllerRouter interface {
+	RegisterRoutes(router gin.IRouter) error
+}
+
+var _ ProductControllerRouter = (*ProductControllerRouterImpl)(nil)
+
+type ProductControllerRouterImpl struct {
+	productController ProductController
+}
+
+func NewProductControllerRouter(productController ProductController) ProductControllerRouter {
+	return &ProductControllerRouterImpl{
+		productController: productController,
+	}
+}
+
+// RegisterRoutes registers the catalog routes, they are public, so the router should not require a token
+func (controllerRouter *ProductControllerRouterImpl) RegisterRoutes(router gin.IRouter) error {
+	if router == nil {
+		return fmt.Errorf("router is nil")
+	}
+
+	router.GET("/products", controllerRouter.productController.ListProducts)
+	router.GET("/products/:productID", controllerRouter.productController.ShowProduct)
+
+	return nil
+}
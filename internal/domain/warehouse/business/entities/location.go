@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultLocationID is the location of the stock recorded without location,
+// e.g. the seeded stock and the movements recorded before there were several locations
+const DefaultLocationID = "main"
+
+// Location is a warehouse which stores its own stock of every product
+type Location struct {
+	ID       string
+	Name     string
+	Position Position
+}
+
+func (location *Location) Validate() error {
+	if location.ID == "" {
+		return fmt.Errorf("location id is empty")
+	}
+
+	positionErr := location.Position.Validate()
+	if positionErr != nil {
+		return fmt.Errorf("location %s: %w", location.ID, positionErr)
+	}
+
+	return nil
+}
+
+// Position is a point on earth in degrees
+type Position struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func (position Position) Validate() error {
+	if position.Latitude < -90 || position.Latitude > 90 {
+		return fmt.Errorf("latitude %v must be between -90 and 90", position.Latitude)
+	} else if position.Longitude < -180 || position.Longitude > 180 {
+		return fmt.Errorf("longitude %v must be between -180 and 180", position.Longitude)
+	}
+
+	return nil
+}
+
+const earthRadiusKilometers = 6371.0
+
+// DistanceTo returns the great-circle distance in kilometers
+func (position Position) DistanceTo(other Position) float64 {
+	latitude := radians(position.Latitude)
+	otherLatitude := radians(other.Latitude)
+	deltaLatitude := otherLatitude - latitude
+	deltaLongitude := radians(other.Longitude - position.Longitude)
+
+	// haversine formula
+	a := math.Sin(deltaLatitude/2)*math.Sin(deltaLatitude/2) +
+		math.Cos(latitude)*math.Cos(otherLatitude)*math.Sin(deltaLongitude/2)*math.Sin(deltaLongitude/2)
+
+	return 2 * earthRadiusKilometers * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,16 @@
+package entities
+
+import "context"
+
+//go:generate mockgen -source=location_repository.go -destination=location_repository_mock.go -package=entities
+
+type LocationRepository interface {
+	Find(ctx context.Context, id string) (*Location, error)
+	// FindAll returns the locations sorted by their id
+	FindAll(ctx context.Context) ([]*Location, error)
+	// Save inserts or replaces the location with the same id
+	Save(ctx context.Context, location *Location) error
+}
+
+// LocationResource is the resource of the domainerror.NotFoundError returned if a location does not exist
+const LocationResource = "location"
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Location_Validate(t *testing.T) {
+	require.NoError(t, (&Location{ID: "main", Position: Position{Latitude: 52.52, Longitude: 13.405}}).Validate())
+}
+
+func Test_Location_Validate_ReturnsError(t *testing.T) {
+	testCases := map[string]*Location{
+		"location id is empty":                        {Position: Position{Latitude: 52.52, Longitude: 13.405}},
+		"latitude 91 must be between -90 and 90":      {ID: "main", Position: Position{Latitude: 91}},
+		"longitude -181 must be between -180 and 180": {ID: "main", Position: Position{Longitude: -181}},
+	}
+
+	for errorString, location := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			require.ErrorContains(t, location.Validate(), errorString)
+		})
+	}
+}
+
+func Test_Position_DistanceTo(t *testing.T) {
+	berlin := Position{Latitude: 52.52, Longitude: 13.405}
+	hamburg := Position{Latitude: 53.551, Longitude: 9.994}
+
+	require.Zero(t, berlin.DistanceTo(berlin))
+	require.InDelta(t, 255, berlin.DistanceTo(hamburg), 1)
+	require.InDelta(t, berlin.DistanceTo(hamburg), hamburg.DistanceTo(berlin), 0.001)
+}
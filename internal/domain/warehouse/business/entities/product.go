@@ -1,14 +1,123 @@
 package entities
 
+import (
+	"fmt"
+	"strings"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
 type Product struct {
 	ID    string
 	Name  string
-	Price *ProductPrice
+	Price money.Money
+	// Stock is the sum of the stock movements of the product, it is only changed by recording a movement
 	Stock int
+	// TaxClass decides the VAT rate included in the price, products without tax class use the standard class
+	TaxClass tax.TaxClass
+	// Deactivated products are not sold anymore, but they are kept for the orders and baskets containing them
+	Deactivated bool
+	// StockPolicy allows selling the product beyond its stock, the stock is negative by the backordered units
+	StockPolicy StockPolicy
+}
+
+// NewProductDeactivatedError is returned if a deactivated product is added to a basket or ordered
+func NewProductDeactivatedError(productID string) *domainerror.ConflictError {
+	return domainerror.NewConflictError("product %s is not sold anymore", productID)
+}
+
+func (product *Product) IsActive() bool {
+	return !product.Deactivated
+}
+
+func (product *Product) Deactivate() {
+	product.Deactivated = true
+}
+
+func (product *Product) Rename(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("name is empty")
+	}
+
+	product.Name = name
+
+	return nil
+}
+
+func (product *Product) SetPrice(price money.Money) error {
+	if price.IsNegative() {
+		return fmt.Errorf("price %s is negative", price)
+	}
+
+	product.Price = price
+
+	return nil
+}
+
+// SetTaxClass stores the normalized class, so an empty class is stored as standard class
+func (product *Product) SetTaxClass(class tax.TaxClass) error {
+	normalizedClass := tax.NormalizeClass(class)
+	switch normalizedClass {
+	case tax.TaxClassStandard, tax.TaxClassReduced, tax.TaxClassZero:
+	default:
+		return fmt.Errorf("tax class %q is unknown", class)
+	}
+
+	product.TaxClass = normalizedClass
+
+	return nil
 }
 
-// ProductPrice is a value object
-type ProductPrice struct {
-	Value    float64
-	Currency string
+// SetStockPolicy rejects a policy which allows fewer backordered units than the product already has
+func (product *Product) SetStockPolicy(policy StockPolicy) error {
+	err := policy.Validate()
+	if err != nil {
+		return err
+	}
+
+	if product.Stock < -policy.MaxBackorder {
+		return fmt.Errorf("product %s has %d backordered units", product.ID, -product.Stock)
+	}
+
+	product.StockPolicy = policy
+
+	return nil
+}
+
+// SellableStock is the stock plus the units which can still be backordered
+func (product *Product) SellableStock() int {
+	return product.Stock + product.StockPolicy.MaxBackorder
+}
+
+// Availability is derived from the stock, so the catalog does not reveal the exact stock
+type Availability string
+
+const (
+	AvailabilityInStock    Availability = "in_stock"
+	AvailabilityLowStock   Availability = "low_stock"
+	AvailabilityOutOfStock Availability = "out_of_stock"
+	// AvailabilityBackorder is shown for products which are out of stock but can be backordered
+	AvailabilityBackorder Availability = "backorder"
+	// AvailabilityPreorder is shown for products which are not released yet, regardless of their stock
+	AvailabilityPreorder Availability = "preorder"
+)
+
+// LowStockThreshold is the highest stock which is shown as low stock
+const LowStockThreshold = 5
+
+func (product *Product) Availability() Availability {
+	switch {
+	case product.StockPolicy.Type == StockPolicyTypePreorder && product.SellableStock() > 0:
+		return AvailabilityPreorder
+	case product.Stock <= 0 && product.SellableStock() > 0:
+		return AvailabilityBackorder
+	case product.Stock <= 0:
+		return AvailabilityOutOfStock
+	case product.Stock <= LowStockThreshold:
+		return AvailabilityLowStock
+	default:
+		return AvailabilityInStock
+	}
 }
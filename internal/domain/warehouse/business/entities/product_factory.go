@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"fmt"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+type ProductFactory interface {
+	NewProduct(id string, name string, price money.Money, stock int, taxClass tax.TaxClass) (*Product, error)
+}
+
+var _ ProductFactory = (*ProductFactoryImpl)(nil)
+
+type ProductFactoryImpl struct {
+}
+
+func NewProductFactory() ProductFactory {
+	return &ProductFactoryImpl{}
+}
+
+// NewProduct returns an active product, it validates the values with the same rules as the setters of the product
+func (factory *ProductFactoryImpl) NewProduct(id string, name string, price money.Money, stock int, taxClass tax.TaxClass) (*Product, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id is empty")
+	} else if stock < 0 {
+		return nil, fmt.Errorf("stock %d is negative", stock)
+	}
+
+	product := &Product{
+		ID:    id,
+		Stock: stock,
+	}
+
+	err := product.Rename(name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.SetPrice(price)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.SetTaxClass(taxClass)
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
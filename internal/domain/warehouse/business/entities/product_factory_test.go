@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_ProductFactory_NewProduct_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		id       string
+		name     string
+		price    money.Money
+		stock    int
+		taxClass tax.TaxClass
+	}{
+		"id is empty": {
+			name: "Product 5",
+		},
+		"name is empty": {
+			id: "A12345",
+		},
+		"stock -1 is negative": {
+			id:    "A12345",
+			name:  "Product 5",
+			stock: -1,
+		},
+		"price -15.99 EUR is negative": {
+			id:    "A12345",
+			name:  "Product 5",
+			price: money.New(-1599, "EUR"),
+		},
+		`tax class "luxury" is unknown`: {
+			id:       "A12345",
+			name:     "Product 5",
+			price:    money.New(1599, "EUR"),
+			taxClass: "luxury",
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			product, err := NewProductFactory().NewProduct(testCase.id, testCase.name, testCase.price, testCase.stock, testCase.taxClass)
+
+			require.EqualError(t, err, errorString)
+			require.Nil(t, product)
+		})
+	}
+}
+
+func Test_ProductFactory_NewProduct(t *testing.T) {
+	product, err := NewProductFactory().NewProduct("A12345", "Product 5", money.New(1599, "EUR"), 10, "")
+
+	require.NoError(t, err)
+	require.Equal(t, &Product{
+		ID:       "A12345",
+		Name:     "Product 5",
+		Price:    money.New(1599, "EUR"),
+		Stock:    10,
+		TaxClass: tax.TaxClassStandard,
+	}, product)
+	require.True(t, product.IsActive())
+}
@@ -1,9 +1,16 @@
 package entities
 
+import "context"
+
 //go:generate mockgen -source=product_repository.go -destination=product_repository_mock.go -package=entities
 
 type ProductRepository interface {
-	Find(id string) (*Product, error)
-	FindAll() []*Product
-	Save(product *Product)
+	Find(ctx context.Context, id string) (*Product, error)
+	// FindAll returns the products sorted by their id
+	FindAll(ctx context.Context) ([]*Product, error)
+	// Save inserts or replaces the product with the same id
+	Save(ctx context.Context, product *Product) error
 }
+
+// ProductResource is the resource of the domainerror.NotFoundError returned if a product does not exist
+const ProductResource = "product"
@@ -10,6 +10,7 @@
 package entities
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,42 +41,45 @@ func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
 }
 
 // Find mocks base method.
-func (m *MockProductRepository) Find(id string) (*Product, error) {
+func (m *MockProductRepository) Find(ctx context.Context, id string) (*Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Find", id)
+	ret := m.ctrl.Call(m, "Find", ctx, id)
 	ret0, _ := ret[0].(*Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Find indicates an expected call of Find.
-func (mr *MockProductRepositoryMockRecorder) Find(id any) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) Find(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockProductRepository)(nil).Find), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockProductRepository)(nil).Find), ctx, id)
 }
 
 // FindAll mocks base method.
-func (m *MockProductRepository) FindAll() []*Product {
+func (m *MockProductRepository) FindAll(ctx context.Context) ([]*Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
+	ret := m.ctrl.Call(m, "FindAll", ctx)
 	ret0, _ := ret[0].([]*Product)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockProductRepositoryMockRecorder) FindAll() *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductRepository)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductRepository)(nil).FindAll), ctx)
 }
 
 // Save mocks base method.
-func (m *MockProductRepository) Save(product *Product) {
+func (m *MockProductRepository) Save(ctx context.Context, product *Product) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Save", product)
+	ret := m.ctrl.Call(m, "Save", ctx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockProductRepositoryMockRecorder) Save(product any) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) Save(ctx, product any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockProductRepository)(nil).Save), product)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockProductRepository)(nil).Save), ctx, product)
 }
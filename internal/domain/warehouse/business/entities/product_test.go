@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_Product_Availability(t *testing.T) {
+	testCases := map[Availability][]int{
+		AvailabilityOutOfStock: {-1, 0},
+		AvailabilityLowStock:   {1, LowStockThreshold},
+		AvailabilityInStock:    {LowStockThreshold + 1, 100},
+	}
+
+	for availability, stocks := range testCases {
+		t.Run(string(availability), func(t *testing.T) {
+			for _, stock := range stocks {
+				product := &Product{ID: "A12345", Stock: stock}
+
+				require.Equal(t, availability, product.Availability(), "stock %d", stock)
+			}
+		})
+	}
+}
+
+func Test_Product_Setters_ReturnError(t *testing.T) {
+	product := &Product{ID: "A12345", Name: "Product 5", Price: money.New(1599, "EUR"), TaxClass: tax.TaxClassReduced}
+
+	require.EqualError(t, product.Rename(" "), "name is empty")
+	require.EqualError(t, product.SetPrice(money.New(-1, "EUR")), "price -0.01 EUR is negative")
+	require.EqualError(t, product.SetTaxClass("luxury"), `tax class "luxury" is unknown`)
+
+	// invalid values do not change the product
+	require.Equal(t, &Product{ID: "A12345", Name: "Product 5", Price: money.New(1599, "EUR"), TaxClass: tax.TaxClassReduced}, product)
+}
+
+func Test_Product_Deactivate(t *testing.T) {
+	product := &Product{ID: "A12345"}
+
+	require.True(t, product.IsActive())
+
+	product.Deactivate()
+
+	require.False(t, product.IsActive())
+}
+
+func Test_Product_Availability_StockPolicy(t *testing.T) {
+	backorder := StockPolicy{Type: StockPolicyTypeBackorder, MaxBackorder: 5}
+	preorder := StockPolicy{Type: StockPolicyTypePreorder, RestockDate: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), MaxBackorder: 5}
+
+	require.Equal(t, AvailabilityBackorder, (&Product{Stock: 0, StockPolicy: backorder}).Availability())
+	require.Equal(t, AvailabilityBackorder, (&Product{Stock: -4, StockPolicy: backorder}).Availability())
+	require.Equal(t, AvailabilityInStock, (&Product{Stock: 10, StockPolicy: backorder}).Availability())
+	require.Equal(t, AvailabilityPreorder, (&Product{Stock: 10, StockPolicy: preorder}).Availability())
+
+	// every unit which can be backordered is sold
+	require.Equal(t, AvailabilityOutOfStock, (&Product{Stock: -5, StockPolicy: backorder}).Availability())
+	require.Equal(t, AvailabilityOutOfStock, (&Product{Stock: -5, StockPolicy: preorder}).Availability())
+}
+
+func Test_Product_SetStockPolicy(t *testing.T) {
+	product := &Product{ID: "A12344", Stock: -3, StockPolicy: StockPolicy{Type: StockPolicyTypeBackorder, MaxBackorder: 5}}
+
+	require.NoError(t, product.SetStockPolicy(StockPolicy{Type: StockPolicyTypeBackorder, MaxBackorder: 3}))
+	require.Equal(t, 0, product.SellableStock())
+
+	require.EqualError(t, product.SetStockPolicy(StockPolicy{Type: StockPolicyTypeBackorder, MaxBackorder: 2}), "product A12344 has 3 backordered units")
+	require.EqualError(t, product.SetStockPolicy(StockPolicy{}), "product A12344 has 3 backordered units")
+	require.EqualError(t, product.SetStockPolicy(StockPolicy{Type: StockPolicyTypeBackorder}), "max backorder must be greater than 0")
+
+	// invalid policies do not change the product
+	require.Equal(t, StockPolicy{Type: StockPolicyTypeBackorder, MaxBackorder: 3}, product.StockPolicy)
+}
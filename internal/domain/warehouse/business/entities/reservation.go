@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// Reservation holds units of a product for the basket of a holder until it expires
+type Reservation struct {
+	ProductID string
+	// HolderID is the user id of the basket owner, every basket belongs to exactly one user
+	HolderID  string
+	Count     int
+	ExpiresAt time.Time
+}
+
+func (reservation *Reservation) GetProductID() string {
+	return reservation.ProductID
+}
+
+func (reservation *Reservation) GetHolderID() string {
+	return reservation.HolderID
+}
+
+func (reservation *Reservation) GetCount() int {
+	return reservation.Count
+}
+
+func (reservation *Reservation) GetExpiresAt() time.Time {
+	return reservation.ExpiresAt
+}
+
+// IsActive returns false if the reservation is expired and its units are available again
+func (reservation *Reservation) IsActive(now time.Time) bool {
+	return now.Before(reservation.ExpiresAt)
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"context"
+
+	"time"
+)
+
+//go:generate mockgen -source=reservation_repository.go -destination=reservation_repository_mock.go -package=entities
+
+// ReservationRepository stores at most one reservation per holder and product
+type ReservationRepository interface {
+	Find(ctx context.Context, holderID string, productID string) (*Reservation, error)
+	FindByProductId(ctx context.Context, productID string) ([]*Reservation, error)
+	FindByHolderId(ctx context.Context, holderID string) ([]*Reservation, error)
+	Save(ctx context.Context, reservation *Reservation) error
+	Delete(ctx context.Context, holderID string, productID string) error
+	// DeleteExpired deletes the reservations which are not active anymore and returns them
+	DeleteExpired(ctx context.Context, now time.Time) ([]*Reservation, error)
+}
+
+// ReservationResource is the resource of the domainerror.NotFoundError returned if a reservation does not exist
+const ReservationResource = "reservation"
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reservation_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reservation_repository.go -destination=reservation_repository_mock.go -package=entities
+//
+
+// Package entities is a generated GoMock package.
+package entities
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReservationRepository is a mock of ReservationRepository interface.
+type MockReservationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReservationRepositoryMockRecorder is the mock recorder for MockReservationRepository.
+type MockReservationRepositoryMockRecorder struct {
+	mock *MockReservationRepository
+}
+
+// NewMockReservationRepository creates a new mock instance.
+func NewMockReservationRepository(ctrl *gomock.Controller) *MockReservationRepository {
+	mock := &MockReservationRepository{ctrl: ctrl}
+	mock.recorder = &MockReservationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationRepository) EXPECT() *MockReservationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockReservationRepository) Delete(ctx context.Context, holderID, productID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, holderID, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReservationRepositoryMockRecorder) Delete(ctx, holderID, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReservationRepository)(nil).Delete), ctx, holderID, productID)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockReservationRepository) DeleteExpired(ctx context.Context, now time.Time) ([]*Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].([]*Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockReservationRepositoryMockRecorder) DeleteExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockReservationRepository)(nil).DeleteExpired), ctx, now)
+}
+
+// Find mocks base method.
+func (m *MockReservationRepository) Find(ctx context.Context, holderID, productID string) (*Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, holderID, productID)
+	ret0, _ := ret[0].(*Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockReservationRepositoryMockRecorder) Find(ctx, holderID, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockReservationRepository)(nil).Find), ctx, holderID, productID)
+}
+
+// FindByHolderId mocks base method.
+func (m *MockReservationRepository) FindByHolderId(ctx context.Context, holderID string) ([]*Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHolderId", ctx, holderID)
+	ret0, _ := ret[0].([]*Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHolderId indicates an expected call of FindByHolderId.
+func (mr *MockReservationRepositoryMockRecorder) FindByHolderId(ctx, holderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHolderId", reflect.TypeOf((*MockReservationRepository)(nil).FindByHolderId), ctx, holderID)
+}
+
+// FindByProductId mocks base method.
+func (m *MockReservationRepository) FindByProductId(ctx context.Context, productID string) ([]*Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProductId", ctx, productID)
+	ret0, _ := ret[0].([]*Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProductId indicates an expected call of FindByProductId.
+func (mr *MockReservationRepositoryMockRecorder) FindByProductId(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProductId", reflect.TypeOf((*MockReservationRepository)(nil).FindByProductId), ctx, productID)
+}
+
+// Save mocks base method.
+func (m *MockReservationRepository) Save(ctx context.Context, reservation *Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, reservation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockReservationRepositoryMockRecorder) Save(ctx, reservation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockReservationRepository)(nil).Save), ctx, reservation)
+}
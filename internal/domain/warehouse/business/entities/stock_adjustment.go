@@ -0,0 +1,41 @@
+package entities
+
+import "fmt"
+
+// StockAdjustmentReason explains a manual change of the stock
+type StockAdjustmentReason string
+
+const (
+	// StockAdjustmentReasonReceived adds units delivered by a supplier
+	StockAdjustmentReasonReceived StockAdjustmentReason = "received"
+	// StockAdjustmentReasonReturned adds units returned by a customer
+	StockAdjustmentReasonReturned StockAdjustmentReason = "returned"
+	// StockAdjustmentReasonDamaged removes units which cannot be sold anymore
+	StockAdjustmentReasonDamaged StockAdjustmentReason = "damaged"
+	// StockAdjustmentReasonLost removes units which cannot be found
+	StockAdjustmentReasonLost StockAdjustmentReason = "lost"
+	// StockAdjustmentReasonCorrection adds or removes units after counting the stock
+	StockAdjustmentReasonCorrection StockAdjustmentReason = "correction"
+)
+
+func (reason StockAdjustmentReason) validate(count int) error {
+	if count == 0 {
+		return fmt.Errorf("count must not be 0")
+	}
+
+	switch reason {
+	case StockAdjustmentReasonReceived, StockAdjustmentReasonReturned:
+		if count < 0 {
+			return fmt.Errorf("reason %s only adds units", reason)
+		}
+	case StockAdjustmentReasonDamaged, StockAdjustmentReasonLost:
+		if count > 0 {
+			return fmt.Errorf("reason %s only removes units", reason)
+		}
+	case StockAdjustmentReasonCorrection:
+	default:
+		return fmt.Errorf("reason %q is unknown (must be received, returned, damaged, lost or correction)", reason)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+// AllocationItem is a product and the units which have to be shipped
+type AllocationItem struct {
+	ProductID string
+	Count     int
+}
+
+// Allocation is the count of a product which is shipped from a location
+type Allocation struct {
+	ProductID  string
+	LocationID string
+	Count      int
+}
+
+// LocationStock is the stock of a product per location id
+type LocationStock map[string]map[string]int
+
+const (
+	// AllocationStrategyNearest ships every product from the nearest locations which have it in stock
+	AllocationStrategyNearest = "nearest"
+	// AllocationStrategyFewestSplits ships from as few locations as possible, the nearest location wins a tie
+	AllocationStrategyFewestSplits = "fewest-splits"
+)
+
+// AllocationStrategy picks the locations which ship the items
+type AllocationStrategy interface {
+	// Allocate splits the items into allocations of the locations, the locations are sorted by preference.
+	// It returns a domainerror.OutOfStockError if the locations together do not have enough units of an item.
+	Allocate(items []*AllocationItem, locations []*Location, stock LocationStock) ([]*Allocation, error)
+}
+
+// NewAllocationStrategy returns the strategy with the name AllocationStrategyNearest or AllocationStrategyFewestSplits
+func NewAllocationStrategy(name string) (AllocationStrategy, error) {
+	switch name {
+	case AllocationStrategyNearest:
+		return &NearestAllocationStrategy{}, nil
+	case AllocationStrategyFewestSplits:
+		return &FewestSplitsAllocationStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("allocation strategy %q is unknown", name)
+	}
+}
+
+var _ AllocationStrategy = (*NearestAllocationStrategy)(nil)
+
+type NearestAllocationStrategy struct{}
+
+func (strategy *NearestAllocationStrategy) Allocate(items []*AllocationItem, locations []*Location, stock LocationStock) ([]*Allocation, error) {
+	remaining, remainingErr := remainingCounts(items, locations, stock)
+	if remainingErr != nil {
+		return nil, remainingErr
+	}
+
+	allocations := make([]*Allocation, 0, len(items))
+	for _, location := range locations {
+		for _, item := range items {
+			count := min(remaining[item.ProductID], stock[item.ProductID][location.ID])
+			if count <= 0 {
+				continue
+			}
+
+			allocations = append(allocations, &Allocation{ProductID: item.ProductID, LocationID: location.ID, Count: count})
+			remaining[item.ProductID] -= count
+		}
+	}
+
+	return allocations, nil
+}
+
+var _ AllocationStrategy = (*FewestSplitsAllocationStrategy)(nil)
+
+// FewestSplitsAllocationStrategy picks the location which ships most of the remaining units until every unit is allocated.
+// The greedy choice does not always find the fewest locations, but it is good enough for a handful of locations.
+type FewestSplitsAllocationStrategy struct{}
+
+func (strategy *FewestSplitsAllocationStrategy) Allocate(items []*AllocationItem, locations []*Location, stock LocationStock) ([]*Allocation, error) {
+	remaining, remainingErr := remainingCounts(items, locations, stock)
+	if remainingErr != nil {
+		return nil, remainingErr
+	}
+
+	allocations := make([]*Allocation, 0, len(items))
+	used := make(map[string]bool, len(locations))
+	for {
+		var bestLocation *Location
+		bestUnits := 0
+		for _, location := range locations {
+			if used[location.ID] {
+				continue
+			}
+
+			units := 0
+			for productID, count := range remaining {
+				units += min(count, stock[productID][location.ID])
+			}
+
+			// the locations are sorted by preference, so a later location has to ship more units to win
+			if units > bestUnits {
+				bestLocation = location
+				bestUnits = units
+			}
+		}
+
+		if bestLocation == nil {
+			break
+		}
+		used[bestLocation.ID] = true
+
+		for _, item := range items {
+			count := min(remaining[item.ProductID], stock[item.ProductID][bestLocation.ID])
+			if count <= 0 {
+				continue
+			}
+
+			allocations = append(allocations, &Allocation{ProductID: item.ProductID, LocationID: bestLocation.ID, Count: count})
+			remaining[item.ProductID] -= count
+		}
+	}
+
+	return allocations, nil
+}
+
+// remainingCounts sums up the counts per product and checks that the stock of the locations is sufficient
+func remainingCounts(items []*AllocationItem, locations []*Location, stock LocationStock) (map[string]int, error) {
+	remaining := make(map[string]int, len(items))
+	for _, item := range items {
+		if item == nil {
+			return nil, fmt.Errorf("item is nil")
+		} else if item.Count <= 0 {
+			return nil, fmt.Errorf("count of product %s must be greater than 0", item.ProductID)
+		}
+
+		remaining[item.ProductID] += item.Count
+	}
+
+	for _, item := range items {
+		available := 0
+		for _, location := range locations {
+			available += max(stock[item.ProductID][location.ID], 0)
+		}
+
+		if available < remaining[item.ProductID] {
+			return nil, &domainerror.OutOfStockError{
+				ProductID: item.ProductID,
+				Available: available,
+				Requested: remaining[item.ProductID],
+			}
+		}
+	}
+
+	return remaining, nil
+}
@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+// testLocations are sorted by preference like the locations passed to a strategy
+var testLocations = []*Location{{ID: "berlin"}, {ID: "hamburg"}, {ID: "munich"}}
+
+func Test_NewAllocationStrategy_ReturnsError(t *testing.T) {
+	_, err := NewAllocationStrategy("cheapest")
+
+	require.ErrorContains(t, err, `allocation strategy "cheapest" is unknown`)
+}
+
+func Test_NearestAllocationStrategy(t *testing.T) {
+	strategy, err := NewAllocationStrategy(AllocationStrategyNearest)
+	require.NoError(t, err)
+
+	stock := LocationStock{
+		"A12341": {"berlin": 1, "hamburg": 5, "munich": 5},
+		"A12342": {"munich": 2},
+	}
+
+	allocations, err := strategy.Allocate([]*AllocationItem{{ProductID: "A12341", Count: 3}, {ProductID: "A12342", Count: 2}}, testLocations, stock)
+
+	require.NoError(t, err)
+	require.Equal(t, []*Allocation{
+		{ProductID: "A12341", LocationID: "berlin", Count: 1},
+		{ProductID: "A12341", LocationID: "hamburg", Count: 2},
+		{ProductID: "A12342", LocationID: "munich", Count: 2},
+	}, allocations)
+}
+
+func Test_FewestSplitsAllocationStrategy(t *testing.T) {
+	strategy, err := NewAllocationStrategy(AllocationStrategyFewestSplits)
+	require.NoError(t, err)
+
+	stock := LocationStock{
+		"A12341": {"berlin": 1, "hamburg": 5, "munich": 5},
+		"A12342": {"munich": 2},
+	}
+
+	// munich ships everything, although berlin and hamburg are preferred
+	allocations, err := strategy.Allocate([]*AllocationItem{{ProductID: "A12341", Count: 3}, {ProductID: "A12342", Count: 2}}, testLocations, stock)
+
+	require.NoError(t, err)
+	require.Equal(t, []*Allocation{
+		{ProductID: "A12341", LocationID: "munich", Count: 3},
+		{ProductID: "A12342", LocationID: "munich", Count: 2},
+	}, allocations)
+}
+
+func Test_FewestSplitsAllocationStrategy_PrefersNearestOnTie(t *testing.T) {
+	strategy := &FewestSplitsAllocationStrategy{}
+
+	stock := LocationStock{
+		"A12341": {"berlin": 2, "hamburg": 4, "munich": 4},
+	}
+
+	allocations, err := strategy.Allocate([]*AllocationItem{{ProductID: "A12341", Count: 6}}, testLocations, stock)
+
+	require.NoError(t, err)
+	require.Equal(t, []*Allocation{
+		{ProductID: "A12341", LocationID: "hamburg", Count: 4},
+		{ProductID: "A12341", LocationID: "berlin", Count: 2},
+	}, allocations)
+}
+
+func Test_AllocationStrategy_OutOfStock(t *testing.T) {
+	for _, name := range []string{AllocationStrategyNearest, AllocationStrategyFewestSplits} {
+		t.Run(name, func(t *testing.T) {
+			strategy, err := NewAllocationStrategy(name)
+			require.NoError(t, err)
+
+			// the stock of a location which is not passed cannot be allocated
+			stock := LocationStock{
+				"A12341": {"berlin": 1, "hamburg": 1, "frankfurt": 10},
+			}
+
+			allocations, err := strategy.Allocate([]*AllocationItem{{ProductID: "A12341", Count: 3}}, testLocations, stock)
+
+			require.Equal(t, &domainerror.OutOfStockError{ProductID: "A12341", Available: 2, Requested: 3}, err)
+			require.Nil(t, allocations)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// StockMovementType is the kind of change recorded by a stock movement
+type StockMovementType string
+
+const (
+	// StockMovementTypeReceipt adds units to the stock, e.g. the initial stock of a new product
+	StockMovementTypeReceipt StockMovementType = "receipt"
+	// StockMovementTypeSale removes the units of an order
+	StockMovementTypeSale StockMovementType = "sale"
+	// StockMovementTypeCancellation returns the units of a cancelled order or of a failed checkout
+	StockMovementTypeCancellation StockMovementType = "cancellation"
+	// StockMovementTypeReservation holds units for a basket, the stock itself is unchanged
+	StockMovementTypeReservation StockMovementType = "reservation"
+	// StockMovementTypeRelease gives reserved units back, the stock itself is unchanged
+	StockMovementTypeRelease StockMovementType = "release"
+	// StockMovementTypeAdjustment is a manual change of the stock with a StockAdjustmentReason
+	StockMovementTypeAdjustment StockMovementType = "adjustment"
+)
+
+// StockMovement is an entry of the stock ledger, it is never changed after it was recorded.
+// The stock and the reserved units of a product are the sums of its movements.
+type StockMovement struct {
+	ID        string
+	ProductID string
+	Type      StockMovementType
+	// Quantity is positive if units are added or reserved and negative if units are removed or released
+	Quantity int
+	// Reason is only set for adjustments
+	Reason StockAdjustmentReason
+	// LocationID is the location whose stock is changed, the stock of movements without location belongs to the DefaultLocationID.
+	// Reservations and releases have no location, because the units are reserved from the stock of all locations.
+	LocationID string
+	// Reference is the order id of sales and cancellations and the holder id of reservations and releases
+	Reference string
+	CreatedAt time.Time
+}
+
+// ChangesStock returns false for reservations and releases, they only change the reserved units
+func (movement *StockMovement) ChangesStock() bool {
+	return movement.Type != StockMovementTypeReservation && movement.Type != StockMovementTypeRelease
+}
+
+// Validate checks the quantity against the type, e.g. a sale cannot add units
+func (movement *StockMovement) Validate() error {
+	if movement.ProductID == "" {
+		return fmt.Errorf("product id is empty")
+	}
+
+	if movement.LocationID != "" && !movement.ChangesStock() {
+		return fmt.Errorf("location id is only allowed for movements which change the stock")
+	}
+
+	if movement.Type == StockMovementTypeAdjustment {
+		return movement.Reason.validate(movement.Quantity)
+	} else if movement.Reason != "" {
+		return fmt.Errorf("reason is only allowed for adjustments")
+	}
+
+	switch movement.Type {
+	case StockMovementTypeReceipt, StockMovementTypeCancellation, StockMovementTypeReservation:
+		if movement.Quantity <= 0 {
+			return fmt.Errorf("quantity of a %s must be positive", movement.Type)
+		}
+	case StockMovementTypeSale, StockMovementTypeRelease:
+		if movement.Quantity >= 0 {
+			return fmt.Errorf("quantity of a %s must be negative", movement.Type)
+		}
+	default:
+		return fmt.Errorf("type %q is unknown", movement.Type)
+	}
+
+	return nil
+}
+
+// StockBalance sums up stock movements
+type StockBalance struct {
+	Stock    int
+	Reserved int
+	// Movements counts the movements, a product without movements was stored before the ledger existed
+	Movements int
+	// Locations is the stock per location, Stock is its sum
+	Locations map[string]int
+}
+
+func (balance *StockBalance) Add(movement *StockMovement) {
+	balance.AddSum(movement.Type, movement.LocationID, movement.Quantity, 1)
+}
+
+// AddSum adds the summed up quantity of movements of the same type and location,
+// so the drivers can sum up the movements in the database
+func (balance *StockBalance) AddSum(movementType StockMovementType, locationID string, quantity int, movements int) {
+	movement := &StockMovement{Type: movementType}
+	if movement.ChangesStock() {
+		if locationID == "" {
+			locationID = DefaultLocationID
+		}
+		if balance.Locations == nil {
+			balance.Locations = make(map[string]int)
+		}
+
+		balance.Stock += quantity
+		balance.Locations[locationID] += quantity
+	} else {
+		balance.Reserved += quantity
+	}
+
+	balance.Movements += movements
+}
@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=stock_movement_repository.go -destination=stock_movement_repository_mock.go -package=entities
+
+// StockMovementRepository is the stock ledger, it only appends movements and never changes or deletes them
+type StockMovementRepository interface {
+	// Append stores a new movement and assigns an id if it has none
+	Append(ctx context.Context, movement *StockMovement) error
+	// FindByProductId returns the movements of the product created in [from, to), oldest first
+	FindByProductId(ctx context.Context, productID string, from time.Time, to time.Time) ([]*StockMovement, error)
+	// Balance sums up the movements of the product created before the given time, the zero time sums up all movements
+	Balance(ctx context.Context, productID string, before time.Time) (*StockBalance, error)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stock_movement_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=stock_movement_repository.go -destination=stock_movement_repository_mock.go -package=entities
+//
+
+// Package entities is a generated GoMock package.
+package entities
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStockMovementRepository is a mock of StockMovementRepository interface.
+type MockStockMovementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockMovementRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStockMovementRepositoryMockRecorder is the mock recorder for MockStockMovementRepository.
+type MockStockMovementRepositoryMockRecorder struct {
+	mock *MockStockMovementRepository
+}
+
+// NewMockStockMovementRepository creates a new mock instance.
+func NewMockStockMovementRepository(ctrl *gomock.Controller) *MockStockMovementRepository {
+	mock := &MockStockMovementRepository{ctrl: ctrl}
+	mock.recorder = &MockStockMovementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockMovementRepository) EXPECT() *MockStockMovementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockStockMovementRepository) Append(ctx context.Context, movement *StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockStockMovementRepositoryMockRecorder) Append(ctx, movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockStockMovementRepository)(nil).Append), ctx, movement)
+}
+
+// Balance mocks base method.
+func (m *MockStockMovementRepository) Balance(ctx context.Context, productID string, before time.Time) (*StockBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", ctx, productID, before)
+	ret0, _ := ret[0].(*StockBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance.
+func (mr *MockStockMovementRepositoryMockRecorder) Balance(ctx, productID, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockStockMovementRepository)(nil).Balance), ctx, productID, before)
+}
+
+// FindByProductId mocks base method.
+func (m *MockStockMovementRepository) FindByProductId(ctx context.Context, productID string, from, to time.Time) ([]*StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProductId", ctx, productID, from, to)
+	ret0, _ := ret[0].([]*StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProductId indicates an expected call of FindByProductId.
+func (mr *MockStockMovementRepositoryMockRecorder) FindByProductId(ctx, productID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProductId", reflect.TypeOf((*MockStockMovementRepository)(nil).FindByProductId), ctx, productID, from, to)
+}
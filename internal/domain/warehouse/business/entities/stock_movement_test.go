@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StockMovement_Validate(t *testing.T) {
+	testCases := map[string]*StockMovement{
+		"receipt":              {ProductID: "A12345", Type: StockMovementTypeReceipt, Quantity: 5},
+		"sale":                 {ProductID: "A12345", Type: StockMovementTypeSale, Quantity: -2},
+		"cancellation":         {ProductID: "A12345", Type: StockMovementTypeCancellation, Quantity: 2},
+		"reservation":          {ProductID: "A12345", Type: StockMovementTypeReservation, Quantity: 1},
+		"release":              {ProductID: "A12345", Type: StockMovementTypeRelease, Quantity: -1},
+		"adjustment received":  {ProductID: "A12345", Type: StockMovementTypeAdjustment, Quantity: 5, Reason: StockAdjustmentReasonReceived},
+		"adjustment returned":  {ProductID: "A12345", Type: StockMovementTypeAdjustment, Quantity: 1, Reason: StockAdjustmentReasonReturned},
+		"adjustment damaged":   {ProductID: "A12345", Type: StockMovementTypeAdjustment, Quantity: -1, Reason: StockAdjustmentReasonDamaged},
+		"adjustment lost":      {ProductID: "A12345", Type: StockMovementTypeAdjustment, Quantity: -3, Reason: StockAdjustmentReasonLost},
+		"correction adds":      {ProductID: "A12345", Type: StockMovementTypeAdjustment, Quantity: 2, Reason: StockAdjustmentReasonCorrection},
+		"correction subtracts": {ProductID: "A12345", Type: StockMovementTypeAdjustment, Quantity: -2, Reason: StockAdjustmentReasonCorrection},
+		"sale at location":     {ProductID: "A12345", Type: StockMovementTypeSale, Quantity: -2, LocationID: "hamburg"},
+	}
+
+	for name, movement := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, movement.Validate())
+		})
+	}
+}
+
+func Test_StockMovement_Validate_ReturnsError(t *testing.T) {
+	testCases := map[string]*StockMovement{
+		"product id is empty":                                              {Type: StockMovementTypeReceipt, Quantity: 5},
+		"quantity of a receipt must be positive":                           {ProductID: "A12345", Type: StockMovementTypeReceipt, Quantity: 0},
+		"quantity of a sale must be negative":                              {ProductID: "A12345", Type: StockMovementTypeSale, Quantity: 2},
+		"quantity of a release must be negative":                           {ProductID: "A12345", Type: StockMovementTypeRelease, Quantity: 1},
+		"reason is only allowed for adjustments":                           {ProductID: "A12345", Type: StockMovementTypeReceipt, Quantity: 5, Reason: StockAdjustmentReasonReceived},
+		`type "theft" is unknown`:                                          {ProductID: "A12345", Type: "theft", Quantity: -1},
+		"count must not be 0":                                              {ProductID: "A12345", Type: StockMovementTypeAdjustment, Reason: StockAdjustmentReasonCorrection},
+		"reason received only adds units":                                  {ProductID: "A12345", Type: StockMovementTypeAdjustment, Quantity: -1, Reason: StockAdjustmentReasonReceived},
+		"reason damaged only removes units":                                {ProductID: "A12345", Type: StockMovementTypeAdjustment, Quantity: 1, Reason: StockAdjustmentReasonDamaged},
+		`reason "stolen" is unknown`:                                       {ProductID: "A12345", Type: StockMovementTypeAdjustment, Quantity: -1, Reason: "stolen"},
+		"location id is only allowed for movements which change the stock": {ProductID: "A12345", Type: StockMovementTypeReservation, Quantity: 1, LocationID: "hamburg"},
+	}
+
+	for errorString, movement := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			require.ErrorContains(t, movement.Validate(), errorString)
+		})
+	}
+}
+
+func Test_StockBalance_Add(t *testing.T) {
+	balance := &StockBalance{}
+
+	for _, movement := range []*StockMovement{
+		{Type: StockMovementTypeReceipt, Quantity: 10},
+		{Type: StockMovementTypeReservation, Quantity: 3},
+		{Type: StockMovementTypeRelease, Quantity: -3},
+		{Type: StockMovementTypeReservation, Quantity: 2},
+		{Type: StockMovementTypeSale, Quantity: -2},
+		{Type: StockMovementTypeAdjustment, Quantity: -1, Reason: StockAdjustmentReasonDamaged},
+		{Type: StockMovementTypeReceipt, Quantity: 4, LocationID: "hamburg"},
+		{Type: StockMovementTypeSale, Quantity: -1, LocationID: "hamburg"},
+	} {
+		balance.Add(movement)
+	}
+
+	// the movements without location belong to the default location
+	require.Equal(t, &StockBalance{Stock: 10, Reserved: 2, Movements: 8, Locations: map[string]int{DefaultLocationID: 7, "hamburg": 3}}, balance)
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// StockPolicyType decides whether a product is sold beyond its stock
+type StockPolicyType string
+
+const (
+	// StockPolicyTypeNone only sells the stock, it is the policy of the products without stock policy
+	StockPolicyTypeNone StockPolicyType = ""
+	// StockPolicyTypeBackorder sells a product which is temporarily out of stock, the units are shipped after the restock
+	StockPolicyTypeBackorder StockPolicyType = "backorder"
+	// StockPolicyTypePreorder sells a product before its release, all units are shipped on the release date
+	StockPolicyTypePreorder StockPolicyType = "preorder"
+)
+
+// StockPolicy allows selling up to MaxBackorder units more than the stock of a product.
+// The backordered units are sold from the DefaultLocationID, so its stock becomes negative until the restock.
+type StockPolicy struct {
+	Type StockPolicyType
+	// RestockDate is the expected date of the next receipt, it is the release date of a pre-order
+	RestockDate time.Time
+	// MaxBackorder is the maximum of units sold without stock, the backordered units of all orders count
+	MaxBackorder int
+}
+
+func (policy StockPolicy) Validate() error {
+	switch policy.Type {
+	case StockPolicyTypeNone:
+		if policy.MaxBackorder != 0 || !policy.RestockDate.IsZero() {
+			return fmt.Errorf("restock date and max backorder are only allowed for a backorder or preorder policy")
+		}
+		return nil
+	case StockPolicyTypeBackorder:
+	case StockPolicyTypePreorder:
+		if policy.RestockDate.IsZero() {
+			return fmt.Errorf("restock date is required for a preorder policy")
+		}
+	default:
+		return fmt.Errorf("stock policy %q is unknown (must be backorder or preorder)", policy.Type)
+	}
+
+	if policy.MaxBackorder <= 0 {
+		return fmt.Errorf("max backorder must be greater than 0")
+	}
+
+	return nil
+}
+
+func (policy StockPolicy) AllowsBackorder() bool {
+	return policy.Type != StockPolicyTypeNone
+}
+
+// Equal compares the restock dates as instants, so the time zone of a stored date does not matter
+func (policy StockPolicy) Equal(other StockPolicy) bool {
+	return policy.Type == other.Type && policy.MaxBackorder == other.MaxBackorder && policy.RestockDate.Equal(other.RestockDate)
+}
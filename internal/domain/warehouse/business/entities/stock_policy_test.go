@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StockPolicy_Validate(t *testing.T) {
+	restockDate := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, StockPolicy{}.Validate())
+	require.NoError(t, StockPolicy{Type: StockPolicyTypeBackorder, MaxBackorder: 10}.Validate())
+	require.NoError(t, StockPolicy{Type: StockPolicyTypeBackorder, RestockDate: restockDate, MaxBackorder: 10}.Validate())
+	require.NoError(t, StockPolicy{Type: StockPolicyTypePreorder, RestockDate: restockDate, MaxBackorder: 10}.Validate())
+}
+
+func Test_StockPolicy_Validate_ReturnsError(t *testing.T) {
+	restockDate := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]StockPolicy{
+		"restock date and max backorder are only allowed for a backorder or preorder policy": {MaxBackorder: 10},
+		`stock policy "sometimes" is unknown`:                                                {Type: "sometimes", MaxBackorder: 10},
+		"restock date is required for a preorder policy":                                     {Type: StockPolicyTypePreorder, MaxBackorder: 10},
+		"max backorder must be greater than 0":                                               {Type: StockPolicyTypeBackorder, RestockDate: restockDate},
+	}
+
+	for errorString, policy := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			require.ErrorContains(t, policy.Validate(), errorString)
+		})
+	}
+}
+
+func Test_StockPolicy_Equal(t *testing.T) {
+	policy := StockPolicy{Type: StockPolicyTypeBackorder, RestockDate: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), MaxBackorder: 10}
+
+	otherZone := policy
+	otherZone.RestockDate = policy.RestockDate.In(time.FixedZone("CET", 3600))
+
+	require.True(t, policy.Equal(otherZone))
+	require.False(t, policy.Equal(StockPolicy{Type: StockPolicyTypeBackorder, MaxBackorder: 10}))
+}
@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type AdjustProductStockUseCaseInput struct {
+	ProductID string
+	// Count is added to the stock, a negative count removes units
+	Count int
+	// Reason is received, returned, damaged, lost or correction
+	Reason string
+	// LocationID is optional, without location the stock of the default location is adjusted
+	LocationID string
+}
+
+type AdjustProductStockUseCaseOutput struct {
+	Product *dto.AdminProductDTO
+}
+
+// AdjustProductStockUseCase records an adjustment in the stock ledger instead of replacing the stock,
+// so units sold while the admin counted the stock are not lost
+type AdjustProductStockUseCase interface {
+	Execute(ctx context.Context, input *AdjustProductStockUseCaseInput) (*AdjustProductStockUseCaseOutput, error)
+}
+
+func NewAdjustProductStockUseCaseImpl(productOutputService helper.ProductOutputService, productRepository warehouse.ProductRepository, stockLedgerService helper.StockLedgerService) AdjustProductStockUseCase {
+	return &AdjustProductStockUseCaseImpl{
+		productOutputService: productOutputService,
+		productRepository:    productRepository,
+		stockLedgerService:   stockLedgerService,
+	}
+}
+
+var _ AdjustProductStockUseCase = (*AdjustProductStockUseCaseImpl)(nil)
+
+type AdjustProductStockUseCaseImpl struct {
+	productOutputService helper.ProductOutputService
+	productRepository    warehouse.ProductRepository
+	stockLedgerService   helper.StockLedgerService
+}
+
+func (useCase *AdjustProductStockUseCaseImpl) validate(input *AdjustProductStockUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.ProductID == "" {
+		return fmt.Errorf("input parameter ProductID is empty")
+	} else if input.Reason == "" {
+		return fmt.Errorf("input parameter Reason is empty")
+	}
+
+	return nil
+}
+
+func (useCase *AdjustProductStockUseCaseImpl) Execute(ctx context.Context, input *AdjustProductStockUseCaseInput) (*AdjustProductStockUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	movement := &warehouse.StockMovement{
+		ProductID:  input.ProductID,
+		Type:       warehouse.StockMovementTypeAdjustment,
+		Quantity:   input.Count,
+		Reason:     warehouse.StockAdjustmentReason(input.Reason),
+		LocationID: input.LocationID,
+	}
+
+	movementErr := movement.Validate()
+	if movementErr != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", movementErr)
+	}
+
+	recordErr := useCase.stockLedgerService.Record(ctx, movement)
+	if recordErr != nil {
+		return nil, recordErr
+	}
+
+	product, productRepositoryErr := useCase.productRepository.Find(ctx, input.ProductID)
+	if productRepositoryErr != nil {
+		return nil, productRepositoryErr
+	}
+
+	productDTO, productOutputServiceErr := useCase.productOutputService.CreateAdminProductDTO(product)
+	if productOutputServiceErr != nil {
+		return nil, productOutputServiceErr
+	}
+
+	output := &AdjustProductStockUseCaseOutput{
+		Product: productDTO,
+	}
+
+	return output, nil
+}
@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_AdjustProductStockUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stockLedgerServiceMock := helper.NewMockStockLedgerService(ctrl)
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &warehouse.StockMovement{
+		ProductID: "A1", Type: warehouse.StockMovementTypeAdjustment, Quantity: -2, Reason: warehouse.StockAdjustmentReasonDamaged, LocationID: "hamburg",
+	}).Return(nil)
+
+	// the ledger has changed the stock of the product
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(&warehouse.Product{ID: "A1", Name: "Book", Price: money.New(999, "EUR"), Stock: 1}, nil)
+
+	useCase := NewAdjustProductStockUseCaseImpl(helper.NewProductOutputService(), productRepositoryMock, stockLedgerServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &AdjustProductStockUseCaseInput{ProductID: "A1", Count: -2, Reason: "damaged", LocationID: "hamburg"})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, output.Product.Stock)
+}
+
+func Test_AdjustProductStockUseCase_OutOfStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stockLedgerServiceMock := helper.NewMockStockLedgerService(ctrl)
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), gomock.Any()).Return(&domainerror.OutOfStockError{ProductID: "A1", Available: 3, Requested: 4})
+
+	useCase := NewAdjustProductStockUseCaseImpl(helper.NewProductOutputService(), warehouse.NewMockProductRepository(ctrl), stockLedgerServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &AdjustProductStockUseCaseInput{ProductID: "A1", Count: -4, Reason: "lost"})
+
+	require.ErrorAs(t, err, new(*domainerror.OutOfStockError))
+	require.Nil(t, output)
+}
+
+func Test_AdjustProductStockUseCase_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *AdjustProductStockUseCaseInput
+	}{
+		"input parameter ProductID is empty": {
+			input: &AdjustProductStockUseCaseInput{},
+		},
+		"input parameter Reason is empty": {
+			input: &AdjustProductStockUseCaseInput{ProductID: "A1", Count: 1},
+		},
+		"count must not be 0": {
+			input: &AdjustProductStockUseCaseInput{ProductID: "A1", Reason: "correction"},
+		},
+		"reason received only adds units": {
+			input: &AdjustProductStockUseCaseInput{ProductID: "A1", Count: -1, Reason: "received"},
+		},
+		`reason "stolen" is unknown`: {
+			input: &AdjustProductStockUseCaseInput{ProductID: "A1", Count: -1, Reason: "stolen"},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := NewAdjustProductStockUseCaseImpl(helper.NewProductOutputService(), warehouse.NewMockProductRepository(ctrl), helper.NewMockStockLedgerService(ctrl))
+
+			output, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.ErrorAs(t, err, new(*domainerror.ValidationError))
+			require.ErrorContains(t, err, errorString)
+			require.Nil(t, output)
+		})
+	}
+}
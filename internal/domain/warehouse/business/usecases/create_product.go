@@ -0,0 +1,128 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+type CreateProductUseCaseInput struct {
+	ProductID string
+	Name      string
+	// Price is a decimal in major units, e.g. 11.99
+	Price    string
+	Currency string
+	Stock    int
+	// LocationID is optional, without location the stock is received at the default location
+	LocationID string
+	// TaxClass is optional, products without tax class use the standard class
+	TaxClass string
+}
+
+type CreateProductUseCaseOutput struct {
+	Product *dto.AdminProductDTO
+}
+
+// CreateProductUseCase adds a new product to the catalog, existing products are never overwritten
+type CreateProductUseCase interface {
+	Execute(ctx context.Context, input *CreateProductUseCaseInput) (*CreateProductUseCaseOutput, error)
+}
+
+func NewCreateProductUseCaseImpl(productOutputService helper.ProductOutputService, productFactory warehouse.ProductFactory, productRepository warehouse.ProductRepository, stockLedgerService helper.StockLedgerService) CreateProductUseCase {
+	return &CreateProductUseCaseImpl{
+		productOutputService: productOutputService,
+		productFactory:       productFactory,
+		productRepository:    productRepository,
+		stockLedgerService:   stockLedgerService,
+	}
+}
+
+var _ CreateProductUseCase = (*CreateProductUseCaseImpl)(nil)
+
+type CreateProductUseCaseImpl struct {
+	productOutputService helper.ProductOutputService
+	productFactory       warehouse.ProductFactory
+	productRepository    warehouse.ProductRepository
+	stockLedgerService   helper.StockLedgerService
+}
+
+func (useCase *CreateProductUseCaseImpl) validate(input *CreateProductUseCaseInput) (*warehouse.Product, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input is nil")
+	} else if input.ProductID == "" {
+		return nil, fmt.Errorf("input parameter ProductID is empty")
+	}
+
+	price, err := money.Parse(input.Price, input.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("input parameter Price is invalid: %w", err)
+	}
+
+	return useCase.productFactory.NewProduct(input.ProductID, input.Name, price, input.Stock, tax.TaxClass(input.TaxClass))
+}
+
+func (useCase *CreateProductUseCaseImpl) Execute(ctx context.Context, input *CreateProductUseCaseInput) (*CreateProductUseCaseOutput, error) {
+	product, err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	_, findErr := useCase.productRepository.Find(ctx, product.ID)
+	if findErr == nil {
+		return nil, domainerror.NewConflictError("product %s already exists", product.ID)
+	} else if !domainerror.IsNotFound(findErr, warehouse.ProductResource) {
+		return nil, findErr
+	}
+
+	saveErr := saveNewProduct(ctx, useCase.productRepository, useCase.stockLedgerService, product, input.LocationID)
+	if saveErr != nil {
+		return nil, saveErr
+	}
+
+	productDTO, productOutputServiceErr := useCase.productOutputService.CreateAdminProductDTO(product)
+	if productOutputServiceErr != nil {
+		return nil, productOutputServiceErr
+	}
+
+	output := &CreateProductUseCaseOutput{
+		Product: productDTO,
+	}
+
+	return output, nil
+}
+
+// saveNewProduct saves the product without stock and records its stock as receipt,
+// so even the initial stock of a product is derived from the ledger
+func saveNewProduct(ctx context.Context, productRepository warehouse.ProductRepository, stockLedgerService helper.StockLedgerService, product *warehouse.Product, locationID string) error {
+	stock := product.Stock
+	product.Stock = 0
+
+	saveErr := productRepository.Save(ctx, product)
+	if saveErr != nil {
+		return saveErr
+	}
+
+	if stock == 0 {
+		return nil
+	}
+
+	recordErr := stockLedgerService.Record(ctx, &warehouse.StockMovement{
+		ProductID:  product.ID,
+		Type:       warehouse.StockMovementTypeReceipt,
+		Quantity:   stock,
+		LocationID: locationID,
+	})
+	if recordErr != nil {
+		return recordErr
+	}
+
+	product.Stock = stock
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_CreateProductUseCase_NewCreateProductUseCaseImpl_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *CreateProductUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"input parameter ProductID is empty": {
+			input: &CreateProductUseCaseInput{},
+		},
+		"input parameter Price is invalid: invalid amount": {
+			input: &CreateProductUseCaseInput{ProductID: "A1", Name: "Book", Price: "abc", Currency: "EUR"},
+		},
+		"name is empty": {
+			input: &CreateProductUseCaseInput{ProductID: "A1", Name: " ", Price: "9.99", Currency: "EUR"},
+		},
+		"price -9.99 EUR is negative": {
+			input: &CreateProductUseCaseInput{ProductID: "A1", Name: "Book", Price: "-9.99", Currency: "EUR"},
+		},
+		"stock -1 is negative": {
+			input: &CreateProductUseCaseInput{ProductID: "A1", Name: "Book", Price: "9.99", Currency: "EUR", Stock: -1},
+		},
+		`tax class "luxury" is unknown`: {
+			input: &CreateProductUseCaseInput{ProductID: "A1", Name: "Book", Price: "9.99", Currency: "EUR", TaxClass: "luxury"},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+
+			useCase := NewCreateProductUseCaseImpl(helper.NewProductOutputService(), warehouse.NewProductFactory(), productRepositoryMock, helper.NewMockStockLedgerService(ctrl))
+
+			output, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.ErrorAs(t, err, new(*domainerror.ValidationError))
+			require.ErrorContains(t, err, errorString)
+			require.Nil(t, output)
+		})
+	}
+}
+
+func Test_CreateProductUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: "A1"})
+	productRepositoryMock.EXPECT().Save(gomock.Any(), &warehouse.Product{
+		ID:       "A1",
+		Name:     "Book",
+		Price:    money.New(999, "EUR"),
+		Stock:    0,
+		TaxClass: tax.TaxClassReduced,
+	}).Return(nil)
+
+	// the initial stock is a receipt
+	stockLedgerServiceMock := helper.NewMockStockLedgerService(ctrl)
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &warehouse.StockMovement{ProductID: "A1", Type: warehouse.StockMovementTypeReceipt, Quantity: 3}).Return(nil)
+
+	useCase := NewCreateProductUseCaseImpl(helper.NewProductOutputService(), warehouse.NewProductFactory(), productRepositoryMock, stockLedgerServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &CreateProductUseCaseInput{ProductID: "A1", Name: "Book", Price: "9.99", Currency: "EUR", Stock: 3, TaxClass: "reduced"})
+
+	require.NoError(t, err)
+	require.Equal(t, "A1", output.Product.ID)
+	require.Equal(t, "9.99", output.Product.Price.Value)
+	require.Equal(t, 3, output.Product.Stock)
+	require.Equal(t, string(warehouse.AvailabilityLowStock), output.Product.Availability)
+	require.False(t, output.Product.Deactivated)
+}
+
+func Test_CreateProductUseCase_AlreadyExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(&warehouse.Product{ID: "A1"}, nil)
+
+	useCase := NewCreateProductUseCaseImpl(helper.NewProductOutputService(), warehouse.NewProductFactory(), productRepositoryMock, helper.NewMockStockLedgerService(ctrl))
+
+	output, err := useCase.Execute(t.Context(), &CreateProductUseCaseInput{ProductID: "A1", Name: "Book", Price: "9.99", Currency: "EUR"})
+
+	require.ErrorAs(t, err, new(*domainerror.ConflictError))
+	require.EqualError(t, err, "product A1 already exists")
+	require.Nil(t, output)
+}
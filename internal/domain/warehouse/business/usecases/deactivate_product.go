@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type DeactivateProductUseCaseInput struct {
+	ProductID string
+}
+
+type DeactivateProductUseCaseOutput struct {
+	Product *dto.AdminProductDTO
+}
+
+// DeactivateProductUseCase stops selling a product, the product is kept for the baskets and orders containing it.
+// Deactivating a deactivated product again succeeds without saving it.
+type DeactivateProductUseCase interface {
+	Execute(ctx context.Context, input *DeactivateProductUseCaseInput) (*DeactivateProductUseCaseOutput, error)
+}
+
+func NewDeactivateProductUseCaseImpl(productOutputService helper.ProductOutputService, productRepository warehouse.ProductRepository, stockLedgerService helper.StockLedgerService) DeactivateProductUseCase {
+	return &DeactivateProductUseCaseImpl{
+		productOutputService: productOutputService,
+		productRepository:    productRepository,
+		stockLedgerService:   stockLedgerService,
+	}
+}
+
+var _ DeactivateProductUseCase = (*DeactivateProductUseCaseImpl)(nil)
+
+type DeactivateProductUseCaseImpl struct {
+	productOutputService helper.ProductOutputService
+	productRepository    warehouse.ProductRepository
+	// stockLedgerService saves the product, so a sale recorded meanwhile is not overwritten
+	stockLedgerService helper.StockLedgerService
+}
+
+func (useCase *DeactivateProductUseCaseImpl) validate(input *DeactivateProductUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.ProductID == "" {
+		return fmt.Errorf("input parameter ProductID is empty")
+	}
+
+	return nil
+}
+
+func (useCase *DeactivateProductUseCaseImpl) Execute(ctx context.Context, input *DeactivateProductUseCaseInput) (*DeactivateProductUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	product, productRepositoryErr := useCase.productRepository.Find(ctx, input.ProductID)
+	if productRepositoryErr != nil {
+		return nil, productRepositoryErr
+	}
+
+	if product.IsActive() {
+		var updateErr error
+		product, updateErr = useCase.stockLedgerService.UpdateProduct(ctx, input.ProductID, func(product *warehouse.Product) error {
+			product.Deactivate()
+
+			return nil
+		})
+		if updateErr != nil {
+			return nil, updateErr
+		}
+	}
+
+	productDTO, productOutputServiceErr := useCase.productOutputService.CreateAdminProductDTO(product)
+	if productOutputServiceErr != nil {
+		return nil, productOutputServiceErr
+	}
+
+	output := &DeactivateProductUseCaseOutput{
+		Product: productDTO,
+	}
+
+	return output, nil
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_DeactivateProductUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	product := &warehouse.Product{ID: "A1", Name: "Book", Price: money.New(999, "EUR"), Stock: 3}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(product, nil).Times(3)
+	// the stock ledger reads the product again before saving it, the second deactivation does not save the product again
+	productRepositoryMock.EXPECT().Save(gomock.Any(), product).Return(nil)
+
+	useCase := NewDeactivateProductUseCaseImpl(helper.NewProductOutputService(), productRepositoryMock, newTestStockLedgerService(t, ctrl, productRepositoryMock))
+
+	for range 2 {
+		output, err := useCase.Execute(t.Context(), &DeactivateProductUseCaseInput{ProductID: "A1"})
+
+		require.NoError(t, err)
+		require.True(t, output.Product.Deactivated)
+		require.Equal(t, 3, output.Product.Stock)
+	}
+}
+
+func Test_DeactivateProductUseCase_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: "A1"})
+
+	useCase := NewDeactivateProductUseCaseImpl(helper.NewProductOutputService(), productRepositoryMock, newTestStockLedgerService(t, ctrl, productRepositoryMock))
+
+	output, err := useCase.Execute(t.Context(), &DeactivateProductUseCaseInput{ProductID: "A1"})
+
+	require.True(t, domainerror.IsNotFound(err, warehouse.ProductResource))
+	require.Nil(t, output)
+}
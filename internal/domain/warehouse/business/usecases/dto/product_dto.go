@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// ProductDTO is a product of the catalog
+type ProductDTO struct {
+	ID       string
+	Name     string
+	Price    *ProductPrice
+	TaxClass string
+	// Availability is in_stock, low_stock, out_of_stock, backorder or preorder
+	Availability string
+	// RestockDate is the expected restock date or the release date of a product which can be backordered
+	RestockDate *time.Time
+}
+
+type ProductPrice struct {
+	Value    string
+	Currency string
+}
+
+// Page describes the slice of the matching products returned by a list
+type Page struct {
+	Number     int
+	Size       int
+	TotalItems int
+	TotalPages int
+}
+
+// AdminProductDTO is the product for the admins, it contains the stock and the deactivation hidden by the catalog
+type AdminProductDTO struct {
+	*ProductDTO
+	// Stock is negative by the backordered units
+	Stock       int
+	Deactivated bool
+	// StockPolicy is nil for products which are only sold from the stock
+	StockPolicy *StockPolicy
+}
+
+type StockPolicy struct {
+	// Type is backorder or preorder
+	Type         string
+	RestockDate  *time.Time
+	MaxBackorder int
+}
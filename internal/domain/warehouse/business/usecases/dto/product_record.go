@@ -0,0 +1,18 @@
+package dto
+
+// ProductRecord is a product as it is written in a fixture file
+type ProductRecord struct {
+	ID   string
+	Name string
+	// Price is an exact decimal string like "11.99" in major units of the currency
+	Price    string
+	Currency string
+	Stock    int
+	// TaxClass is optional, products without tax class use the standard class
+	TaxClass string
+	// StockPolicy is optional, it is backorder or preorder for products which are sold beyond their stock
+	StockPolicy string
+	// RestockDate is a date like "2026-12-01", it is required for pre-orders
+	RestockDate  string
+	MaxBackorder int
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// StockReportDTO contains the stock movements of a product in the time range [From, To)
+type StockReportDTO struct {
+	ProductID string
+	From      time.Time
+	To        time.Time
+	// Opening is the balance before the first movement of the range
+	Opening *StockBalanceDTO
+	// Closing is the balance after the last movement of the range
+	Closing *StockBalanceDTO
+	// Totals sums up the quantities of the movements per type
+	Totals    map[string]int
+	Movements []*StockMovementDTO
+}
+
+type StockBalanceDTO struct {
+	Stock    int
+	Reserved int
+	// Locations is the stock per location id
+	Locations map[string]int
+}
+
+type StockMovementDTO struct {
+	ID       string
+	Type     string
+	Quantity int
+	// Reason is only set for adjustments
+	Reason string
+	// LocationID is only set for movements which change the stock
+	LocationID string
+	Reference  string
+	CreatedAt  time.Time
+	// Stock is the stock after the movement
+	Stock int
+}
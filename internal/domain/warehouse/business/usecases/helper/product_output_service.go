@@ -0,0 +1,81 @@
+package helper
+
+import (
+	"fmt"
+	"time"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+)
+
+type ProductOutputService interface {
+	CreateProductDTO(product *entities.Product) (*dto.ProductDTO, error)
+	CreateAdminProductDTO(product *entities.Product) (*dto.AdminProductDTO, error)
+}
+
+var _ ProductOutputService = (*ProductOutputServiceImpl)(nil)
+
+type ProductOutputServiceImpl struct {
+}
+
+func NewProductOutputService() ProductOutputService {
+	return &ProductOutputServiceImpl{}
+}
+
+func (service *ProductOutputServiceImpl) CreateProductDTO(product *entities.Product) (*dto.ProductDTO, error) {
+	if product == nil {
+		return nil, fmt.Errorf("product is nil")
+	}
+
+	productDTO := &dto.ProductDTO{
+		ID:   product.ID,
+		Name: product.Name,
+		Price: &dto.ProductPrice{
+			Value:    product.Price.FormatAmount(),
+			Currency: product.Price.GetCurrency(),
+		},
+		TaxClass:     string(tax.NormalizeClass(product.TaxClass)),
+		Availability: string(product.Availability()),
+	}
+
+	if product.StockPolicy.AllowsBackorder() {
+		productDTO.RestockDate = restockDate(product.StockPolicy)
+	}
+
+	return productDTO, nil
+}
+
+func (service *ProductOutputServiceImpl) CreateAdminProductDTO(product *entities.Product) (*dto.AdminProductDTO, error) {
+	productDTO, err := service.CreateProductDTO(product)
+	if err != nil {
+		return nil, err
+	}
+
+	adminProductDTO := &dto.AdminProductDTO{
+		ProductDTO:  productDTO,
+		Stock:       product.Stock,
+		Deactivated: product.Deactivated,
+	}
+
+	if product.StockPolicy.AllowsBackorder() {
+		adminProductDTO.StockPolicy = &dto.StockPolicy{
+			Type:         string(product.StockPolicy.Type),
+			RestockDate:  restockDate(product.StockPolicy),
+			MaxBackorder: product.StockPolicy.MaxBackorder,
+		}
+	}
+
+	return adminProductDTO, nil
+}
+
+// restockDate returns nil if the policy has no restock date
+func restockDate(stockPolicy entities.StockPolicy) *time.Time {
+	if stockPolicy.RestockDate.IsZero() {
+		return nil
+	}
+
+	restockDate := stockPolicy.RestockDate
+
+	return &restockDate
+}
@@ -9,7 +9,7 @@ import (
 )
 
 const (
-	ProductPriceSimulatorWaitDuration = 10 * time.Second
+	DefaultProductPriceSimulatorWaitDuration = 10 * time.Second
 )
 
 // ProductPriceSimulatorBackgroundService will make price changes to demonstrate the difference between Basket and BasketDTO
@@ -21,21 +21,24 @@ type ProductPriceSimulatorBackgroundService interface {
 var _ ProductPriceSimulatorBackgroundService = (*ProductPriceSimulatorBackgroundServiceImpl)(nil)
 
 type ProductPriceSimulatorBackgroundServiceImpl struct {
-	ctx                          context.Context
+	cancel                       context.CancelFunc
 	started                      bool
 	syncMutex                    sync.Mutex
 	productPriceSimulatorService ProductPriceSimulatorService
+	waitDuration                 time.Duration
 }
 
-func NewProductPriceSimulatorBackgroundService(productPriceSimulatorService ProductPriceSimulatorService) (ProductPriceSimulatorBackgroundService, error) {
+func NewProductPriceSimulatorBackgroundService(productPriceSimulatorService ProductPriceSimulatorService, waitDuration time.Duration) (ProductPriceSimulatorBackgroundService, error) {
 	if productPriceSimulatorService == nil {
 		return nil, fmt.Errorf("productPriceSimulatorService is nil")
+	} else if waitDuration <= 0 {
+		return nil, fmt.Errorf("waitDuration must be greater than 0")
 	}
 
 	return &ProductPriceSimulatorBackgroundServiceImpl{
-		ctx:                          context.Background(),
 		started:                      false,
 		productPriceSimulatorService: productPriceSimulatorService,
+		waitDuration:                 waitDuration,
 	}, nil
 }
 
@@ -49,19 +52,23 @@ func (service *ProductPriceSimulatorBackgroundServiceImpl) Start() {
 	}
 
 	log.Println("ProductPriceSimulatorBackgroundService: Starting...")
-	go service.start()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	service.cancel = cancel
+
+	go service.start(ctx)
 	service.started = true
 }
 
-func (service *ProductPriceSimulatorBackgroundServiceImpl) start() {
+func (service *ProductPriceSimulatorBackgroundServiceImpl) start(ctx context.Context) {
 outerloop:
 	for {
-		service.productPriceSimulatorService.Execute()
+		service.productPriceSimulatorService.Execute(ctx)
 
 		select {
-		case <-service.ctx.Done():
+		case <-ctx.Done():
 			break outerloop
-		case <-time.After(ProductPriceSimulatorWaitDuration):
+		case <-time.After(service.waitDuration):
 		}
 	}
 }
@@ -75,5 +82,6 @@ func (service *ProductPriceSimulatorBackgroundServiceImpl) Stop() {
 	}
 
 	log.Println("ProductPriceSimulatorBackgroundService: Stopping...")
-	service.ctx.Done()
+	service.cancel()
+	service.started = false
 }
@@ -8,7 +8,15 @@ import (
 )
 
 func Test_ProductPriceSimulatorBackgroundServiceImpl_NewProductPriceSimulator_ReturnsError(t *testing.T) {
-	service, err := NewProductPriceSimulatorBackgroundService(nil)
+	service, err := NewProductPriceSimulatorBackgroundService(nil, DefaultProductPriceSimulatorWaitDuration)
+
+	require.Error(t, err)
+	require.Nil(t, service)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, err = NewProductPriceSimulatorBackgroundService(NewMockProductPriceSimulatorService(ctrl), 0)
 
 	require.Error(t, err)
 	require.Nil(t, service)
@@ -20,7 +28,7 @@ func Test_ProductPriceSimulatorBackgroundServiceImpl_NewProductPriceSimulator(t
 
 	mock := NewMockProductPriceSimulatorService(ctrl)
 
-	service, err := NewProductPriceSimulatorBackgroundService(mock)
+	service, err := NewProductPriceSimulatorBackgroundService(mock, DefaultProductPriceSimulatorWaitDuration)
 
 	require.NoError(t, err)
 	require.NotNil(t, service)
@@ -3,45 +3,70 @@ package helper
 //go:generate mockgen -source=product_price_simulator_service.go -destination=product_price_simulator_service_mock.go -package=helper
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math/rand"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
 )
 
 // ProductPriceSimulatorService will make price changes to demonstrate the difference between Basket and BasketDTO
 type ProductPriceSimulatorService interface {
-	Execute()
+	Execute(ctx context.Context)
 }
 
 var _ ProductPriceSimulatorService = (*ProductPriceSimulatorServiceImpl)(nil)
 
 type ProductPriceSimulatorServiceImpl struct {
-	productRepository entities.ProductRepository
+	productRepository  entities.ProductRepository
+	stockLedgerService StockLedgerService
 }
 
-func NewProductPriceSimulatorService(productRepository entities.ProductRepository) (ProductPriceSimulatorService, error) {
+func NewProductPriceSimulatorService(productRepository entities.ProductRepository, stockLedgerService StockLedgerService) (ProductPriceSimulatorService, error) {
 	if productRepository == nil {
 		return nil, fmt.Errorf("productRepository is nil")
+	} else if stockLedgerService == nil {
+		return nil, fmt.Errorf("stockLedgerService is nil")
 	}
 
 	return &ProductPriceSimulatorServiceImpl{
-		productRepository: productRepository,
+		productRepository:  productRepository,
+		stockLedgerService: stockLedgerService,
 	}, nil
 }
 
-func (service *ProductPriceSimulatorServiceImpl) Execute() {
+func (service *ProductPriceSimulatorServiceImpl) Execute(ctx context.Context) {
+	products, err := service.productRepository.FindAll(ctx)
+	if err != nil {
+		log.Printf("ProductPriceSimulatorService: Failed to find products: %v\n", err)
+		return
+	}
+
 	plus := rand.Intn(2) == 0
-	for _, product := range service.productRepository.FindAll() {
-		change := rand.Float64() * 0.10
-		oldPrice := product.Price.Value
-		if plus {
-			product.Price.Value += change
-		} else {
-			product.Price.Value -= change
+	for _, product := range products {
+		// the product is read again by the stock ledger, so the stock sold since FindAll is kept
+		_, updateErr := service.stockLedgerService.UpdateProduct(ctx, product.ID, func(product *entities.Product) error {
+			// change the price by 1 up to 10 minor units, e.g. cents
+			change := money.New(rand.Int63n(10)+1, product.Price.GetCurrency())
+			if !plus && product.Price.GetAmount() > change.GetAmount() {
+				change = change.Negate()
+			}
+
+			oldPrice := product.Price
+			newPrice, err := product.Price.Add(change)
+			if err != nil {
+				return err
+			}
+
+			product.Price = newPrice
+			log.Printf("ProductPriceSimulatorService: Updating Product %s price: %s (old price: %s)\n", product.ID, product.Price, oldPrice)
+
+			return nil
+		})
+		if updateErr != nil {
+			log.Printf("ProductPriceSimulatorService: Failed to update Product %s price: %v\n", product.ID, updateErr)
 		}
-		log.Printf("ProductPriceSimulatorService: Updating Product %s price: %f (old price: %f)\n", product.ID, product.Price.Value, oldPrice)
-		service.productRepository.Save(product)
 	}
 }
@@ -10,6 +10,7 @@
 package helper
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,13 +41,13 @@ func (m *MockProductPriceSimulatorService) EXPECT() *MockProductPriceSimulatorSe
 }
 
 // Execute mocks base method.
-func (m *MockProductPriceSimulatorService) Execute() {
+func (m *MockProductPriceSimulatorService) Execute(ctx context.Context) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Execute")
+	m.ctrl.Call(m, "Execute", ctx)
 }
 
 // Execute indicates an expected call of Execute.
-func (mr *MockProductPriceSimulatorServiceMockRecorder) Execute() *gomock.Call {
+func (mr *MockProductPriceSimulatorServiceMockRecorder) Execute(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockProductPriceSimulatorService)(nil).Execute))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockProductPriceSimulatorService)(nil).Execute), ctx)
 }
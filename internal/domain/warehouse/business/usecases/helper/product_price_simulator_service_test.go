@@ -1,18 +1,28 @@
 package helper
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
 )
 
 func Test_ProductPriceSimulatorServiceImpl_NewProductPriceSimulator(t *testing.T) {
-	service, err := NewProductPriceSimulatorService(nil)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, err := NewProductPriceSimulatorService(nil, NewMockStockLedgerService(ctrl))
 
-	require.Error(t, err)
+	require.ErrorContains(t, err, "productRepository is nil")
+	require.Nil(t, service)
+
+	service, err = NewProductPriceSimulatorService(entities.NewMockProductRepository(ctrl), nil)
+
+	require.ErrorContains(t, err, "stockLedgerService is nil")
 	require.Nil(t, service)
 }
 
@@ -25,25 +35,36 @@ func Test_ProductPriceSimulatorServiceImpl_Execute(t *testing.T) {
 			ID:    "A12345",
 			Name:  "Product A12345",
 			Stock: 10,
-			Price: &entities.ProductPrice{
-				Value:    13.37,
-				Currency: "EUR",
-			},
+			Price: money.New(1337, "EUR"),
 		},
 	}
 
 	mockProductRepository := entities.NewMockProductRepository(ctrl)
-	mockProductRepository.EXPECT().FindAll().Return(products).Times(1)
-	mockProductRepository.EXPECT().Save(products[0]).Return().Times(1)
+	mockProductRepository.EXPECT().FindAll(gomock.Any()).Return(products, nil).Times(1)
+
+	// a sale was recorded since FindAll, the simulator must not save the stale stock
+	storedProduct := &entities.Product{
+		ID:    "A12345",
+		Name:  "Product A12345",
+		Stock: 7,
+		Price: money.New(1337, "EUR"),
+	}
+
+	mockStockLedgerService := NewMockStockLedgerService(ctrl)
+	mockStockLedgerService.EXPECT().UpdateProduct(gomock.Any(), "A12345", gomock.Any()).DoAndReturn(func(ctx context.Context, productID string, update func(product *entities.Product) error) (*entities.Product, error) {
+		return storedProduct, update(storedProduct)
+	}).Times(1)
 
-	service, err := NewProductPriceSimulatorService(mockProductRepository)
+	service, err := NewProductPriceSimulatorService(mockProductRepository, mockStockLedgerService)
 
 	require.NoError(t, err)
 	require.NotNil(t, service)
 
-	oldPrice := products[0].Price.Value
+	oldPrice := storedProduct.Price
 
-	service.Execute()
+	service.Execute(t.Context())
 
-	require.NotEqual(t, oldPrice, products[0].Price.Value)
+	require.NotEqual(t, oldPrice, storedProduct.Price)
+	require.Equal(t, oldPrice.GetCurrency(), storedProduct.Price.GetCurrency())
+	require.Equal(t, 7, storedProduct.Stock)
 }
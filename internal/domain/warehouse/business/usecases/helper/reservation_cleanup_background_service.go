@@ -0,0 +1,91 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultReservationCleanupWaitDuration = time.Minute
+)
+
+// ReservationCleanupBackgroundService regularly deletes the expired reservations.
+// Expired reservations do not count against the available stock anyway, the cleanup only frees the storage.
+type ReservationCleanupBackgroundService interface {
+	Start()
+	Stop()
+}
+
+var _ ReservationCleanupBackgroundService = (*ReservationCleanupBackgroundServiceImpl)(nil)
+
+type ReservationCleanupBackgroundServiceImpl struct {
+	cancel                  context.CancelFunc
+	started                 bool
+	syncMutex               sync.Mutex
+	stockReservationService StockReservationService
+	waitDuration            time.Duration
+}
+
+func NewReservationCleanupBackgroundService(stockReservationService StockReservationService, waitDuration time.Duration) (ReservationCleanupBackgroundService, error) {
+	if stockReservationService == nil {
+		return nil, fmt.Errorf("stockReservationService is nil")
+	} else if waitDuration <= 0 {
+		return nil, fmt.Errorf("waitDuration must be greater than 0")
+	}
+
+	return &ReservationCleanupBackgroundServiceImpl{
+		started:                 false,
+		stockReservationService: stockReservationService,
+		waitDuration:            waitDuration,
+	}, nil
+}
+
+func (service *ReservationCleanupBackgroundServiceImpl) Start() {
+	service.syncMutex.Lock()
+	defer service.syncMutex.Unlock()
+
+	if service.started {
+		return
+	}
+
+	log.Println("ReservationCleanupBackgroundService: Starting...")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	service.cancel = cancel
+
+	go service.start(ctx)
+	service.started = true
+}
+
+func (service *ReservationCleanupBackgroundServiceImpl) start(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(service.waitDuration):
+		}
+
+		released, err := service.stockReservationService.ReleaseExpired(ctx)
+		if err != nil {
+			log.Printf("ReservationCleanupBackgroundService: %v", err)
+		} else if released > 0 {
+			log.Printf("ReservationCleanupBackgroundService: Released %d expired reservations", released)
+		}
+	}
+}
+
+func (service *ReservationCleanupBackgroundServiceImpl) Stop() {
+	service.syncMutex.Lock()
+	defer service.syncMutex.Unlock()
+
+	if !service.started {
+		return
+	}
+
+	log.Println("ReservationCleanupBackgroundService: Stopping...")
+	service.cancel()
+	service.started = false
+}
@@ -0,0 +1,40 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_ReservationCleanupBackgroundService_NewReservationCleanupBackgroundService_ReturnsError(t *testing.T) {
+	service, err := NewReservationCleanupBackgroundService(nil, DefaultReservationCleanupWaitDuration)
+
+	require.Error(t, err)
+	require.Nil(t, service)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, err = NewReservationCleanupBackgroundService(NewMockStockReservationService(ctrl), 0)
+
+	require.Error(t, err)
+	require.Nil(t, service)
+}
+
+func Test_ReservationCleanupBackgroundService_StartStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockStockReservationService(ctrl)
+
+	service, err := NewReservationCleanupBackgroundService(mock, DefaultReservationCleanupWaitDuration)
+
+	require.NoError(t, err)
+	require.NotNil(t, service)
+
+	service.Start()
+	service.Start()
+	service.Stop()
+	service.Stop()
+}
@@ -0,0 +1,92 @@
+package helper
+
+//go:generate mockgen -source=stock_allocation_service.go -destination=stock_allocation_service_mock.go -package=helper
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+)
+
+// StockAllocationService picks the locations which ship the units of an order
+type StockAllocationService interface {
+	// Allocate splits the items into allocations of the locations with the configured entities.AllocationStrategy.
+	// The locations nearest to the destination are preferred,
+	// without destination the locations nearest to the entities.DefaultLocationID are preferred.
+	Allocate(ctx context.Context, items []*entities.AllocationItem, destination *entities.Position) ([]*entities.Allocation, error)
+}
+
+var _ StockAllocationService = (*StockAllocationServiceImpl)(nil)
+
+type StockAllocationServiceImpl struct {
+	locationRepository entities.LocationRepository
+	stockLedgerService StockLedgerService
+	strategy           entities.AllocationStrategy
+}
+
+func NewStockAllocationService(locationRepository entities.LocationRepository, stockLedgerService StockLedgerService, strategy entities.AllocationStrategy) (StockAllocationService, error) {
+	if locationRepository == nil {
+		return nil, fmt.Errorf("locationRepository is nil")
+	} else if stockLedgerService == nil {
+		return nil, fmt.Errorf("stockLedgerService is nil")
+	} else if strategy == nil {
+		return nil, fmt.Errorf("strategy is nil")
+	}
+
+	return &StockAllocationServiceImpl{
+		locationRepository: locationRepository,
+		stockLedgerService: stockLedgerService,
+		strategy:           strategy,
+	}, nil
+}
+
+func (service *StockAllocationServiceImpl) Allocate(ctx context.Context, items []*entities.AllocationItem, destination *entities.Position) ([]*entities.Allocation, error) {
+	locations, locationsErr := service.sortedLocations(ctx, destination)
+	if locationsErr != nil {
+		return nil, locationsErr
+	}
+
+	stock := make(entities.LocationStock, len(items))
+	for _, item := range items {
+		if item == nil {
+			return nil, fmt.Errorf("item is nil")
+		} else if _, loaded := stock[item.ProductID]; loaded {
+			continue
+		}
+
+		balance, balanceErr := service.stockLedgerService.Balance(ctx, item.ProductID)
+		if balanceErr != nil {
+			return nil, balanceErr
+		}
+
+		stock[item.ProductID] = balance.Locations
+	}
+
+	return service.strategy.Allocate(items, locations, stock)
+}
+
+// sortedLocations returns the locations sorted by their distance to the destination, locations with the same distance by id
+func (service *StockAllocationServiceImpl) sortedLocations(ctx context.Context, destination *entities.Position) ([]*entities.Location, error) {
+	locations, locationsErr := service.locationRepository.FindAll(ctx)
+	if locationsErr != nil {
+		return nil, locationsErr
+	}
+
+	if destination == nil {
+		defaultLocation, defaultLocationErr := service.locationRepository.Find(ctx, entities.DefaultLocationID)
+		if defaultLocationErr != nil {
+			return nil, defaultLocationErr
+		}
+
+		destination = &defaultLocation.Position
+	}
+
+	// FindAll sorts by id, so the stable sort keeps that order for the same distance
+	sort.SliceStable(locations, func(i, j int) bool {
+		return destination.DistanceTo(locations[i].Position) < destination.DistanceTo(locations[j].Position)
+	})
+
+	return locations, nil
+}
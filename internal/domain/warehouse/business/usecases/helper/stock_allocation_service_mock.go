@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stock_allocation_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=stock_allocation_service.go -destination=stock_allocation_service_mock.go -package=helper
+//
+
+// Package helper is a generated GoMock package.
+package helper
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStockAllocationService is a mock of StockAllocationService interface.
+type MockStockAllocationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockAllocationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStockAllocationServiceMockRecorder is the mock recorder for MockStockAllocationService.
+type MockStockAllocationServiceMockRecorder struct {
+	mock *MockStockAllocationService
+}
+
+// NewMockStockAllocationService creates a new mock instance.
+func NewMockStockAllocationService(ctrl *gomock.Controller) *MockStockAllocationService {
+	mock := &MockStockAllocationService{ctrl: ctrl}
+	mock.recorder = &MockStockAllocationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockAllocationService) EXPECT() *MockStockAllocationServiceMockRecorder {
+	return m.recorder
+}
+
+// Allocate mocks base method.
+func (m *MockStockAllocationService) Allocate(ctx context.Context, items []*entities.AllocationItem, destination *entities.Position) ([]*entities.Allocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allocate", ctx, items, destination)
+	ret0, _ := ret[0].([]*entities.Allocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allocate indicates an expected call of Allocate.
+func (mr *MockStockAllocationServiceMockRecorder) Allocate(ctx, items, destination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allocate", reflect.TypeOf((*MockStockAllocationService)(nil).Allocate), ctx, items, destination)
+}
@@ -0,0 +1,98 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+var (
+	berlin  = &entities.Location{ID: entities.DefaultLocationID, Position: entities.Position{Latitude: 52.52, Longitude: 13.405}}
+	hamburg = &entities.Location{ID: "hamburg", Position: entities.Position{Latitude: 53.551, Longitude: 9.994}}
+	munich  = &entities.Location{ID: "munich", Position: entities.Position{Latitude: 48.137, Longitude: 11.575}}
+)
+
+func Test_StockAllocationService_NewStockAllocationService_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	strategy := &entities.NearestAllocationStrategy{}
+
+	service, err := NewStockAllocationService(nil, NewMockStockLedgerService(ctrl), strategy)
+	require.ErrorContains(t, err, "locationRepository is nil")
+	require.Nil(t, service)
+
+	service, err = NewStockAllocationService(entities.NewMockLocationRepository(ctrl), nil, strategy)
+	require.ErrorContains(t, err, "stockLedgerService is nil")
+	require.Nil(t, service)
+
+	service, err = NewStockAllocationService(entities.NewMockLocationRepository(ctrl), NewMockStockLedgerService(ctrl), nil)
+	require.ErrorContains(t, err, "strategy is nil")
+	require.Nil(t, service)
+}
+
+func newStockAllocationServiceForTest(t *testing.T, ctrl *gomock.Controller) (StockAllocationService, *entities.MockLocationRepository) {
+	locationRepositoryMock := entities.NewMockLocationRepository(ctrl)
+	locationRepositoryMock.EXPECT().FindAll(gomock.Any()).Return([]*entities.Location{hamburg, berlin, munich}, nil)
+
+	stockLedgerServiceMock := NewMockStockLedgerService(ctrl)
+	stockLedgerServiceMock.EXPECT().Balance(gomock.Any(), "A12341").Return(&entities.StockBalance{
+		Stock: 6, Locations: map[string]int{entities.DefaultLocationID: 2, "hamburg": 2, "munich": 2},
+	}, nil)
+
+	service, err := NewStockAllocationService(locationRepositoryMock, stockLedgerServiceMock, &entities.NearestAllocationStrategy{})
+	require.NoError(t, err)
+
+	return service, locationRepositoryMock
+}
+
+func Test_StockAllocationService_Allocate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _ := newStockAllocationServiceForTest(t, ctrl)
+
+	// munich is nearer to stuttgart than berlin
+	stuttgart := &entities.Position{Latitude: 48.776, Longitude: 9.183}
+
+	allocations, err := service.Allocate(t.Context(), []*entities.AllocationItem{{ProductID: "A12341", Count: 3}}, stuttgart)
+
+	require.NoError(t, err)
+	require.Equal(t, []*entities.Allocation{
+		{ProductID: "A12341", LocationID: "munich", Count: 2},
+		{ProductID: "A12341", LocationID: entities.DefaultLocationID, Count: 1},
+	}, allocations)
+}
+
+func Test_StockAllocationService_Allocate_WithoutDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, locationRepositoryMock := newStockAllocationServiceForTest(t, ctrl)
+
+	locationRepositoryMock.EXPECT().Find(gomock.Any(), entities.DefaultLocationID).Return(berlin, nil)
+
+	// the default location ships first, hamburg is nearer to berlin than munich
+	allocations, err := service.Allocate(t.Context(), []*entities.AllocationItem{{ProductID: "A12341", Count: 3}}, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, []*entities.Allocation{
+		{ProductID: "A12341", LocationID: entities.DefaultLocationID, Count: 2},
+		{ProductID: "A12341", LocationID: "hamburg", Count: 1},
+	}, allocations)
+}
+
+func Test_StockAllocationService_Allocate_OutOfStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _ := newStockAllocationServiceForTest(t, ctrl)
+
+	_, err := service.Allocate(t.Context(), []*entities.AllocationItem{{ProductID: "A12341", Count: 7}}, &hamburg.Position)
+
+	require.Equal(t, &domainerror.OutOfStockError{ProductID: "A12341", Available: 6, Requested: 7}, err)
+}
@@ -0,0 +1,244 @@
+package helper
+
+//go:generate mockgen -source=stock_ledger_service.go -destination=stock_ledger_service_mock.go -package=helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+// OpeningBalanceReference is the reference of the receipts which move the stock of a product without movements into the ledger
+const OpeningBalanceReference = "opening balance"
+
+// StockLedgerService records every change of the stock as a stock movement.
+// The stock of a product is derived from its movements, the product only stores it for reading.
+type StockLedgerService interface {
+	// Record validates the movement, sets its time and appends it to the ledger.
+	// A movement which changes the stock also saves the product with the stock derived from the ledger,
+	// it is rejected with a domainerror.OutOfStockError if the stock of its location would become negative.
+	// Only a sale at the entities.DefaultLocationID may backorder units up to the max backorder of the product's stock policy.
+	// The stock of a movement without location is changed at the entities.DefaultLocationID.
+	Record(ctx context.Context, movement *entities.StockMovement) error
+	// Balance returns the current stock of the product per location and its reserved units
+	Balance(ctx context.Context, productID string) (*entities.StockBalance, error)
+	// RecordOpeningBalances records a receipt of the stock of every product without movements,
+	// e.g. of the products stored before the ledger existed, and returns the number of receipts
+	RecordOpeningBalances(ctx context.Context) (int, error)
+	// UpdateProduct finds the product, changes it with update and saves it while no movement is recorded,
+	// so changing another field of the product cannot overwrite its stock with a stale value.
+	// The product is not saved if update returns an error, the error is returned as is.
+	UpdateProduct(ctx context.Context, productID string, update func(product *entities.Product) error) (*entities.Product, error)
+}
+
+var _ StockLedgerService = (*StockLedgerServiceImpl)(nil)
+
+type StockLedgerServiceImpl struct {
+	productRepository       entities.ProductRepository
+	locationRepository      entities.LocationRepository
+	stockMovementRepository entities.StockMovementRepository
+	now                     func() time.Time
+
+	// mutex makes reading the balance, saving the product and appending the movement one step,
+	// it is also held by UpdateProduct, so the product is not saved by two writers at the same time
+	mutex sync.Mutex
+}
+
+func NewStockLedgerService(productRepository entities.ProductRepository, locationRepository entities.LocationRepository, stockMovementRepository entities.StockMovementRepository) (StockLedgerService, error) {
+	if productRepository == nil {
+		return nil, fmt.Errorf("productRepository is nil")
+	} else if locationRepository == nil {
+		return nil, fmt.Errorf("locationRepository is nil")
+	} else if stockMovementRepository == nil {
+		return nil, fmt.Errorf("stockMovementRepository is nil")
+	}
+
+	return &StockLedgerServiceImpl{
+		productRepository:       productRepository,
+		locationRepository:      locationRepository,
+		stockMovementRepository: stockMovementRepository,
+		now:                     time.Now,
+	}, nil
+}
+
+func (service *StockLedgerServiceImpl) Record(ctx context.Context, movement *entities.StockMovement) error {
+	if movement == nil {
+		return fmt.Errorf("movement is nil")
+	}
+
+	validateErr := movement.Validate()
+	if validateErr != nil {
+		return validateErr
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	// databases like MongoDB only store milliseconds
+	movement.CreatedAt = service.now().UTC().Truncate(time.Millisecond)
+
+	if !movement.ChangesStock() {
+		return service.stockMovementRepository.Append(ctx, movement)
+	}
+
+	if movement.LocationID == "" {
+		movement.LocationID = entities.DefaultLocationID
+	}
+
+	_, locationErr := service.locationRepository.Find(ctx, movement.LocationID)
+	if locationErr != nil {
+		return locationErr
+	}
+
+	product, productErr := service.productRepository.Find(ctx, movement.ProductID)
+	if productErr != nil {
+		return productErr
+	}
+
+	balance, balanceErr := service.balance(ctx, product, movement.CreatedAt)
+	if balanceErr != nil {
+		return balanceErr
+	}
+
+	locationStock := balance.Locations[movement.LocationID]
+	minimumStock := service.minimumStock(product, movement)
+	if locationStock+movement.Quantity < minimumStock {
+		return &domainerror.OutOfStockError{
+			ProductID: product.ID,
+			Available: max(locationStock-minimumStock, 0),
+			Requested: -movement.Quantity,
+		}
+	}
+
+	// the product is saved first, because it can be restored if the movement cannot be appended
+	product.Stock = balance.Stock + movement.Quantity
+	saveErr := service.productRepository.Save(ctx, product)
+	if saveErr != nil {
+		return saveErr
+	}
+
+	appendErr := service.stockMovementRepository.Append(ctx, movement)
+	if appendErr != nil {
+		product.Stock = balance.Stock
+		restoreErr := service.productRepository.Save(context.WithoutCancel(ctx), product)
+		if restoreErr != nil {
+			restoreErr = fmt.Errorf("failed to restore the stock of product %s: %w", product.ID, restoreErr)
+		}
+
+		return errors.Join(appendErr, restoreErr)
+	}
+
+	return nil
+}
+
+func (service *StockLedgerServiceImpl) Balance(ctx context.Context, productID string) (*entities.StockBalance, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	product, productErr := service.productRepository.Find(ctx, productID)
+	if productErr != nil {
+		return nil, productErr
+	}
+
+	return service.balance(ctx, product, service.now().UTC().Truncate(time.Millisecond))
+}
+
+func (service *StockLedgerServiceImpl) RecordOpeningBalances(ctx context.Context) (int, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	products, productsErr := service.productRepository.FindAll(ctx)
+	if productsErr != nil {
+		return 0, productsErr
+	}
+
+	now := service.now().UTC().Truncate(time.Millisecond)
+
+	recorded := 0
+	for _, product := range products {
+		balance, balanceErr := service.stockMovementRepository.Balance(ctx, product.ID, time.Time{})
+		if balanceErr != nil {
+			return recorded, balanceErr
+		}
+
+		if balance.Movements == 0 && product.Stock > 0 {
+			openErr := service.openBalance(ctx, product, now)
+			if openErr != nil {
+				return recorded, openErr
+			}
+			recorded++
+		}
+	}
+
+	return recorded, nil
+}
+
+func (service *StockLedgerServiceImpl) UpdateProduct(ctx context.Context, productID string, update func(product *entities.Product) error) (*entities.Product, error) {
+	if update == nil {
+		return nil, fmt.Errorf("update is nil")
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	product, productErr := service.productRepository.Find(ctx, productID)
+	if productErr != nil {
+		return nil, productErr
+	}
+
+	updateErr := update(product)
+	if updateErr != nil {
+		return nil, updateErr
+	}
+
+	saveErr := service.productRepository.Save(ctx, product)
+	if saveErr != nil {
+		return nil, saveErr
+	}
+
+	return product, nil
+}
+
+// balance returns the balance of the product, a product without movements gets its opening balance first
+func (service *StockLedgerServiceImpl) balance(ctx context.Context, product *entities.Product, now time.Time) (*entities.StockBalance, error) {
+	balance, balanceErr := service.stockMovementRepository.Balance(ctx, product.ID, time.Time{})
+	if balanceErr != nil {
+		return nil, balanceErr
+	}
+
+	if balance.Movements == 0 && product.Stock > 0 {
+		openErr := service.openBalance(ctx, product, now)
+		if openErr != nil {
+			return nil, openErr
+		}
+		balance.Add(&entities.StockMovement{Type: entities.StockMovementTypeReceipt, Quantity: product.Stock, LocationID: entities.DefaultLocationID})
+	}
+
+	return balance, nil
+}
+
+// minimumStock is the lowest stock of the location after the movement.
+// Only the sales of the default location may backorder units, the other movements cannot make the stock negative.
+func (service *StockLedgerServiceImpl) minimumStock(product *entities.Product, movement *entities.StockMovement) int {
+	if movement.Type == entities.StockMovementTypeSale && movement.LocationID == entities.DefaultLocationID {
+		return -product.StockPolicy.MaxBackorder
+	}
+
+	return 0
+}
+
+func (service *StockLedgerServiceImpl) openBalance(ctx context.Context, product *entities.Product, now time.Time) error {
+	return service.stockMovementRepository.Append(ctx, &entities.StockMovement{
+		ProductID:  product.ID,
+		Type:       entities.StockMovementTypeReceipt,
+		Quantity:   product.Stock,
+		LocationID: entities.DefaultLocationID,
+		Reference:  OpeningBalanceReference,
+		CreatedAt:  now,
+	})
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stock_ledger_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=stock_ledger_service.go -destination=stock_ledger_service_mock.go -package=helper
+//
+
+// Package helper is a generated GoMock package.
+package helper
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStockLedgerService is a mock of StockLedgerService interface.
+type MockStockLedgerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockLedgerServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStockLedgerServiceMockRecorder is the mock recorder for MockStockLedgerService.
+type MockStockLedgerServiceMockRecorder struct {
+	mock *MockStockLedgerService
+}
+
+// NewMockStockLedgerService creates a new mock instance.
+func NewMockStockLedgerService(ctrl *gomock.Controller) *MockStockLedgerService {
+	mock := &MockStockLedgerService{ctrl: ctrl}
+	mock.recorder = &MockStockLedgerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockLedgerService) EXPECT() *MockStockLedgerServiceMockRecorder {
+	return m.recorder
+}
+
+// Balance mocks base method.
+func (m *MockStockLedgerService) Balance(ctx context.Context, productID string) (*entities.StockBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", ctx, productID)
+	ret0, _ := ret[0].(*entities.StockBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance.
+func (mr *MockStockLedgerServiceMockRecorder) Balance(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockStockLedgerService)(nil).Balance), ctx, productID)
+}
+
+// Record mocks base method.
+func (m *MockStockLedgerService) Record(ctx context.Context, movement *entities.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockStockLedgerServiceMockRecorder) Record(ctx, movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockStockLedgerService)(nil).Record), ctx, movement)
+}
+
+// RecordOpeningBalances mocks base method.
+func (m *MockStockLedgerService) RecordOpeningBalances(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOpeningBalances", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordOpeningBalances indicates an expected call of RecordOpeningBalances.
+func (mr *MockStockLedgerServiceMockRecorder) RecordOpeningBalances(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOpeningBalances", reflect.TypeOf((*MockStockLedgerService)(nil).RecordOpeningBalances), ctx)
+}
+
+// UpdateProduct mocks base method.
+func (m *MockStockLedgerService) UpdateProduct(ctx context.Context, productID string, update func(*entities.Product) error) (*entities.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", ctx, productID, update)
+	ret0, _ := ret[0].(*entities.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *MockStockLedgerServiceMockRecorder) UpdateProduct(ctx, productID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockStockLedgerService)(nil).UpdateProduct), ctx, productID, update)
+}
@@ -0,0 +1,339 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_StockLedgerService_NewStockLedgerService_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, err := NewStockLedgerService(nil, entities.NewMockLocationRepository(ctrl), entities.NewMockStockMovementRepository(ctrl))
+	require.ErrorContains(t, err, "productRepository is nil")
+	require.Nil(t, service)
+
+	service, err = NewStockLedgerService(entities.NewMockProductRepository(ctrl), nil, entities.NewMockStockMovementRepository(ctrl))
+	require.ErrorContains(t, err, "locationRepository is nil")
+	require.Nil(t, service)
+
+	service, err = NewStockLedgerService(entities.NewMockProductRepository(ctrl), entities.NewMockLocationRepository(ctrl), nil)
+	require.ErrorContains(t, err, "stockMovementRepository is nil")
+	require.Nil(t, service)
+}
+
+// newStockLedgerServiceForTest knows the locations entities.DefaultLocationID and hamburg
+func newStockLedgerServiceForTest(t *testing.T, ctrl *gomock.Controller, now time.Time) (*StockLedgerServiceImpl, *entities.MockProductRepository, *entities.MockStockMovementRepository) {
+	productRepositoryMock := entities.NewMockProductRepository(ctrl)
+	stockMovementRepositoryMock := entities.NewMockStockMovementRepository(ctrl)
+
+	locationRepositoryMock := entities.NewMockLocationRepository(ctrl)
+	locationRepositoryMock.EXPECT().Find(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id string) (*entities.Location, error) {
+		if id != entities.DefaultLocationID && id != "hamburg" {
+			return nil, &domainerror.NotFoundError{Resource: entities.LocationResource, ID: id}
+		}
+
+		return &entities.Location{ID: id}, nil
+	}).AnyTimes()
+
+	service, err := NewStockLedgerService(productRepositoryMock, locationRepositoryMock, stockMovementRepositoryMock)
+	require.NoError(t, err)
+
+	serviceImpl := service.(*StockLedgerServiceImpl)
+	serviceImpl.now = func() time.Time {
+		return now
+	}
+
+	return serviceImpl, productRepositoryMock, stockMovementRepositoryMock
+}
+
+func Test_StockLedgerService_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	service, productRepositoryMock, stockMovementRepositoryMock := newStockLedgerServiceForTest(t, ctrl, now)
+
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "1").Return(&entities.Product{ID: "1", Price: money.New(1337, "EUR"), Stock: 8}, nil)
+	stockMovementRepositoryMock.EXPECT().Balance(gomock.Any(), "1", time.Time{}).Return(&entities.StockBalance{Stock: 10, Reserved: 2, Movements: 3, Locations: map[string]int{entities.DefaultLocationID: 10}}, nil)
+
+	// the stock is derived from the ledger, not from the stored product
+	gomock.InOrder(
+		productRepositoryMock.EXPECT().Save(gomock.Any(), &entities.Product{ID: "1", Price: money.New(1337, "EUR"), Stock: 7}).Return(nil),
+		stockMovementRepositoryMock.EXPECT().Append(gomock.Any(), &entities.StockMovement{
+			ProductID: "1", Type: entities.StockMovementTypeSale, Quantity: -3, LocationID: entities.DefaultLocationID, Reference: "order-1", CreatedAt: now,
+		}).Return(nil),
+	)
+
+	err := service.Record(t.Context(), &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeSale, Quantity: -3, Reference: "order-1"})
+
+	require.NoError(t, err)
+}
+
+func Test_StockLedgerService_Record_OpeningBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	service, productRepositoryMock, stockMovementRepositoryMock := newStockLedgerServiceForTest(t, ctrl, now)
+
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "1").Return(&entities.Product{ID: "1", Price: money.New(1337, "EUR"), Stock: 8}, nil)
+	stockMovementRepositoryMock.EXPECT().Balance(gomock.Any(), "1", time.Time{}).Return(&entities.StockBalance{}, nil)
+
+	gomock.InOrder(
+		stockMovementRepositoryMock.EXPECT().Append(gomock.Any(), &entities.StockMovement{
+			ProductID: "1", Type: entities.StockMovementTypeReceipt, Quantity: 8, LocationID: entities.DefaultLocationID, Reference: OpeningBalanceReference, CreatedAt: now,
+		}).Return(nil),
+		productRepositoryMock.EXPECT().Save(gomock.Any(), &entities.Product{ID: "1", Price: money.New(1337, "EUR"), Stock: 5}).Return(nil),
+		stockMovementRepositoryMock.EXPECT().Append(gomock.Any(), &entities.StockMovement{
+			ProductID: "1", Type: entities.StockMovementTypeAdjustment, Quantity: -3, Reason: entities.StockAdjustmentReasonLost, LocationID: entities.DefaultLocationID, CreatedAt: now,
+		}).Return(nil),
+	)
+
+	err := service.Record(t.Context(), &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeAdjustment, Quantity: -3, Reason: entities.StockAdjustmentReasonLost})
+
+	require.NoError(t, err)
+}
+
+func Test_StockLedgerService_Record_Reservation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	service, _, stockMovementRepositoryMock := newStockLedgerServiceForTest(t, ctrl, now)
+
+	// a reservation does not change the stock of the product
+	stockMovementRepositoryMock.EXPECT().Append(gomock.Any(), &entities.StockMovement{
+		ProductID: "1", Type: entities.StockMovementTypeReservation, Quantity: 2, Reference: "1337", CreatedAt: now,
+	}).Return(nil)
+
+	err := service.Record(t.Context(), &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeReservation, Quantity: 2, Reference: "1337"})
+
+	require.NoError(t, err)
+}
+
+func Test_StockLedgerService_Record_OutOfStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, productRepositoryMock, stockMovementRepositoryMock := newStockLedgerServiceForTest(t, ctrl, time.Now())
+
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "1").Return(&entities.Product{ID: "1", Stock: 2}, nil)
+	stockMovementRepositoryMock.EXPECT().Balance(gomock.Any(), "1", time.Time{}).Return(&entities.StockBalance{Stock: 2, Movements: 1, Locations: map[string]int{entities.DefaultLocationID: 2}}, nil)
+
+	err := service.Record(t.Context(), &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeSale, Quantity: -3})
+
+	var outOfStockErr *domainerror.OutOfStockError
+	require.ErrorAs(t, err, &outOfStockErr)
+	require.Equal(t, 2, outOfStockErr.Available)
+	require.Equal(t, 3, outOfStockErr.Requested)
+}
+
+func Test_StockLedgerService_Record_OutOfStockAtLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, productRepositoryMock, stockMovementRepositoryMock := newStockLedgerServiceForTest(t, ctrl, time.Now())
+
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "1").Return(&entities.Product{ID: "1", Stock: 10}, nil)
+	stockMovementRepositoryMock.EXPECT().Balance(gomock.Any(), "1", time.Time{}).Return(&entities.StockBalance{Stock: 10, Movements: 2, Locations: map[string]int{entities.DefaultLocationID: 9, "hamburg": 1}}, nil)
+
+	// the stock of the other locations cannot be sold from hamburg
+	err := service.Record(t.Context(), &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeSale, Quantity: -2, LocationID: "hamburg"})
+
+	require.Equal(t, &domainerror.OutOfStockError{ProductID: "1", Available: 1, Requested: 2}, err)
+}
+
+func Test_StockLedgerService_Record_Backorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	service, productRepositoryMock, stockMovementRepositoryMock := newStockLedgerServiceForTest(t, ctrl, now)
+
+	stockPolicy := entities.StockPolicy{Type: entities.StockPolicyTypeBackorder, MaxBackorder: 5}
+
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "1").Return(&entities.Product{ID: "1", Stock: 3, StockPolicy: stockPolicy}, nil).Times(4)
+	stockMovementRepositoryMock.EXPECT().Balance(gomock.Any(), "1", time.Time{}).Return(&entities.StockBalance{Stock: 3, Movements: 2, Locations: map[string]int{entities.DefaultLocationID: 2, "hamburg": 1}}, nil).Times(4)
+
+	gomock.InOrder(
+		productRepositoryMock.EXPECT().Save(gomock.Any(), &entities.Product{ID: "1", Stock: -4, StockPolicy: stockPolicy}).Return(nil),
+		stockMovementRepositoryMock.EXPECT().Append(gomock.Any(), &entities.StockMovement{
+			ProductID: "1", Type: entities.StockMovementTypeSale, Quantity: -7, LocationID: entities.DefaultLocationID, Reference: "order-1", CreatedAt: now,
+		}).Return(nil),
+	)
+
+	// the backordered units are sold from the default location
+	err := service.Record(t.Context(), &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeSale, Quantity: -7, Reference: "order-1"})
+	require.NoError(t, err)
+
+	err = service.Record(t.Context(), &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeSale, Quantity: -8, Reference: "order-2"})
+	require.Equal(t, &domainerror.OutOfStockError{ProductID: "1", Available: 7, Requested: 8}, err)
+
+	err = service.Record(t.Context(), &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeSale, Quantity: -2, LocationID: "hamburg", Reference: "order-3"})
+	require.Equal(t, &domainerror.OutOfStockError{ProductID: "1", Available: 1, Requested: 2}, err)
+
+	// units which are not sold cannot be backordered
+	err = service.Record(t.Context(), &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeAdjustment, Quantity: -3, Reason: entities.StockAdjustmentReasonLost})
+	require.Equal(t, &domainerror.OutOfStockError{ProductID: "1", Available: 2, Requested: 3}, err)
+}
+
+func Test_StockLedgerService_Record_UnknownLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _, _ := newStockLedgerServiceForTest(t, ctrl, time.Now())
+
+	err := service.Record(t.Context(), &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeReceipt, Quantity: 2, LocationID: "munich"})
+
+	require.True(t, domainerror.IsNotFound(err, entities.LocationResource))
+}
+
+func Test_StockLedgerService_Record_RestoresStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, productRepositoryMock, stockMovementRepositoryMock := newStockLedgerServiceForTest(t, ctrl, time.Now())
+
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "1").Return(&entities.Product{ID: "1", Stock: 5}, nil)
+	stockMovementRepositoryMock.EXPECT().Balance(gomock.Any(), "1", time.Time{}).Return(&entities.StockBalance{Stock: 5, Movements: 1, Locations: map[string]int{entities.DefaultLocationID: 5}}, nil)
+
+	gomock.InOrder(
+		productRepositoryMock.EXPECT().Save(gomock.Any(), &entities.Product{ID: "1", Stock: 9}).Return(nil),
+		stockMovementRepositoryMock.EXPECT().Append(gomock.Any(), gomock.Any()).Return(fmt.Errorf("database error")),
+		productRepositoryMock.EXPECT().Save(gomock.Any(), &entities.Product{ID: "1", Stock: 5}).Return(nil),
+	)
+
+	err := service.Record(t.Context(), &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeReceipt, Quantity: 4})
+
+	require.ErrorContains(t, err, "database error")
+}
+
+func Test_StockLedgerService_Record_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		movement *entities.StockMovement
+	}{
+		"movement is nil": {
+			movement: nil,
+		},
+		"product id is empty": {
+			movement: &entities.StockMovement{Type: entities.StockMovementTypeReceipt, Quantity: 1},
+		},
+		"quantity of a sale must be negative": {
+			movement: &entities.StockMovement{ProductID: "1", Type: entities.StockMovementTypeSale, Quantity: 1},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service, _, _ := newStockLedgerServiceForTest(t, ctrl, time.Now())
+
+			err := service.Record(t.Context(), testCase.movement)
+
+			require.ErrorContains(t, err, errorString)
+		})
+	}
+}
+
+func Test_StockLedgerService_Balance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	service, productRepositoryMock, stockMovementRepositoryMock := newStockLedgerServiceForTest(t, ctrl, now)
+
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "1").Return(&entities.Product{ID: "1", Stock: 4}, nil)
+	stockMovementRepositoryMock.EXPECT().Balance(gomock.Any(), "1", time.Time{}).Return(&entities.StockBalance{}, nil)
+	stockMovementRepositoryMock.EXPECT().Append(gomock.Any(), &entities.StockMovement{
+		ProductID: "1", Type: entities.StockMovementTypeReceipt, Quantity: 4, LocationID: entities.DefaultLocationID, Reference: OpeningBalanceReference, CreatedAt: now,
+	}).Return(nil)
+
+	// the stock of a product without movements is at the default location
+	balance, err := service.Balance(t.Context(), "1")
+
+	require.NoError(t, err)
+	require.Equal(t, &entities.StockBalance{Stock: 4, Movements: 1, Locations: map[string]int{entities.DefaultLocationID: 4}}, balance)
+}
+
+func Test_StockLedgerService_RecordOpeningBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	service, productRepositoryMock, stockMovementRepositoryMock := newStockLedgerServiceForTest(t, ctrl, now)
+
+	productRepositoryMock.EXPECT().FindAll(gomock.Any()).Return([]*entities.Product{
+		{ID: "1", Stock: 5},
+		// products with movements or without stock need no opening balance
+		{ID: "2", Stock: 7},
+		{ID: "3", Stock: 0},
+	}, nil)
+	stockMovementRepositoryMock.EXPECT().Balance(gomock.Any(), "1", time.Time{}).Return(&entities.StockBalance{}, nil)
+	stockMovementRepositoryMock.EXPECT().Balance(gomock.Any(), "2", time.Time{}).Return(&entities.StockBalance{Stock: 7, Movements: 2}, nil)
+	stockMovementRepositoryMock.EXPECT().Balance(gomock.Any(), "3", time.Time{}).Return(&entities.StockBalance{}, nil)
+	stockMovementRepositoryMock.EXPECT().Append(gomock.Any(), &entities.StockMovement{
+		ProductID: "1", Type: entities.StockMovementTypeReceipt, Quantity: 5, LocationID: entities.DefaultLocationID, Reference: OpeningBalanceReference, CreatedAt: now,
+	}).Return(nil)
+
+	recorded, err := service.RecordOpeningBalances(t.Context())
+
+	require.NoError(t, err)
+	require.Equal(t, 1, recorded)
+}
+
+func Test_StockLedgerService_UpdateProduct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, productRepositoryMock, _ := newStockLedgerServiceForTest(t, ctrl, time.Now())
+
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "1").Return(&entities.Product{ID: "1", Name: "Book", Stock: 4}, nil)
+	productRepositoryMock.EXPECT().Save(gomock.Any(), &entities.Product{ID: "1", Name: "Cookbook", Stock: 4}).Return(nil)
+
+	product, err := service.UpdateProduct(t.Context(), "1", func(product *entities.Product) error {
+		return product.Rename("Cookbook")
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, &entities.Product{ID: "1", Name: "Cookbook", Stock: 4}, product)
+}
+
+func Test_StockLedgerService_UpdateProduct_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, productRepositoryMock, _ := newStockLedgerServiceForTest(t, ctrl, time.Now())
+
+	// the product is not saved if the update fails
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "1").Return(&entities.Product{ID: "1", Name: "Book", Stock: 4}, nil)
+
+	product, err := service.UpdateProduct(t.Context(), "1", func(product *entities.Product) error {
+		return product.Rename("")
+	})
+
+	require.ErrorContains(t, err, "name is empty")
+	require.Nil(t, product)
+
+	product, err = service.UpdateProduct(t.Context(), "1", nil)
+
+	require.ErrorContains(t, err, "update is nil")
+	require.Nil(t, product)
+}
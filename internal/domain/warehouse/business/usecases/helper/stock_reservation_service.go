@@ -0,0 +1,255 @@
+package helper
+
+//go:generate mockgen -source=stock_reservation_service.go -destination=stock_reservation_service_mock.go -package=helper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+const (
+	DefaultReservationLifetime = 15 * time.Minute
+)
+
+// StockReservationService holds units of the products in a basket,
+// so that two users cannot put the last unit of a product into their baskets.
+// Every change of a reservation is recorded in the stock ledger with the holder as reference.
+type StockReservationService interface {
+	// AvailableStock returns the stock minus the active reservations of all other holders
+	AvailableStock(ctx context.Context, productID string, holderID string) (int, error)
+	// SellableStock is like AvailableStock, but it includes the units which can still be backordered
+	SellableStock(ctx context.Context, productID string, holderID string) (int, error)
+	// Reserve sets the reserved count of the holder for the product and renews the expiry,
+	// the count may include units which are backordered
+	Reserve(ctx context.Context, holderID string, productID string, count int) error
+	Release(ctx context.Context, holderID string, productID string) error
+	ReleaseAll(ctx context.Context, holderID string) error
+	ReleaseExpired(ctx context.Context) (int, error)
+}
+
+var _ StockReservationService = (*StockReservationServiceImpl)(nil)
+
+type StockReservationServiceImpl struct {
+	productRepository     entities.ProductRepository
+	reservationRepository entities.ReservationRepository
+	stockLedgerService    StockLedgerService
+	lifetime              time.Duration
+	now                   func() time.Time
+
+	// mutex makes the availability check and the reservation one step
+	mutex sync.Mutex
+}
+
+func NewStockReservationService(productRepository entities.ProductRepository, reservationRepository entities.ReservationRepository, stockLedgerService StockLedgerService, lifetime time.Duration) (StockReservationService, error) {
+	if productRepository == nil {
+		return nil, fmt.Errorf("productRepository is nil")
+	} else if reservationRepository == nil {
+		return nil, fmt.Errorf("reservationRepository is nil")
+	} else if stockLedgerService == nil {
+		return nil, fmt.Errorf("stockLedgerService is nil")
+	} else if lifetime <= 0 {
+		return nil, fmt.Errorf("lifetime must be greater than 0")
+	}
+
+	return &StockReservationServiceImpl{
+		productRepository:     productRepository,
+		reservationRepository: reservationRepository,
+		stockLedgerService:    stockLedgerService,
+		lifetime:              lifetime,
+		now:                   time.Now,
+	}, nil
+}
+
+func (service *StockReservationServiceImpl) AvailableStock(ctx context.Context, productID string, holderID string) (int, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	product, productErr := service.productRepository.Find(ctx, productID)
+	if productErr != nil {
+		return 0, productErr
+	}
+
+	return service.unreservedStock(ctx, product.ID, product.Stock, holderID)
+}
+
+func (service *StockReservationServiceImpl) SellableStock(ctx context.Context, productID string, holderID string) (int, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	return service.sellableStock(ctx, productID, holderID)
+}
+
+func (service *StockReservationServiceImpl) sellableStock(ctx context.Context, productID string, holderID string) (int, error) {
+	product, productErr := service.productRepository.Find(ctx, productID)
+	if productErr != nil {
+		return 0, productErr
+	}
+
+	return service.unreservedStock(ctx, product.ID, product.SellableStock(), holderID)
+}
+
+// unreservedStock subtracts the active reservations of all other holders from the stock
+func (service *StockReservationServiceImpl) unreservedStock(ctx context.Context, productID string, stock int, holderID string) (int, error) {
+	reservations, reservationsErr := service.reservationRepository.FindByProductId(ctx, productID)
+	if reservationsErr != nil {
+		return 0, reservationsErr
+	}
+
+	now := service.now()
+
+	available := stock
+	for _, reservation := range reservations {
+		if reservation.GetHolderID() != holderID && reservation.IsActive(now) {
+			available -= reservation.GetCount()
+		}
+	}
+
+	// the stock can drop below the reserved units, e.g. by a checkout of another holder
+	if available < 0 {
+		available = 0
+	}
+
+	return available, nil
+}
+
+func (service *StockReservationServiceImpl) Reserve(ctx context.Context, holderID string, productID string, count int) error {
+	if count <= 0 {
+		return service.Release(ctx, holderID, productID)
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	available, availableErr := service.sellableStock(ctx, productID, holderID)
+	if availableErr != nil {
+		return availableErr
+	}
+
+	if available < count {
+		return &domainerror.OutOfStockError{
+			ProductID: productID,
+			Available: available,
+			Requested: count,
+		}
+	}
+
+	reservedCount, reservedCountErr := service.reservedCount(ctx, holderID, productID)
+	if reservedCountErr != nil {
+		return reservedCountErr
+	}
+
+	saveErr := service.reservationRepository.Save(ctx, &entities.Reservation{
+		ProductID: productID,
+		HolderID:  holderID,
+		Count:     count,
+		ExpiresAt: service.now().Add(service.lifetime),
+	})
+	if saveErr != nil {
+		return saveErr
+	}
+
+	return service.record(ctx, holderID, productID, count-reservedCount)
+}
+
+func (service *StockReservationServiceImpl) Release(ctx context.Context, holderID string, productID string) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	reservedCount, reservedCountErr := service.reservedCount(ctx, holderID, productID)
+	if reservedCountErr != nil {
+		return reservedCountErr
+	}
+
+	err := service.reservationRepository.Delete(ctx, holderID, productID)
+
+	if domainerror.IsNotFound(err, entities.ReservationResource) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return service.record(ctx, holderID, productID, -reservedCount)
+}
+
+func (service *StockReservationServiceImpl) ReleaseAll(ctx context.Context, holderID string) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	reservations, reservationsErr := service.reservationRepository.FindByHolderId(ctx, holderID)
+	if reservationsErr != nil {
+		return reservationsErr
+	}
+
+	for _, reservation := range reservations {
+		err := service.reservationRepository.Delete(ctx, holderID, reservation.GetProductID())
+
+		if domainerror.IsNotFound(err, entities.ReservationResource) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		recordErr := service.record(ctx, holderID, reservation.GetProductID(), -reservation.GetCount())
+		if recordErr != nil {
+			return recordErr
+		}
+	}
+
+	return nil
+}
+
+func (service *StockReservationServiceImpl) ReleaseExpired(ctx context.Context) (int, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	reservations, err := service.reservationRepository.DeleteExpired(ctx, service.now())
+	if err != nil {
+		return 0, err
+	}
+
+	for _, reservation := range reservations {
+		recordErr := service.record(ctx, reservation.GetHolderID(), reservation.GetProductID(), -reservation.GetCount())
+		if recordErr != nil {
+			return 0, recordErr
+		}
+	}
+
+	return len(reservations), nil
+}
+
+// reservedCount returns the units the holder has reserved of the product, including an expired reservation
+// which is not deleted yet, because its units are still reserved in the stock ledger
+func (service *StockReservationServiceImpl) reservedCount(ctx context.Context, holderID string, productID string) (int, error) {
+	reservation, err := service.reservationRepository.Find(ctx, holderID, productID)
+	if domainerror.IsNotFound(err, entities.ReservationResource) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return reservation.GetCount(), nil
+}
+
+// record records the change of the reserved units, a negative change is a release
+func (service *StockReservationServiceImpl) record(ctx context.Context, holderID string, productID string, change int) error {
+	if change == 0 {
+		return nil
+	}
+
+	movementType := entities.StockMovementTypeReservation
+	if change < 0 {
+		movementType = entities.StockMovementTypeRelease
+	}
+
+	return service.stockLedgerService.Record(ctx, &entities.StockMovement{
+		ProductID: productID,
+		Type:      movementType,
+		Quantity:  change,
+		Reference: holderID,
+	})
+}
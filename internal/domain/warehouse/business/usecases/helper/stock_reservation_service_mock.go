@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stock_reservation_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=stock_reservation_service.go -destination=stock_reservation_service_mock.go -package=helper
+//
+
+// Package helper is a generated GoMock package.
+package helper
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStockReservationService is a mock of StockReservationService interface.
+type MockStockReservationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockReservationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStockReservationServiceMockRecorder is the mock recorder for MockStockReservationService.
+type MockStockReservationServiceMockRecorder struct {
+	mock *MockStockReservationService
+}
+
+// NewMockStockReservationService creates a new mock instance.
+func NewMockStockReservationService(ctrl *gomock.Controller) *MockStockReservationService {
+	mock := &MockStockReservationService{ctrl: ctrl}
+	mock.recorder = &MockStockReservationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockReservationService) EXPECT() *MockStockReservationServiceMockRecorder {
+	return m.recorder
+}
+
+// AvailableStock mocks base method.
+func (m *MockStockReservationService) AvailableStock(ctx context.Context, productID, holderID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailableStock", ctx, productID, holderID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AvailableStock indicates an expected call of AvailableStock.
+func (mr *MockStockReservationServiceMockRecorder) AvailableStock(ctx, productID, holderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailableStock", reflect.TypeOf((*MockStockReservationService)(nil).AvailableStock), ctx, productID, holderID)
+}
+
+// Release mocks base method.
+func (m *MockStockReservationService) Release(ctx context.Context, holderID, productID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, holderID, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockStockReservationServiceMockRecorder) Release(ctx, holderID, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockStockReservationService)(nil).Release), ctx, holderID, productID)
+}
+
+// ReleaseAll mocks base method.
+func (m *MockStockReservationService) ReleaseAll(ctx context.Context, holderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseAll", ctx, holderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseAll indicates an expected call of ReleaseAll.
+func (mr *MockStockReservationServiceMockRecorder) ReleaseAll(ctx, holderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseAll", reflect.TypeOf((*MockStockReservationService)(nil).ReleaseAll), ctx, holderID)
+}
+
+// ReleaseExpired mocks base method.
+func (m *MockStockReservationService) ReleaseExpired(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpired", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseExpired indicates an expected call of ReleaseExpired.
+func (mr *MockStockReservationServiceMockRecorder) ReleaseExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpired", reflect.TypeOf((*MockStockReservationService)(nil).ReleaseExpired), ctx)
+}
+
+// Reserve mocks base method.
+func (m *MockStockReservationService) Reserve(ctx context.Context, holderID, productID string, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, holderID, productID, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockStockReservationServiceMockRecorder) Reserve(ctx, holderID, productID, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockStockReservationService)(nil).Reserve), ctx, holderID, productID, count)
+}
+
+// SellableStock mocks base method.
+func (m *MockStockReservationService) SellableStock(ctx context.Context, productID, holderID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SellableStock", ctx, productID, holderID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SellableStock indicates an expected call of SellableStock.
+func (mr *MockStockReservationServiceMockRecorder) SellableStock(ctx, productID, holderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SellableStock", reflect.TypeOf((*MockStockReservationService)(nil).SellableStock), ctx, productID, holderID)
+}
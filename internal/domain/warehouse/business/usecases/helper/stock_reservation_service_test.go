@@ -0,0 +1,222 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_StockReservationService_NewStockReservationService_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepositoryMock := entities.NewMockProductRepository(ctrl)
+	reservationRepositoryMock := entities.NewMockReservationRepository(ctrl)
+	stockLedgerServiceMock := NewMockStockLedgerService(ctrl)
+
+	service, err := NewStockReservationService(nil, reservationRepositoryMock, stockLedgerServiceMock, time.Minute)
+	require.ErrorContains(t, err, "productRepository is nil")
+	require.Nil(t, service)
+
+	service, err = NewStockReservationService(productRepositoryMock, nil, stockLedgerServiceMock, time.Minute)
+	require.ErrorContains(t, err, "reservationRepository is nil")
+	require.Nil(t, service)
+
+	service, err = NewStockReservationService(productRepositoryMock, reservationRepositoryMock, nil, time.Minute)
+	require.ErrorContains(t, err, "stockLedgerService is nil")
+	require.Nil(t, service)
+
+	service, err = NewStockReservationService(productRepositoryMock, reservationRepositoryMock, stockLedgerServiceMock, 0)
+	require.ErrorContains(t, err, "lifetime must be greater than 0")
+	require.Nil(t, service)
+}
+
+func newStockReservationServiceForTest(t *testing.T, ctrl *gomock.Controller, now time.Time) (*StockReservationServiceImpl, *entities.MockReservationRepository, *MockStockLedgerService) {
+	product := &entities.Product{
+		ID:    "1",
+		Name:  "Product 1",
+		Price: money.New(1337, "EUR"),
+		Stock: 10,
+	}
+
+	productRepositoryMock := entities.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), product.ID).Return(product, nil).AnyTimes()
+
+	reservationRepositoryMock := entities.NewMockReservationRepository(ctrl)
+	reservationRepositoryMock.EXPECT().FindByProductId(gomock.Any(), product.ID).Return([]*entities.Reservation{
+		{ProductID: product.ID, HolderID: "1337", Count: 2, ExpiresAt: now.Add(time.Minute)},
+		{ProductID: product.ID, HolderID: "1338", Count: 3, ExpiresAt: now.Add(time.Minute)},
+		// expired reservations do not count
+		{ProductID: product.ID, HolderID: "1339", Count: 4, ExpiresAt: now.Add(-time.Minute)},
+	}, nil).AnyTimes()
+
+	stockLedgerServiceMock := NewMockStockLedgerService(ctrl)
+
+	service, err := NewStockReservationService(productRepositoryMock, reservationRepositoryMock, stockLedgerServiceMock, time.Minute)
+	require.NoError(t, err)
+
+	serviceImpl := service.(*StockReservationServiceImpl)
+	serviceImpl.now = func() time.Time {
+		return now
+	}
+
+	return serviceImpl, reservationRepositoryMock, stockLedgerServiceMock
+}
+
+func Test_StockReservationService_AvailableStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _, _ := newStockReservationServiceForTest(t, ctrl, time.Now())
+
+	available, err := service.AvailableStock(t.Context(), "1", "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, 7, available)
+
+	available, err = service.AvailableStock(t.Context(), "1", "1340")
+
+	require.NoError(t, err)
+	require.Equal(t, 5, available)
+}
+
+func Test_StockReservationService_SellableStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+
+	productRepositoryMock := entities.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "1").Return(&entities.Product{
+		ID:          "1",
+		Stock:       -2,
+		StockPolicy: entities.StockPolicy{Type: entities.StockPolicyTypeBackorder, MaxBackorder: 10},
+	}, nil).AnyTimes()
+
+	reservationRepositoryMock := entities.NewMockReservationRepository(ctrl)
+	reservationRepositoryMock.EXPECT().FindByProductId(gomock.Any(), "1").Return([]*entities.Reservation{
+		{ProductID: "1", HolderID: "1337", Count: 3, ExpiresAt: now.Add(time.Minute)},
+	}, nil).AnyTimes()
+	reservationRepositoryMock.EXPECT().Find(gomock.Any(), "1338", "1").Return(nil, &domainerror.NotFoundError{Resource: entities.ReservationResource, ID: "1"})
+
+	stockLedgerServiceMock := NewMockStockLedgerService(ctrl)
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &entities.StockMovement{
+		ProductID: "1", Type: entities.StockMovementTypeReservation, Quantity: 5, Reference: "1338",
+	}).Return(nil)
+
+	service, err := NewStockReservationService(productRepositoryMock, reservationRepositoryMock, stockLedgerServiceMock, time.Minute)
+	require.NoError(t, err)
+	service.(*StockReservationServiceImpl).now = func() time.Time {
+		return now
+	}
+
+	// the backordered units of the orders and the reserved units of the other holders are not sellable
+	sellable, err := service.SellableStock(t.Context(), "1", "1338")
+	require.NoError(t, err)
+	require.Equal(t, 5, sellable)
+
+	available, err := service.AvailableStock(t.Context(), "1", "1338")
+	require.NoError(t, err)
+	require.Zero(t, available)
+
+	reservationRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, service.Reserve(t.Context(), "1338", "1", 5))
+	require.ErrorAs(t, service.Reserve(t.Context(), "1338", "1", 6), new(*domainerror.OutOfStockError))
+}
+
+func Test_StockReservationService_Reserve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+
+	service, reservationRepositoryMock, stockLedgerServiceMock := newStockReservationServiceForTest(t, ctrl, now)
+
+	reservationRepositoryMock.EXPECT().Find(gomock.Any(), "1337", "1").Return(&entities.Reservation{ProductID: "1", HolderID: "1337", Count: 2}, nil)
+	reservationRepositoryMock.EXPECT().Save(gomock.Any(), &entities.Reservation{
+		ProductID: "1",
+		HolderID:  "1337",
+		Count:     7,
+		ExpiresAt: now.Add(time.Minute),
+	}).Return(nil)
+	// only the additional units are recorded
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &entities.StockMovement{
+		ProductID: "1", Type: entities.StockMovementTypeReservation, Quantity: 5, Reference: "1337",
+	}).Return(nil)
+
+	err := service.Reserve(t.Context(), "1337", "1", 7)
+
+	require.NoError(t, err)
+
+	err = service.Reserve(t.Context(), "1337", "1", 8)
+
+	require.ErrorAs(t, err, new(*domainerror.OutOfStockError))
+}
+
+func Test_StockReservationService_Release(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, reservationRepositoryMock, stockLedgerServiceMock := newStockReservationServiceForTest(t, ctrl, time.Now())
+
+	reservationRepositoryMock.EXPECT().Find(gomock.Any(), "1337", "1").Return(&entities.Reservation{ProductID: "1", HolderID: "1337", Count: 2}, nil)
+	reservationRepositoryMock.EXPECT().Delete(gomock.Any(), "1337", "1").Return(nil)
+	reservationRepositoryMock.EXPECT().Find(gomock.Any(), "1340", "1").Return(nil, &domainerror.NotFoundError{Resource: entities.ReservationResource})
+	reservationRepositoryMock.EXPECT().Delete(gomock.Any(), "1340", "1").Return(&domainerror.NotFoundError{Resource: entities.ReservationResource})
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &entities.StockMovement{
+		ProductID: "1", Type: entities.StockMovementTypeRelease, Quantity: -2, Reference: "1337",
+	}).Return(nil)
+
+	require.NoError(t, service.Release(t.Context(), "1337", "1"))
+	// releasing a missing reservation is not an error
+	require.NoError(t, service.Release(t.Context(), "1340", "1"))
+}
+
+func Test_StockReservationService_ReleaseAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, reservationRepositoryMock, stockLedgerServiceMock := newStockReservationServiceForTest(t, ctrl, time.Now())
+
+	reservationRepositoryMock.EXPECT().FindByHolderId(gomock.Any(), "1337").Return([]*entities.Reservation{
+		{ProductID: "1", HolderID: "1337", Count: 2},
+		{ProductID: "2", HolderID: "1337", Count: 1},
+	}, nil)
+	reservationRepositoryMock.EXPECT().Delete(gomock.Any(), "1337", "1").Return(nil)
+	reservationRepositoryMock.EXPECT().Delete(gomock.Any(), "1337", "2").Return(nil)
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &entities.StockMovement{
+		ProductID: "1", Type: entities.StockMovementTypeRelease, Quantity: -2, Reference: "1337",
+	}).Return(nil)
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &entities.StockMovement{
+		ProductID: "2", Type: entities.StockMovementTypeRelease, Quantity: -1, Reference: "1337",
+	}).Return(nil)
+
+	require.NoError(t, service.ReleaseAll(t.Context(), "1337"))
+}
+
+func Test_StockReservationService_ReleaseExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+
+	service, reservationRepositoryMock, stockLedgerServiceMock := newStockReservationServiceForTest(t, ctrl, now)
+
+	reservationRepositoryMock.EXPECT().DeleteExpired(gomock.Any(), now).Return([]*entities.Reservation{
+		{ProductID: "1", HolderID: "1339", Count: 4, ExpiresAt: now.Add(-time.Minute)},
+	}, nil)
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &entities.StockMovement{
+		ProductID: "1", Type: entities.StockMovementTypeRelease, Quantity: -4, Reference: "1339",
+	}).Return(nil)
+
+	released, err := service.ReleaseExpired(t.Context())
+
+	require.NoError(t, err)
+	require.Equal(t, 1, released)
+}
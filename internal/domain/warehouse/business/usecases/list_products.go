@@ -0,0 +1,181 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+// ProductSort is the order of a product list, a leading minus sorts descending
+type ProductSort string
+
+const (
+	ProductSortID        ProductSort = "id"
+	ProductSortName      ProductSort = "name"
+	ProductSortNameDesc  ProductSort = "-name"
+	ProductSortPrice     ProductSort = "price"
+	ProductSortPriceDesc ProductSort = "-price"
+)
+
+const (
+	DefaultProductPageSize = 20
+	MaxProductPageSize     = 100
+)
+
+type ListProductsUseCaseInput struct {
+	// Query only lists the products whose name contains it, ignoring the case
+	Query string
+	// Sort is empty or one of the ProductSort values, products with the same sort value are sorted by id
+	Sort ProductSort
+	// Page starts at 1, 0 is the first page
+	Page int
+	// PageSize is at most MaxProductPageSize, 0 uses DefaultProductPageSize
+	PageSize int
+}
+
+type ListProductsUseCaseOutput struct {
+	Products []*dto.ProductDTO
+	Page     *dto.Page
+}
+
+type ListProductsUseCase interface {
+	Execute(ctx context.Context, input *ListProductsUseCaseInput) (*ListProductsUseCaseOutput, error)
+}
+
+func NewListProductsUseCaseImpl(productOutputService helper.ProductOutputService, productRepository warehouse.ProductRepository) ListProductsUseCase {
+	return &ListProductsUseCaseImpl{
+		productOutputService: productOutputService,
+		productRepository:    productRepository,
+	}
+}
+
+var _ ListProductsUseCase = (*ListProductsUseCaseImpl)(nil)
+
+type ListProductsUseCaseImpl struct {
+	productOutputService helper.ProductOutputService
+	productRepository    warehouse.ProductRepository
+}
+
+func (useCase *ListProductsUseCaseImpl) validate(input *ListProductsUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.Page < 0 {
+		return fmt.Errorf("input parameter Page is invalid (must not be negative)")
+	} else if input.PageSize < 0 || input.PageSize > MaxProductPageSize {
+		return fmt.Errorf("input parameter PageSize is invalid (must be between 1 and %d)", MaxProductPageSize)
+	}
+
+	switch input.Sort {
+	case "", ProductSortID, ProductSortName, ProductSortNameDesc, ProductSortPrice, ProductSortPriceDesc:
+		return nil
+	default:
+		return fmt.Errorf("input parameter Sort %q is invalid (must be id, name, -name, price or -price)", input.Sort)
+	}
+}
+
+// Execute filters and sorts all products of the repository, the catalog is small enough to do that in memory
+func (useCase *ListProductsUseCaseImpl) Execute(ctx context.Context, input *ListProductsUseCaseInput) (*ListProductsUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	products, productsErr := useCase.productRepository.FindAll(ctx)
+	if productsErr != nil {
+		return nil, productsErr
+	}
+
+	products = filterProducts(products, input.Query)
+	sortProducts(products, input.Sort)
+
+	page := newPage(input.Page, input.PageSize, len(products))
+
+	// the page number is compared before the multiplication, a huge page would overflow the offset
+	start := len(products)
+	if page.Number-1 < len(products)/page.Size+1 {
+		start = min((page.Number-1)*page.Size, len(products))
+	}
+	end := min(start+page.Size, len(products))
+
+	productDTOs := make([]*dto.ProductDTO, 0, end-start)
+	for _, product := range products[start:end] {
+		productDTO, productOutputServiceErr := useCase.productOutputService.CreateProductDTO(product)
+		if productOutputServiceErr != nil {
+			return nil, productOutputServiceErr
+		}
+		productDTOs = append(productDTOs, productDTO)
+	}
+
+	output := &ListProductsUseCaseOutput{
+		Products: productDTOs,
+		Page:     page,
+	}
+
+	return output, nil
+}
+
+// filterProducts returns the active products whose name contains the query
+func filterProducts(products []*warehouse.Product, query string) []*warehouse.Product {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	filteredProducts := make([]*warehouse.Product, 0, len(products))
+	for _, product := range products {
+		if product.IsActive() && strings.Contains(strings.ToLower(product.Name), query) {
+			filteredProducts = append(filteredProducts, product)
+		}
+	}
+
+	return filteredProducts
+}
+
+// sortProducts keeps the order of the repository, the id, for products with the same sort value
+func sortProducts(products []*warehouse.Product, productSort ProductSort) {
+	var less func(a *warehouse.Product, b *warehouse.Product) bool
+
+	switch productSort {
+	case ProductSortName, ProductSortNameDesc:
+		less = func(a *warehouse.Product, b *warehouse.Product) bool {
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+	case ProductSortPrice, ProductSortPriceDesc:
+		// prices of different currencies cannot be compared, so they are grouped by currency
+		less = func(a *warehouse.Product, b *warehouse.Product) bool {
+			if a.Price.GetCurrency() != b.Price.GetCurrency() {
+				return a.Price.GetCurrency() < b.Price.GetCurrency()
+			}
+			return a.Price.GetAmount() < b.Price.GetAmount()
+		}
+	default:
+		return
+	}
+
+	descending := strings.HasPrefix(string(productSort), "-")
+	sort.SliceStable(products, func(i, j int) bool {
+		if descending {
+			return less(products[j], products[i])
+		}
+		return less(products[i], products[j])
+	})
+}
+
+func newPage(number int, size int, totalItems int) *dto.Page {
+	if number == 0 {
+		number = 1
+	}
+	if size == 0 {
+		size = DefaultProductPageSize
+	}
+
+	return &dto.Page{
+		Number:     number,
+		Size:       size,
+		TotalItems: totalItems,
+		TotalPages: (totalItems + size - 1) / size,
+	}
+}
@@ -0,0 +1,181 @@
+package usecases
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+// newTestCatalog returns the products sorted by id like the repositories
+func newTestCatalog() []*warehouse.Product {
+	return []*warehouse.Product{
+		{ID: "A1", Name: "Coffee Mug", Price: money.New(899, "EUR"), Stock: 20},
+		{ID: "A2", Name: "apple juice", Price: money.New(199, "EUR"), Stock: 0},
+		{ID: "A3", Name: "Book", Price: money.New(1999, "EUR"), Stock: 2},
+		{ID: "A4", Name: "Coffee Beans", Price: money.New(899, "EUR"), Stock: 8},
+		{ID: "A5", Name: "Tea", Price: money.New(500, "USD"), Stock: 8},
+		// deactivated products are never listed
+		{ID: "A6", Name: "Coffee Filter", Price: money.New(299, "EUR"), Stock: 8, Deactivated: true},
+	}
+}
+
+func newTestListProductsUseCase(t *testing.T, products []*warehouse.Product, err error) ListProductsUseCase {
+	ctrl := gomock.NewController(t)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().FindAll(gomock.Any()).Return(products, err).AnyTimes()
+
+	return NewListProductsUseCaseImpl(helper.NewProductOutputService(), productRepositoryMock)
+}
+
+func productIDs(products []*dto.ProductDTO) []string {
+	ids := make([]string, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+
+	return ids
+}
+
+func Test_ListProductsUseCase_NewListProductsUseCaseImpl_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *ListProductsUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"input parameter Page is invalid (must not be negative)": {
+			input: &ListProductsUseCaseInput{Page: -1},
+		},
+		"input parameter PageSize is invalid (must be between 1 and 100)": {
+			input: &ListProductsUseCaseInput{PageSize: 101},
+		},
+		`input parameter Sort "stock" is invalid`: {
+			input: &ListProductsUseCaseInput{Sort: "stock"},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			useCase := newTestListProductsUseCase(t, newTestCatalog(), nil)
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.Error(t, err)
+			require.ErrorContains(t, err, errorString)
+		})
+	}
+}
+
+func Test_ListProductsUseCase(t *testing.T) {
+	testCases := map[string]struct {
+		input *ListProductsUseCaseInput
+		ids   []string
+		page  *dto.Page
+	}{
+		"defaults": {
+			input: &ListProductsUseCaseInput{},
+			ids:   []string{"A1", "A2", "A3", "A4", "A5"},
+			page:  &dto.Page{Number: 1, Size: DefaultProductPageSize, TotalItems: 5, TotalPages: 1},
+		},
+		"sort by name ignoring the case": {
+			input: &ListProductsUseCaseInput{Sort: ProductSortName},
+			ids:   []string{"A2", "A3", "A4", "A1", "A5"},
+			page:  &dto.Page{Number: 1, Size: DefaultProductPageSize, TotalItems: 5, TotalPages: 1},
+		},
+		"sort by name descending": {
+			input: &ListProductsUseCaseInput{Sort: ProductSortNameDesc},
+			ids:   []string{"A5", "A1", "A4", "A3", "A2"},
+			page:  &dto.Page{Number: 1, Size: DefaultProductPageSize, TotalItems: 5, TotalPages: 1},
+		},
+		"sort by price per currency and id": {
+			input: &ListProductsUseCaseInput{Sort: ProductSortPrice},
+			ids:   []string{"A2", "A1", "A4", "A3", "A5"},
+			page:  &dto.Page{Number: 1, Size: DefaultProductPageSize, TotalItems: 5, TotalPages: 1},
+		},
+		"sort by price descending": {
+			input: &ListProductsUseCaseInput{Sort: ProductSortPriceDesc},
+			ids:   []string{"A5", "A3", "A1", "A4", "A2"},
+			page:  &dto.Page{Number: 1, Size: DefaultProductPageSize, TotalItems: 5, TotalPages: 1},
+		},
+		"search by name": {
+			input: &ListProductsUseCaseInput{Query: " coffee "},
+			ids:   []string{"A1", "A4"},
+			page:  &dto.Page{Number: 1, Size: DefaultProductPageSize, TotalItems: 2, TotalPages: 1},
+		},
+		"search without match": {
+			input: &ListProductsUseCaseInput{Query: "chair"},
+			ids:   []string{},
+			page:  &dto.Page{Number: 1, Size: DefaultProductPageSize, TotalItems: 0, TotalPages: 0},
+		},
+		"second page": {
+			input: &ListProductsUseCaseInput{Page: 2, PageSize: 2},
+			ids:   []string{"A3", "A4"},
+			page:  &dto.Page{Number: 2, Size: 2, TotalItems: 5, TotalPages: 3},
+		},
+		"last page": {
+			input: &ListProductsUseCaseInput{Sort: ProductSortName, Page: 3, PageSize: 2},
+			ids:   []string{"A5"},
+			page:  &dto.Page{Number: 3, Size: 2, TotalItems: 5, TotalPages: 3},
+		},
+		"page after the last page": {
+			input: &ListProductsUseCaseInput{Page: 4, PageSize: 2},
+			ids:   []string{},
+			page:  &dto.Page{Number: 4, Size: 2, TotalItems: 5, TotalPages: 3},
+		},
+		"huge page does not overflow the offset": {
+			input: &ListProductsUseCaseInput{Page: 461168601842738792, PageSize: 20},
+			ids:   []string{},
+			page:  &dto.Page{Number: 461168601842738792, Size: 20, TotalItems: 5, TotalPages: 1},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			useCase := newTestListProductsUseCase(t, newTestCatalog(), nil)
+
+			output, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.ids, productIDs(output.Products))
+			require.Equal(t, testCase.page, output.Page)
+		})
+	}
+}
+
+func Test_ListProductsUseCase_Availability(t *testing.T) {
+	useCase := newTestListProductsUseCase(t, newTestCatalog(), nil)
+
+	output, err := useCase.Execute(t.Context(), &ListProductsUseCaseInput{})
+
+	require.NoError(t, err)
+
+	availabilities := map[string]string{}
+	for _, product := range output.Products {
+		availabilities[product.ID] = product.Availability
+	}
+
+	require.Equal(t, map[string]string{
+		"A1": "in_stock",
+		"A2": "out_of_stock",
+		"A3": "low_stock",
+		"A4": "in_stock",
+		"A5": "in_stock",
+	}, availabilities)
+}
+
+func Test_ListProductsUseCase_ProductRepositoryFails(t *testing.T) {
+	useCase := newTestListProductsUseCase(t, nil, fmt.Errorf("product repository error"))
+
+	output, err := useCase.Execute(t.Context(), &ListProductsUseCaseInput{})
+
+	require.EqualError(t, err, "product repository error")
+	require.Nil(t, output)
+}
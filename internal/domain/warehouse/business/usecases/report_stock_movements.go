@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type ReportStockMovementsUseCaseInput struct {
+	ProductID string
+	// From is optional, the zero time starts the report with the first movement
+	From time.Time
+	// To is optional, the zero time ends the report now
+	To time.Time
+}
+
+type ReportStockMovementsUseCaseOutput struct {
+	Report *dto.StockReportDTO
+}
+
+// ReportStockMovementsUseCase returns the stock movements of a product in a time range with the balances before and after them
+type ReportStockMovementsUseCase interface {
+	Execute(ctx context.Context, input *ReportStockMovementsUseCaseInput) (*ReportStockMovementsUseCaseOutput, error)
+}
+
+func NewReportStockMovementsUseCaseImpl(productRepository warehouse.ProductRepository, stockMovementRepository warehouse.StockMovementRepository) ReportStockMovementsUseCase {
+	return &ReportStockMovementsUseCaseImpl{
+		productRepository:       productRepository,
+		stockMovementRepository: stockMovementRepository,
+		now:                     time.Now,
+	}
+}
+
+var _ ReportStockMovementsUseCase = (*ReportStockMovementsUseCaseImpl)(nil)
+
+type ReportStockMovementsUseCaseImpl struct {
+	productRepository       warehouse.ProductRepository
+	stockMovementRepository warehouse.StockMovementRepository
+	now                     func() time.Time
+}
+
+func (useCase *ReportStockMovementsUseCaseImpl) validate(input *ReportStockMovementsUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.ProductID == "" {
+		return fmt.Errorf("input parameter ProductID is empty")
+	} else if !input.To.IsZero() && !input.To.After(input.From) {
+		return fmt.Errorf("input parameter To must be after From")
+	}
+
+	return nil
+}
+
+func (useCase *ReportStockMovementsUseCaseImpl) Execute(ctx context.Context, input *ReportStockMovementsUseCaseInput) (*ReportStockMovementsUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	from := input.From.UTC()
+	to := input.To.UTC()
+	if input.To.IsZero() {
+		to = useCase.now().UTC()
+	}
+
+	// deactivated products are reported as well, only an unknown product is not found
+	_, productRepositoryErr := useCase.productRepository.Find(ctx, input.ProductID)
+	if productRepositoryErr != nil {
+		return nil, productRepositoryErr
+	}
+
+	opening := &warehouse.StockBalance{}
+	if !input.From.IsZero() {
+		balance, balanceErr := useCase.stockMovementRepository.Balance(ctx, input.ProductID, from)
+		if balanceErr != nil {
+			return nil, balanceErr
+		}
+		opening = balance
+	}
+
+	movements, movementsErr := useCase.stockMovementRepository.FindByProductId(ctx, input.ProductID, from, to)
+	if movementsErr != nil {
+		return nil, movementsErr
+	}
+
+	closing := &warehouse.StockBalance{Stock: opening.Stock, Reserved: opening.Reserved, Locations: maps.Clone(opening.Locations)}
+	totals := make(map[string]int)
+	movementDTOs := make([]*dto.StockMovementDTO, 0, len(movements))
+	for _, movement := range movements {
+		closing.Add(movement)
+		totals[string(movement.Type)] += movement.Quantity
+
+		movementDTOs = append(movementDTOs, &dto.StockMovementDTO{
+			ID:         movement.ID,
+			Type:       string(movement.Type),
+			Quantity:   movement.Quantity,
+			Reason:     string(movement.Reason),
+			LocationID: movement.LocationID,
+			Reference:  movement.Reference,
+			CreatedAt:  movement.CreatedAt,
+			Stock:      closing.Stock,
+		})
+	}
+
+	output := &ReportStockMovementsUseCaseOutput{
+		Report: &dto.StockReportDTO{
+			ProductID: input.ProductID,
+			From:      from,
+			To:        to,
+			Opening:   newStockBalanceDTO(opening),
+			Closing:   newStockBalanceDTO(closing),
+			Totals:    totals,
+			Movements: movementDTOs,
+		},
+	}
+
+	return output, nil
+}
+
+func newStockBalanceDTO(balance *warehouse.StockBalance) *dto.StockBalanceDTO {
+	locations := maps.Clone(balance.Locations)
+	if locations == nil {
+		locations = make(map[string]int)
+	}
+
+	return &dto.StockBalanceDTO{
+		Stock:     balance.Stock,
+		Reserved:  balance.Reserved,
+		Locations: locations,
+	}
+}
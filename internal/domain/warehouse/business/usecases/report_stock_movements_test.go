@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+func Test_ReportStockMovementsUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(&warehouse.Product{ID: "A1", Stock: 6}, nil)
+
+	stockMovementRepositoryMock := warehouse.NewMockStockMovementRepository(ctrl)
+	stockMovementRepositoryMock.EXPECT().Balance(gomock.Any(), "A1", from).Return(&warehouse.StockBalance{
+		Stock: 10, Reserved: 1, Movements: 4, Locations: map[string]int{warehouse.DefaultLocationID: 8, "hamburg": 2},
+	}, nil)
+	stockMovementRepositoryMock.EXPECT().FindByProductId(gomock.Any(), "A1", from, to).Return([]*warehouse.StockMovement{
+		{ID: "m1", ProductID: "A1", Type: warehouse.StockMovementTypeReservation, Quantity: 2, Reference: "1337", CreatedAt: from.Add(time.Hour)},
+		{ID: "m2", ProductID: "A1", Type: warehouse.StockMovementTypeSale, Quantity: -2, LocationID: "hamburg", Reference: "order-1", CreatedAt: from.Add(2 * time.Hour)},
+		{ID: "m3", ProductID: "A1", Type: warehouse.StockMovementTypeRelease, Quantity: -2, Reference: "1337", CreatedAt: from.Add(2 * time.Hour)},
+		{ID: "m4", ProductID: "A1", Type: warehouse.StockMovementTypeAdjustment, Quantity: -2, Reason: warehouse.StockAdjustmentReasonDamaged, LocationID: warehouse.DefaultLocationID, CreatedAt: from.Add(3 * time.Hour)},
+	}, nil)
+
+	useCase := NewReportStockMovementsUseCaseImpl(productRepositoryMock, stockMovementRepositoryMock)
+
+	output, err := useCase.Execute(t.Context(), &ReportStockMovementsUseCaseInput{ProductID: "A1", From: from, To: to})
+
+	require.NoError(t, err)
+
+	report := output.Report
+	require.Equal(t, 10, report.Opening.Stock)
+	require.Equal(t, 1, report.Opening.Reserved)
+	require.Equal(t, 6, report.Closing.Stock)
+	require.Equal(t, 1, report.Closing.Reserved)
+	require.Equal(t, map[string]int{warehouse.DefaultLocationID: 8, "hamburg": 2}, report.Opening.Locations)
+	require.Equal(t, map[string]int{warehouse.DefaultLocationID: 6, "hamburg": 0}, report.Closing.Locations)
+	require.Equal(t, map[string]int{"reservation": 2, "sale": -2, "release": -2, "adjustment": -2}, report.Totals)
+
+	require.Len(t, report.Movements, 4)
+	require.Equal(t, "damaged", report.Movements[3].Reason)
+	require.Equal(t, "hamburg", report.Movements[1].LocationID)
+	// the stock after each movement, reservations do not change it
+	require.Equal(t, 10, report.Movements[0].Stock)
+	require.Equal(t, 8, report.Movements[1].Stock)
+	require.Equal(t, 8, report.Movements[2].Stock)
+	require.Equal(t, 6, report.Movements[3].Stock)
+}
+
+func Test_ReportStockMovementsUseCase_WithoutRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(&warehouse.Product{ID: "A1", Deactivated: true}, nil)
+
+	// the report starts with the first movement, so no opening balance is read
+	stockMovementRepositoryMock := warehouse.NewMockStockMovementRepository(ctrl)
+	stockMovementRepositoryMock.EXPECT().FindByProductId(gomock.Any(), "A1", time.Time{}, now).Return([]*warehouse.StockMovement{}, nil)
+
+	useCase := NewReportStockMovementsUseCaseImpl(productRepositoryMock, stockMovementRepositoryMock)
+	useCase.(*ReportStockMovementsUseCaseImpl).now = func() time.Time {
+		return now
+	}
+
+	output, err := useCase.Execute(t.Context(), &ReportStockMovementsUseCaseInput{ProductID: "A1"})
+
+	require.NoError(t, err)
+	require.Equal(t, now, output.Report.To)
+	require.Equal(t, 0, output.Report.Closing.Stock)
+	require.Empty(t, output.Report.Movements)
+}
+
+func Test_ReportStockMovementsUseCase_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *ReportStockMovementsUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"input parameter ProductID is empty": {
+			input: &ReportStockMovementsUseCaseInput{},
+		},
+		"input parameter To must be after From": {
+			input: &ReportStockMovementsUseCaseInput{
+				ProductID: "A1",
+				From:      time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC),
+				To:        time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := NewReportStockMovementsUseCaseImpl(warehouse.NewMockProductRepository(ctrl), warehouse.NewMockStockMovementRepository(ctrl))
+
+			output, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.ErrorAs(t, err, new(*domainerror.ValidationError))
+			require.ErrorContains(t, err, errorString)
+			require.Nil(t, output)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+type SeedProductsUseCaseInput struct {
+	Products []*dto.ProductRecord
+	// SkipExisting only creates the missing products,
+	// so the prices and the stock changed since the last seeding are kept
+	SkipExisting bool
+}
+
+// SeedProductsUseCaseOutput counts the products by what the seeding did with them
+type SeedProductsUseCaseOutput struct {
+	Created   int
+	Updated   int
+	Unchanged int
+	Skipped   int
+}
+
+// SeedProductsUseCase upserts the products of fixture files.
+// Seeding the same records again leaves the products unchanged, so it can run on every startup.
+type SeedProductsUseCase interface {
+	Execute(ctx context.Context, input *SeedProductsUseCaseInput) (*SeedProductsUseCaseOutput, error)
+}
+
+func NewSeedProductsUseCaseImpl(productFactory warehouse.ProductFactory, productRepository warehouse.ProductRepository, stockLedgerService helper.StockLedgerService) SeedProductsUseCase {
+	return &SeedProductsUseCaseImpl{
+		productFactory:     productFactory,
+		productRepository:  productRepository,
+		stockLedgerService: stockLedgerService,
+	}
+}
+
+var _ SeedProductsUseCase = (*SeedProductsUseCaseImpl)(nil)
+
+type SeedProductsUseCaseImpl struct {
+	productFactory     warehouse.ProductFactory
+	productRepository  warehouse.ProductRepository
+	stockLedgerService helper.StockLedgerService
+}
+
+// validate checks all records before any product is saved and returns all invalid records at once
+func (useCase *SeedProductsUseCaseImpl) validate(input *SeedProductsUseCaseInput) ([]*warehouse.Product, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input is nil")
+	}
+
+	var errs []error
+	products := make([]*warehouse.Product, 0, len(input.Products))
+	productIDs := make(map[string]bool, len(input.Products))
+
+	for i, record := range input.Products {
+		if record == nil {
+			errs = append(errs, fmt.Errorf("product %d is nil", i+1))
+			continue
+		}
+
+		product, err := useCase.newProduct(record)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("product %d (%s): %w", i+1, record.ID, err))
+			continue
+		}
+
+		if productIDs[product.ID] {
+			errs = append(errs, fmt.Errorf("product %d (%s): id is duplicated", i+1, record.ID))
+			continue
+		}
+		productIDs[product.ID] = true
+
+		products = append(products, product)
+	}
+
+	return products, errors.Join(errs...)
+}
+
+func (useCase *SeedProductsUseCaseImpl) newProduct(record *dto.ProductRecord) (*warehouse.Product, error) {
+	price, err := money.Parse(record.Price, record.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("price is invalid: %w", err)
+	}
+
+	product, err := useCase.productFactory.NewProduct(record.ID, record.Name, price, record.Stock, tax.TaxClass(record.TaxClass))
+	if err != nil {
+		return nil, err
+	}
+
+	stockPolicy := warehouse.StockPolicy{
+		Type:         warehouse.StockPolicyType(record.StockPolicy),
+		MaxBackorder: record.MaxBackorder,
+	}
+	if record.RestockDate != "" {
+		stockPolicy.RestockDate, err = time.Parse(time.DateOnly, record.RestockDate)
+		if err != nil {
+			return nil, fmt.Errorf("restock date %q is not a date like 2026-12-01", record.RestockDate)
+		}
+	}
+
+	err = product.SetStockPolicy(stockPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (useCase *SeedProductsUseCaseImpl) Execute(ctx context.Context, input *SeedProductsUseCaseInput) (*SeedProductsUseCaseOutput, error) {
+	// validate input first
+	products, err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	output := &SeedProductsUseCaseOutput{}
+
+	for _, product := range products {
+		existingProduct, findErr := useCase.productRepository.Find(ctx, product.ID)
+		if findErr != nil && !domainerror.IsNotFound(findErr, warehouse.ProductResource) {
+			return nil, findErr
+		}
+
+		switch {
+		case existingProduct == nil:
+			output.Created++
+		case input.SkipExisting:
+			output.Skipped++
+			continue
+		case isSameProduct(existingProduct, product):
+			output.Unchanged++
+			continue
+		default:
+			output.Updated++
+		}
+
+		if existingProduct == nil {
+			saveErr := saveNewProduct(ctx, useCase.productRepository, useCase.stockLedgerService, product, warehouse.DefaultLocationID)
+			if saveErr != nil {
+				return nil, saveErr
+			}
+			continue
+		}
+
+		// the product is changed under the lock of the stock ledger, so the stock sold since Find is not overwritten
+		// and the correction is computed from the current stock
+		var stockChange int
+		_, updateErr := useCase.stockLedgerService.UpdateProduct(ctx, product.ID, func(existingProduct *warehouse.Product) error {
+			// the stock of the fixture is reached by recording the difference as correction of the default location,
+			// the backordered units of a negative stock are kept, so they are still shipped after the next receipt
+			stockChange = product.Stock - max(existingProduct.Stock, 0)
+
+			// the fixtures cannot deactivate products, so a deactivated product stays deactivated
+			existingProduct.Name = product.Name
+			existingProduct.Price = product.Price
+			existingProduct.TaxClass = product.TaxClass
+
+			stockPolicyErr := existingProduct.SetStockPolicy(product.StockPolicy)
+			if stockPolicyErr != nil {
+				return domainerror.NewValidationError("input validation error: %s", stockPolicyErr)
+			}
+
+			return nil
+		})
+		if updateErr != nil {
+			return nil, updateErr
+		}
+
+		if stockChange != 0 {
+			recordErr := useCase.stockLedgerService.Record(ctx, &warehouse.StockMovement{
+				ProductID:  product.ID,
+				Type:       warehouse.StockMovementTypeAdjustment,
+				Quantity:   stockChange,
+				Reason:     warehouse.StockAdjustmentReasonCorrection,
+				LocationID: warehouse.DefaultLocationID,
+			})
+			if recordErr != nil {
+				return nil, recordErr
+			}
+		}
+	}
+
+	return output, nil
+}
+
+// isSameProduct treats a missing tax class like the standard class, the fixtures do not contain the deactivation.
+// The stock of the fixture does not contain the backordered units.
+func isSameProduct(existingProduct *warehouse.Product, product *warehouse.Product) bool {
+	normalizedProduct := *existingProduct
+	normalizedProduct.TaxClass = tax.NormalizeClass(normalizedProduct.TaxClass)
+	normalizedProduct.Deactivated = product.Deactivated
+	normalizedProduct.Stock = max(normalizedProduct.Stock, 0)
+
+	if !normalizedProduct.StockPolicy.Equal(product.StockPolicy) {
+		return false
+	}
+	normalizedProduct.StockPolicy = product.StockPolicy
+
+	return normalizedProduct == *product
+}
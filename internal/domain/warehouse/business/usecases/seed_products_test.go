@@ -0,0 +1,306 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_SeedProductsUseCase_ReturnsValidationError(t *testing.T) {
+	validRecord := func() *dto.ProductRecord {
+		return &dto.ProductRecord{ID: "A12341", Name: "Product 1", Price: "11.99", Currency: "EUR", Stock: 10}
+	}
+
+	testCases := map[string]struct {
+		input *SeedProductsUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"product 1 is nil": {
+			input: &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{nil}},
+		},
+		"product 1 (): id is empty": {
+			input: &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{{Name: "Product 1", Price: "11.99", Currency: "EUR"}}},
+		},
+		"name is empty": {
+			input: &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{{ID: "A12341", Price: "11.99", Currency: "EUR"}}},
+		},
+		"price is invalid": {
+			input: &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{{ID: "A12341", Name: "Product 1", Price: "cheap", Currency: "EUR"}}},
+		},
+		"currency is empty": {
+			input: &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{{ID: "A12341", Name: "Product 1", Price: "11.99"}}},
+		},
+		"price -1.00 EUR is negative": {
+			input: &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{{ID: "A12341", Name: "Product 1", Price: "-1", Currency: "EUR"}}},
+		},
+		"stock -1 is negative": {
+			input: &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{{ID: "A12341", Name: "Product 1", Price: "11.99", Currency: "EUR", Stock: -1}}},
+		},
+		"tax class \"luxury\" is unknown": {
+			input: &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{{ID: "A12341", Name: "Product 1", Price: "11.99", Currency: "EUR", TaxClass: "luxury"}}},
+		},
+		"restock date \"soon\" is not a date like 2026-12-01": {
+			input: &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{{ID: "A12341", Name: "Product 1", Price: "11.99", Currency: "EUR", StockPolicy: "backorder", RestockDate: "soon", MaxBackorder: 5}}},
+		},
+		"max backorder must be greater than 0": {
+			input: &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{{ID: "A12341", Name: "Product 1", Price: "11.99", Currency: "EUR", StockPolicy: "backorder"}}},
+		},
+		"product 2 (A12341): id is duplicated": {
+			input: &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{validRecord(), validRecord()}},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// nothing is saved if any record is invalid
+			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+
+			useCase := NewSeedProductsUseCaseImpl(warehouse.NewProductFactory(), productRepositoryMock, helper.NewMockStockLedgerService(ctrl))
+
+			output, err := useCase.Execute(t.Context(), testCase.input)
+
+			var validationErr *domainerror.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.ErrorContains(t, err, errorString)
+			require.Nil(t, output)
+		})
+	}
+}
+
+func Test_SeedProductsUseCase_ReturnsAllInvalidRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := NewSeedProductsUseCaseImpl(warehouse.NewProductFactory(), warehouse.NewMockProductRepository(ctrl), helper.NewMockStockLedgerService(ctrl))
+
+	_, err := useCase.Execute(t.Context(), &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{
+		{ID: "A12341", Price: "11.99", Currency: "EUR"},
+		{ID: "A12342", Name: "Product 2", Price: "12.99", Currency: "EUR", Stock: -5},
+	}})
+
+	require.ErrorContains(t, err, "product 1 (A12341): name is empty")
+	require.ErrorContains(t, err, "product 2 (A12342): stock -5 is negative")
+}
+
+func Test_SeedProductsUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	unchangedProduct := &warehouse.Product{ID: "A12341", Name: "Product 1", Price: money.New(1199, "EUR"), Stock: 10}
+	updatedProduct := &warehouse.Product{ID: "A12342", Name: "Product 2", Price: money.New(1299, "EUR"), Stock: 25, TaxClass: tax.TaxClassReduced}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12341").Return(unchangedProduct, nil)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12342").Return(updatedProduct, nil)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12343").Return(nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: "A12343"})
+	productRepositoryMock.EXPECT().Save(gomock.Any(), &warehouse.Product{
+		ID: "A12343", Name: "Product 3", Price: money.New(1399, "EUR"), Stock: 0, TaxClass: tax.TaxClassStandard,
+	})
+
+	stockLedgerServiceMock := helper.NewMockStockLedgerService(ctrl)
+	expectUpdateProduct(stockLedgerServiceMock, updatedProduct)
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &warehouse.StockMovement{
+		ProductID: "A12342", Type: warehouse.StockMovementTypeAdjustment, Quantity: -5, Reason: warehouse.StockAdjustmentReasonCorrection, LocationID: warehouse.DefaultLocationID,
+	})
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &warehouse.StockMovement{
+		ProductID: "A12343", Type: warehouse.StockMovementTypeReceipt, Quantity: 30, LocationID: warehouse.DefaultLocationID,
+	})
+
+	useCase := NewSeedProductsUseCaseImpl(warehouse.NewProductFactory(), productRepositoryMock, stockLedgerServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{
+		// the stored product has no tax class, which is the same as the standard class
+		{ID: "A12341", Name: "Product 1", Price: "11.99", Currency: "EUR", Stock: 10, TaxClass: "standard"},
+		{ID: "A12342", Name: "Product 2", Price: "10.99", Currency: "EUR", Stock: 20, TaxClass: "reduced"},
+		{ID: "A12343", Name: "Product 3", Price: "13.99", Currency: "EUR", Stock: 30},
+	}})
+
+	require.NoError(t, err)
+	require.Equal(t, &SeedProductsUseCaseOutput{Created: 1, Updated: 1, Unchanged: 1}, output)
+	// the stock is not saved with the product, it is changed by the movements
+	require.Equal(t, &warehouse.Product{ID: "A12342", Name: "Product 2", Price: money.New(1099, "EUR"), Stock: 25, TaxClass: tax.TaxClassReduced}, updatedProduct)
+}
+
+// expectUpdateProduct lets the stock ledger mock change the stored product like the stock ledger
+func expectUpdateProduct(stockLedgerServiceMock *helper.MockStockLedgerService, storedProduct *warehouse.Product) {
+	stockLedgerServiceMock.EXPECT().UpdateProduct(gomock.Any(), storedProduct.ID, gomock.Any()).DoAndReturn(func(ctx context.Context, productID string, update func(product *warehouse.Product) error) (*warehouse.Product, error) {
+		updateErr := update(storedProduct)
+		if updateErr != nil {
+			return nil, updateErr
+		}
+
+		return storedProduct, nil
+	})
+}
+
+func Test_SeedProductsUseCase_SaleDuringUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12342").Return(&warehouse.Product{ID: "A12342", Name: "Product 2", Price: money.New(1299, "EUR"), Stock: 25}, nil)
+
+	// 3 units were sold between Find and the update, the stock ledger updates the current product
+	storedProduct := &warehouse.Product{ID: "A12342", Name: "Product 2", Price: money.New(1299, "EUR"), Stock: 22}
+
+	stockLedgerServiceMock := helper.NewMockStockLedgerService(ctrl)
+	expectUpdateProduct(stockLedgerServiceMock, storedProduct)
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &warehouse.StockMovement{
+		ProductID: "A12342", Type: warehouse.StockMovementTypeAdjustment, Quantity: -2, Reason: warehouse.StockAdjustmentReasonCorrection, LocationID: warehouse.DefaultLocationID,
+	})
+
+	useCase := NewSeedProductsUseCaseImpl(warehouse.NewProductFactory(), productRepositoryMock, stockLedgerServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{
+		{ID: "A12342", Name: "Product 2", Price: "10.99", Currency: "EUR", Stock: 20},
+	}})
+
+	require.NoError(t, err)
+	require.Equal(t, &SeedProductsUseCaseOutput{Updated: 1}, output)
+	require.Equal(t, &warehouse.Product{ID: "A12342", Name: "Product 2", Price: money.New(1099, "EUR"), Stock: 22, TaxClass: tax.TaxClassStandard}, storedProduct)
+}
+
+func Test_SeedProductsUseCase_StockPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stockPolicy := warehouse.StockPolicy{Type: warehouse.StockPolicyTypeBackorder, RestockDate: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), MaxBackorder: 5}
+
+	// the 3 backordered units of the stored products are not part of the fixture stock
+	unchangedProduct := &warehouse.Product{ID: "A12344", Name: "Product 4", Price: money.New(1499, "EUR"), Stock: -3, StockPolicy: stockPolicy}
+	updatedProduct := &warehouse.Product{ID: "A12345", Name: "Product 5", Price: money.New(1599, "EUR"), Stock: -3, StockPolicy: stockPolicy}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12344").Return(unchangedProduct, nil)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12345").Return(updatedProduct, nil)
+
+	stockLedgerServiceMock := helper.NewMockStockLedgerService(ctrl)
+	expectUpdateProduct(stockLedgerServiceMock, updatedProduct)
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &warehouse.StockMovement{
+		ProductID: "A12345", Type: warehouse.StockMovementTypeAdjustment, Quantity: 2, Reason: warehouse.StockAdjustmentReasonCorrection, LocationID: warehouse.DefaultLocationID,
+	})
+
+	useCase := NewSeedProductsUseCaseImpl(warehouse.NewProductFactory(), productRepositoryMock, stockLedgerServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{
+		{ID: "A12344", Name: "Product 4", Price: "14.99", Currency: "EUR", StockPolicy: "backorder", RestockDate: "2026-12-01", MaxBackorder: 5},
+		{ID: "A12345", Name: "Product 5", Price: "15.99", Currency: "EUR", Stock: 2, StockPolicy: "backorder", RestockDate: "2027-01-15", MaxBackorder: 10},
+	}})
+
+	require.NoError(t, err)
+	require.Equal(t, &SeedProductsUseCaseOutput{Updated: 1, Unchanged: 1}, output)
+	require.Equal(t, &warehouse.Product{
+		ID: "A12345", Name: "Product 5", Price: money.New(1599, "EUR"), Stock: -3, TaxClass: tax.TaxClassStandard,
+		StockPolicy: warehouse.StockPolicy{Type: warehouse.StockPolicyTypeBackorder, RestockDate: time.Date(2027, 1, 15, 0, 0, 0, 0, time.UTC), MaxBackorder: 10},
+	}, updatedProduct)
+}
+
+func Test_SeedProductsUseCase_StockPolicy_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storedProduct := &warehouse.Product{
+		ID: "A12344", Name: "Product 4", Price: money.New(1499, "EUR"), Stock: -3,
+		StockPolicy: warehouse.StockPolicy{Type: warehouse.StockPolicyTypeBackorder, MaxBackorder: 5},
+	}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12344").Return(storedProduct, nil)
+
+	stockLedgerServiceMock := helper.NewMockStockLedgerService(ctrl)
+	expectUpdateProduct(stockLedgerServiceMock, storedProduct)
+
+	useCase := NewSeedProductsUseCaseImpl(warehouse.NewProductFactory(), productRepositoryMock, stockLedgerServiceMock)
+
+	// the policy of the fixture does not allow the units which are already backordered
+	output, err := useCase.Execute(t.Context(), &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{
+		{ID: "A12344", Name: "Product 4", Price: "14.99", Currency: "EUR"},
+	}})
+
+	require.ErrorAs(t, err, new(*domainerror.ValidationError))
+	require.ErrorContains(t, err, "input validation error: product A12344 has 3 backordered units")
+	require.Nil(t, output)
+}
+
+func Test_SeedProductsUseCase_SkipExisting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	existingProduct := &warehouse.Product{ID: "A12341", Name: "Product 1", Price: money.New(1099, "EUR"), Stock: 3}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12341").Return(existingProduct, nil)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12343").Return(nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: "A12343"})
+	productRepositoryMock.EXPECT().Save(gomock.Any(), &warehouse.Product{
+		ID: "A12343", Name: "Product 3", Price: money.New(1399, "EUR"), Stock: 0, TaxClass: tax.TaxClassStandard,
+	})
+
+	stockLedgerServiceMock := helper.NewMockStockLedgerService(ctrl)
+	stockLedgerServiceMock.EXPECT().Record(gomock.Any(), &warehouse.StockMovement{
+		ProductID: "A12343", Type: warehouse.StockMovementTypeReceipt, Quantity: 30, LocationID: warehouse.DefaultLocationID,
+	})
+
+	useCase := NewSeedProductsUseCaseImpl(warehouse.NewProductFactory(), productRepositoryMock, stockLedgerServiceMock)
+
+	output, err := useCase.Execute(t.Context(), &SeedProductsUseCaseInput{
+		Products: []*dto.ProductRecord{
+			{ID: "A12341", Name: "Product 1", Price: "11.99", Currency: "EUR", Stock: 10},
+			{ID: "A12343", Name: "Product 3", Price: "13.99", Currency: "EUR", Stock: 30},
+		},
+		SkipExisting: true,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, &SeedProductsUseCaseOutput{Created: 1, Skipped: 1}, output)
+}
+
+func Test_SeedProductsUseCase_ReturnsSaveError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12341").Return(nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: "A12341"})
+	productRepositoryMock.EXPECT().Save(gomock.Any(), gomock.Any()).Return(fmt.Errorf("database is down"))
+
+	useCase := NewSeedProductsUseCaseImpl(warehouse.NewProductFactory(), productRepositoryMock, helper.NewMockStockLedgerService(ctrl))
+
+	output, err := useCase.Execute(t.Context(), &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{
+		{ID: "A12341", Name: "Product 1", Price: "11.99", Currency: "EUR", Stock: 10},
+	}})
+
+	require.ErrorContains(t, err, "database is down")
+	require.Nil(t, output)
+}
+
+func Test_SeedProductsUseCase_ReturnsFindError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12341").Return(nil, fmt.Errorf("database is down"))
+
+	useCase := NewSeedProductsUseCaseImpl(warehouse.NewProductFactory(), productRepositoryMock, helper.NewMockStockLedgerService(ctrl))
+
+	output, err := useCase.Execute(t.Context(), &SeedProductsUseCaseInput{Products: []*dto.ProductRecord{
+		{ID: "A12341", Name: "Product 1", Price: "11.99", Currency: "EUR", Stock: 10},
+	}})
+
+	require.ErrorContains(t, err, "database is down")
+	require.Nil(t, output)
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+type SetProductPriceUseCaseInput struct {
+	ProductID string
+	// Price is a decimal in major units, e.g. 11.99
+	Price    string
+	Currency string
+}
+
+type SetProductPriceUseCaseOutput struct {
+	Product *dto.AdminProductDTO
+}
+
+// SetProductPriceUseCase changes the price of a product.
+// The currency cannot be changed, because the baskets and the orders add up the prices of their products.
+type SetProductPriceUseCase interface {
+	Execute(ctx context.Context, input *SetProductPriceUseCaseInput) (*SetProductPriceUseCaseOutput, error)
+}
+
+func NewSetProductPriceUseCaseImpl(productOutputService helper.ProductOutputService, stockLedgerService helper.StockLedgerService) SetProductPriceUseCase {
+	return &SetProductPriceUseCaseImpl{
+		productOutputService: productOutputService,
+		stockLedgerService:   stockLedgerService,
+	}
+}
+
+var _ SetProductPriceUseCase = (*SetProductPriceUseCaseImpl)(nil)
+
+type SetProductPriceUseCaseImpl struct {
+	productOutputService helper.ProductOutputService
+	// stockLedgerService saves the product, so a sale recorded meanwhile is not overwritten
+	stockLedgerService helper.StockLedgerService
+}
+
+func (useCase *SetProductPriceUseCaseImpl) validate(input *SetProductPriceUseCaseInput) (money.Money, error) {
+	if input == nil {
+		return money.Money{}, fmt.Errorf("input is nil")
+	} else if input.ProductID == "" {
+		return money.Money{}, fmt.Errorf("input parameter ProductID is empty")
+	}
+
+	price, err := money.Parse(input.Price, input.Currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("input parameter Price is invalid: %w", err)
+	}
+
+	return price, nil
+}
+
+func (useCase *SetProductPriceUseCaseImpl) Execute(ctx context.Context, input *SetProductPriceUseCaseInput) (*SetProductPriceUseCaseOutput, error) {
+	price, err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	product, updateErr := useCase.stockLedgerService.UpdateProduct(ctx, input.ProductID, func(product *warehouse.Product) error {
+		if price.GetCurrency() != product.Price.GetCurrency() {
+			return domainerror.NewValidationError("input validation error: currency %s differs from the currency %s of product %s", price.GetCurrency(), product.Price.GetCurrency(), product.ID)
+		}
+
+		setPriceErr := product.SetPrice(price)
+		if setPriceErr != nil {
+			return domainerror.NewValidationError("input validation error: %s", setPriceErr)
+		}
+
+		return nil
+	})
+	if updateErr != nil {
+		return nil, updateErr
+	}
+
+	productDTO, productOutputServiceErr := useCase.productOutputService.CreateAdminProductDTO(product)
+	if productOutputServiceErr != nil {
+		return nil, productOutputServiceErr
+	}
+
+	output := &SetProductPriceUseCaseOutput{
+		Product: productDTO,
+	}
+
+	return output, nil
+}
@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/inmemory"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+// newTestStockLedgerService saves the products of the product repository, the ledger itself is not used by the test
+func newTestStockLedgerService(t *testing.T, ctrl *gomock.Controller, productRepository warehouse.ProductRepository) helper.StockLedgerService {
+	stockLedgerService, err := helper.NewStockLedgerService(productRepository, warehouse.NewMockLocationRepository(ctrl), warehouse.NewMockStockMovementRepository(ctrl))
+	require.NoError(t, err)
+
+	return stockLedgerService
+}
+
+func Test_SetProductPriceUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	product := &warehouse.Product{ID: "A1", Name: "Book", Price: money.New(999, "EUR")}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(product, nil)
+	productRepositoryMock.EXPECT().Save(gomock.Any(), product).Return(nil)
+
+	useCase := NewSetProductPriceUseCaseImpl(helper.NewProductOutputService(), newTestStockLedgerService(t, ctrl, productRepositoryMock))
+
+	output, err := useCase.Execute(t.Context(), &SetProductPriceUseCaseInput{ProductID: "A1", Price: "12.50", Currency: "EUR"})
+
+	require.NoError(t, err)
+	require.Equal(t, money.New(1250, "EUR"), product.Price)
+	require.Equal(t, "12.50", output.Product.Price.Value)
+}
+
+func Test_SetProductPriceUseCase_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *SetProductPriceUseCaseInput
+	}{
+		"input parameter ProductID is empty": {
+			input: &SetProductPriceUseCaseInput{},
+		},
+		"input parameter Price is invalid: currency is empty": {
+			input: &SetProductPriceUseCaseInput{ProductID: "A1", Price: "12.50"},
+		},
+		"price -12.50 EUR is negative": {
+			input: &SetProductPriceUseCaseInput{ProductID: "A1", Price: "-12.50", Currency: "EUR"},
+		},
+		"currency USD differs from the currency EUR of product A1": {
+			input: &SetProductPriceUseCaseInput{ProductID: "A1", Price: "12.50", Currency: "USD"},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+			productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(&warehouse.Product{ID: "A1", Name: "Book", Price: money.New(999, "EUR")}, nil).AnyTimes()
+
+			useCase := NewSetProductPriceUseCaseImpl(helper.NewProductOutputService(), newTestStockLedgerService(t, ctrl, productRepositoryMock))
+
+			output, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.ErrorAs(t, err, new(*domainerror.ValidationError))
+			require.ErrorContains(t, err, errorString)
+			require.Nil(t, output)
+		})
+	}
+}
+
+// interleavingProductRepository calls interleave once, after the product has been found for an update and before it is saved
+type interleavingProductRepository struct {
+	warehouse.ProductRepository
+	interleave func()
+}
+
+func (repository *interleavingProductRepository) Find(ctx context.Context, id string) (*warehouse.Product, error) {
+	product, err := repository.ProductRepository.Find(ctx, id)
+
+	interleave := repository.interleave
+	repository.interleave = nil
+	if interleave != nil {
+		interleave()
+	}
+
+	return product, err
+}
+
+func Test_SetProductPriceUseCase_KeepsRecordedSale(t *testing.T) {
+	productRepository := &interleavingProductRepository{ProductRepository: inmemory.NewInMemoryProductRepository()}
+	require.NoError(t, productRepository.Save(t.Context(), &warehouse.Product{ID: "A1", Name: "Book", Price: money.New(999, "EUR"), Stock: 10}))
+
+	locationRepository := inmemory.NewInMemoryLocationRepository()
+	require.NoError(t, locationRepository.Save(t.Context(), &warehouse.Location{ID: warehouse.DefaultLocationID, Name: "Berlin"}))
+
+	stockLedgerService, err := helper.NewStockLedgerService(productRepository, locationRepository, inmemory.NewInMemoryStockMovementRepository())
+	require.NoError(t, err)
+
+	// the sale is recorded while the price is set, it has to wait until the product with the new price is saved
+	recordErrs := make(chan error, 1)
+	productRepository.interleave = func() {
+		go func() {
+			recordErrs <- stockLedgerService.Record(t.Context(), &warehouse.StockMovement{ProductID: "A1", Type: warehouse.StockMovementTypeSale, Quantity: -3, Reference: "order-1"})
+		}()
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	useCase := NewSetProductPriceUseCaseImpl(helper.NewProductOutputService(), stockLedgerService)
+
+	_, err = useCase.Execute(t.Context(), &SetProductPriceUseCaseInput{ProductID: "A1", Price: "12.50", Currency: "EUR"})
+	require.NoError(t, err)
+	require.NoError(t, <-recordErrs)
+
+	product, err := productRepository.Find(t.Context(), "A1")
+	require.NoError(t, err)
+
+	balance, err := stockLedgerService.Balance(t.Context(), "A1")
+	require.NoError(t, err)
+
+	require.Equal(t, money.New(1250, "EUR"), product.Price)
+	require.Equal(t, 7, product.Stock)
+	require.Equal(t, balance.Stock, product.Stock)
+}
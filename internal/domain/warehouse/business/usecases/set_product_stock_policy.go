@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type SetProductStockPolicyUseCaseInput struct {
+	ProductID string
+	// StockPolicy is backorder or preorder, an empty policy only sells the stock
+	StockPolicy string
+	// RestockDate is a date like 2026-12-01, it is required for pre-orders
+	RestockDate  string
+	MaxBackorder int
+}
+
+type SetProductStockPolicyUseCaseOutput struct {
+	Product *dto.AdminProductDTO
+}
+
+// SetProductStockPolicyUseCase replaces the stock policy of a product.
+// The policy cannot allow fewer units than are already backordered, they have to be received first.
+type SetProductStockPolicyUseCase interface {
+	Execute(ctx context.Context, input *SetProductStockPolicyUseCaseInput) (*SetProductStockPolicyUseCaseOutput, error)
+}
+
+func NewSetProductStockPolicyUseCaseImpl(productOutputService helper.ProductOutputService, stockLedgerService helper.StockLedgerService) SetProductStockPolicyUseCase {
+	return &SetProductStockPolicyUseCaseImpl{
+		productOutputService: productOutputService,
+		stockLedgerService:   stockLedgerService,
+	}
+}
+
+var _ SetProductStockPolicyUseCase = (*SetProductStockPolicyUseCaseImpl)(nil)
+
+type SetProductStockPolicyUseCaseImpl struct {
+	productOutputService helper.ProductOutputService
+	// stockLedgerService saves the product, so a sale recorded meanwhile is not overwritten
+	stockLedgerService helper.StockLedgerService
+}
+
+func (useCase *SetProductStockPolicyUseCaseImpl) validate(input *SetProductStockPolicyUseCaseInput) (warehouse.StockPolicy, error) {
+	if input == nil {
+		return warehouse.StockPolicy{}, fmt.Errorf("input is nil")
+	} else if input.ProductID == "" {
+		return warehouse.StockPolicy{}, fmt.Errorf("input parameter ProductID is empty")
+	}
+
+	stockPolicy := warehouse.StockPolicy{
+		Type:         warehouse.StockPolicyType(input.StockPolicy),
+		MaxBackorder: input.MaxBackorder,
+	}
+
+	if input.RestockDate != "" {
+		restockDate, err := time.Parse(time.DateOnly, input.RestockDate)
+		if err != nil {
+			return warehouse.StockPolicy{}, fmt.Errorf("input parameter RestockDate %q is not a date like 2026-12-01", input.RestockDate)
+		}
+		stockPolicy.RestockDate = restockDate
+	}
+
+	return stockPolicy, nil
+}
+
+func (useCase *SetProductStockPolicyUseCaseImpl) Execute(ctx context.Context, input *SetProductStockPolicyUseCaseInput) (*SetProductStockPolicyUseCaseOutput, error) {
+	stockPolicy, err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	// the backordered units are checked against the current stock, which cannot change until the product is saved
+	product, updateErr := useCase.stockLedgerService.UpdateProduct(ctx, input.ProductID, func(product *warehouse.Product) error {
+		setStockPolicyErr := product.SetStockPolicy(stockPolicy)
+		if setStockPolicyErr != nil {
+			return domainerror.NewValidationError("input validation error: %s", setStockPolicyErr)
+		}
+
+		return nil
+	})
+	if updateErr != nil {
+		return nil, updateErr
+	}
+
+	productDTO, productOutputServiceErr := useCase.productOutputService.CreateAdminProductDTO(product)
+	if productOutputServiceErr != nil {
+		return nil, productOutputServiceErr
+	}
+
+	output := &SetProductStockPolicyUseCaseOutput{
+		Product: productDTO,
+	}
+
+	return output, nil
+}
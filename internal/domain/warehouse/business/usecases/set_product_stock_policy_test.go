@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_SetProductStockPolicyUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	product := &warehouse.Product{ID: "A12344", Name: "Product 4", Price: money.New(1499, "EUR")}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12344").Return(product, nil)
+	productRepositoryMock.EXPECT().Save(gomock.Any(), product).Return(nil)
+
+	useCase := NewSetProductStockPolicyUseCaseImpl(helper.NewProductOutputService(), newTestStockLedgerService(t, ctrl, productRepositoryMock))
+
+	output, err := useCase.Execute(t.Context(), &SetProductStockPolicyUseCaseInput{ProductID: "A12344", StockPolicy: "preorder", RestockDate: "2026-12-01", MaxBackorder: 100})
+
+	restockDate := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, err)
+	require.Equal(t, warehouse.StockPolicy{Type: warehouse.StockPolicyTypePreorder, RestockDate: restockDate, MaxBackorder: 100}, product.StockPolicy)
+	require.Equal(t, "preorder", output.Product.Availability)
+	require.Equal(t, &restockDate, output.Product.RestockDate)
+	require.Equal(t, &dto.StockPolicy{Type: "preorder", RestockDate: &restockDate, MaxBackorder: 100}, output.Product.StockPolicy)
+}
+
+func Test_SetProductStockPolicyUseCase_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *SetProductStockPolicyUseCaseInput
+	}{
+		"input parameter ProductID is empty": {
+			input: &SetProductStockPolicyUseCaseInput{},
+		},
+		`input parameter RestockDate "next week" is not a date like 2026-12-01`: {
+			input: &SetProductStockPolicyUseCaseInput{ProductID: "A12344", StockPolicy: "backorder", RestockDate: "next week", MaxBackorder: 10},
+		},
+		`stock policy "always" is unknown`: {
+			input: &SetProductStockPolicyUseCaseInput{ProductID: "A12344", StockPolicy: "always", MaxBackorder: 10},
+		},
+		"product A12344 has 3 backordered units": {
+			input: &SetProductStockPolicyUseCaseInput{ProductID: "A12344"},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+			productRepositoryMock.EXPECT().Find(gomock.Any(), "A12344").Return(&warehouse.Product{
+				ID: "A12344", Name: "Product 4", Price: money.New(1499, "EUR"), Stock: -3,
+				StockPolicy: warehouse.StockPolicy{Type: warehouse.StockPolicyTypeBackorder, MaxBackorder: 5},
+			}, nil).AnyTimes()
+
+			useCase := NewSetProductStockPolicyUseCaseImpl(helper.NewProductOutputService(), newTestStockLedgerService(t, ctrl, productRepositoryMock))
+
+			output, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.ErrorAs(t, err, new(*domainerror.ValidationError))
+			require.ErrorContains(t, err, errorString)
+			require.Nil(t, output)
+		})
+	}
+}
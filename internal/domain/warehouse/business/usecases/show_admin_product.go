@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type ShowAdminProductUseCaseInput struct {
+	ProductID string
+}
+
+type ShowAdminProductUseCaseOutput struct {
+	Product *dto.AdminProductDTO
+}
+
+// ShowAdminProductUseCase returns the product with its stock, unlike the catalog it also returns deactivated products
+type ShowAdminProductUseCase interface {
+	Execute(ctx context.Context, input *ShowAdminProductUseCaseInput) (*ShowAdminProductUseCaseOutput, error)
+}
+
+func NewShowAdminProductUseCaseImpl(productOutputService helper.ProductOutputService, productRepository warehouse.ProductRepository) ShowAdminProductUseCase {
+	return &ShowAdminProductUseCaseImpl{
+		productOutputService: productOutputService,
+		productRepository:    productRepository,
+	}
+}
+
+var _ ShowAdminProductUseCase = (*ShowAdminProductUseCaseImpl)(nil)
+
+type ShowAdminProductUseCaseImpl struct {
+	productOutputService helper.ProductOutputService
+	productRepository    warehouse.ProductRepository
+}
+
+func (useCase *ShowAdminProductUseCaseImpl) validate(input *ShowAdminProductUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.ProductID == "" {
+		return fmt.Errorf("input parameter ProductID is empty")
+	}
+
+	return nil
+}
+
+func (useCase *ShowAdminProductUseCaseImpl) Execute(ctx context.Context, input *ShowAdminProductUseCaseInput) (*ShowAdminProductUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	product, productRepositoryErr := useCase.productRepository.Find(ctx, input.ProductID)
+	if productRepositoryErr != nil {
+		return nil, productRepositoryErr
+	}
+
+	productDTO, productOutputServiceErr := useCase.productOutputService.CreateAdminProductDTO(product)
+	if productOutputServiceErr != nil {
+		return nil, productOutputServiceErr
+	}
+
+	output := &ShowAdminProductUseCaseOutput{
+		Product: productDTO,
+	}
+
+	return output, nil
+}
@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_ShowAdminProductUseCase_Deactivated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(&warehouse.Product{ID: "A1", Name: "Book", Price: money.New(999, "EUR"), Stock: 7, Deactivated: true}, nil)
+
+	useCase := NewShowAdminProductUseCaseImpl(helper.NewProductOutputService(), productRepositoryMock)
+
+	// unlike the catalog, the admins see deactivated products
+	output, err := useCase.Execute(t.Context(), &ShowAdminProductUseCaseInput{ProductID: "A1"})
+
+	require.NoError(t, err)
+	require.Equal(t, "Book", output.Product.Name)
+	require.Equal(t, 7, output.Product.Stock)
+	require.True(t, output.Product.Deactivated)
+}
@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type ShowProductUseCaseInput struct {
+	ProductID string
+}
+
+type ShowProductUseCaseOutput struct {
+	Product *dto.ProductDTO
+}
+
+type ShowProductUseCase interface {
+	Execute(ctx context.Context, input *ShowProductUseCaseInput) (*ShowProductUseCaseOutput, error)
+}
+
+func NewShowProductUseCaseImpl(productOutputService helper.ProductOutputService, productRepository warehouse.ProductRepository) ShowProductUseCase {
+	return &ShowProductUseCaseImpl{
+		productOutputService: productOutputService,
+		productRepository:    productRepository,
+	}
+}
+
+var _ ShowProductUseCase = (*ShowProductUseCaseImpl)(nil)
+
+type ShowProductUseCaseImpl struct {
+	productOutputService helper.ProductOutputService
+	productRepository    warehouse.ProductRepository
+}
+
+func (useCase *ShowProductUseCaseImpl) validate(input *ShowProductUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.ProductID == "" {
+		return fmt.Errorf("input parameter ProductID is empty")
+	}
+
+	return nil
+}
+
+func (useCase *ShowProductUseCaseImpl) Execute(ctx context.Context, input *ShowProductUseCaseInput) (*ShowProductUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	product, productRepositoryErr := useCase.productRepository.Find(ctx, input.ProductID)
+	if productRepositoryErr != nil {
+		return nil, productRepositoryErr
+	}
+
+	// the catalog only contains the products which are sold
+	if !product.IsActive() {
+		return nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: product.ID}
+	}
+
+	productDTO, productOutputServiceErr := useCase.productOutputService.CreateProductDTO(product)
+	if productOutputServiceErr != nil {
+		return nil, productOutputServiceErr
+	}
+
+	output := &ShowProductUseCaseOutput{
+		Product: productDTO,
+	}
+
+	return output, nil
+}
@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_ShowProductUseCase_NewShowProductUseCaseImpl_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *ShowProductUseCaseInput
+	}{
+		"input is nil": {
+			input: nil,
+		},
+		"ProductID is empty": {
+			input: &ShowProductUseCaseInput{},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := NewShowProductUseCaseImpl(helper.NewProductOutputService(), warehouse.NewMockProductRepository(ctrl))
+
+			_, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.Error(t, err)
+			require.ErrorContains(t, err, errorString)
+		})
+	}
+}
+
+func Test_ShowProductUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12345").Return(&warehouse.Product{
+		ID:       "A12345",
+		Name:     "Book",
+		Price:    money.New(1999, "EUR"),
+		Stock:    3,
+		TaxClass: tax.TaxClassReduced,
+	}, nil)
+
+	useCase := NewShowProductUseCaseImpl(helper.NewProductOutputService(), productRepositoryMock)
+
+	output, err := useCase.Execute(t.Context(), &ShowProductUseCaseInput{ProductID: "A12345"})
+
+	require.NoError(t, err)
+	require.Equal(t, "A12345", output.Product.ID)
+	require.Equal(t, "Book", output.Product.Name)
+	require.Equal(t, "19.99", output.Product.Price.Value)
+	require.Equal(t, "EUR", output.Product.Price.Currency)
+	require.Equal(t, "reduced", output.Product.TaxClass)
+	require.Equal(t, "low_stock", output.Product.Availability)
+}
+
+func Test_ShowProductUseCase_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A99999").Return(nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: "A99999"})
+
+	useCase := NewShowProductUseCaseImpl(helper.NewProductOutputService(), productRepositoryMock)
+
+	output, err := useCase.Execute(t.Context(), &ShowProductUseCaseInput{ProductID: "A99999"})
+
+	require.True(t, domainerror.IsNotFound(err, warehouse.ProductResource))
+	require.Nil(t, output)
+}
+
+func Test_ShowProductUseCase_Deactivated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A12345").Return(&warehouse.Product{ID: "A12345", Name: "Book", Deactivated: true}, nil)
+
+	useCase := NewShowProductUseCaseImpl(helper.NewProductOutputService(), productRepositoryMock)
+
+	output, err := useCase.Execute(t.Context(), &ShowProductUseCaseInput{ProductID: "A12345"})
+
+	require.EqualError(t, err, "product A12345 not found")
+	require.Nil(t, output)
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+type UpdateProductUseCaseInput struct {
+	ProductID string
+	Name      string
+	// TaxClass replaces the tax class, an empty tax class is the standard class
+	TaxClass string
+}
+
+type UpdateProductUseCaseOutput struct {
+	Product *dto.AdminProductDTO
+}
+
+// UpdateProductUseCase replaces the name and the tax class, the price and the stock have their own use cases
+type UpdateProductUseCase interface {
+	Execute(ctx context.Context, input *UpdateProductUseCaseInput) (*UpdateProductUseCaseOutput, error)
+}
+
+func NewUpdateProductUseCaseImpl(productOutputService helper.ProductOutputService, stockLedgerService helper.StockLedgerService) UpdateProductUseCase {
+	return &UpdateProductUseCaseImpl{
+		productOutputService: productOutputService,
+		stockLedgerService:   stockLedgerService,
+	}
+}
+
+var _ UpdateProductUseCase = (*UpdateProductUseCaseImpl)(nil)
+
+type UpdateProductUseCaseImpl struct {
+	productOutputService helper.ProductOutputService
+	// stockLedgerService saves the product, so a sale recorded meanwhile is not overwritten
+	stockLedgerService helper.StockLedgerService
+}
+
+func (useCase *UpdateProductUseCaseImpl) validate(input *UpdateProductUseCaseInput) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	} else if input.ProductID == "" {
+		return fmt.Errorf("input parameter ProductID is empty")
+	}
+
+	return nil
+}
+
+func (useCase *UpdateProductUseCaseImpl) Execute(ctx context.Context, input *UpdateProductUseCaseInput) (*UpdateProductUseCaseOutput, error) {
+	err := useCase.validate(input)
+	if err != nil {
+		return nil, domainerror.NewValidationError("input validation error: %s", err)
+	}
+
+	product, updateErr := useCase.stockLedgerService.UpdateProduct(ctx, input.ProductID, func(product *warehouse.Product) error {
+		renameErr := product.Rename(input.Name)
+		if renameErr != nil {
+			return domainerror.NewValidationError("input validation error: %s", renameErr)
+		}
+
+		taxClassErr := product.SetTaxClass(tax.TaxClass(input.TaxClass))
+		if taxClassErr != nil {
+			return domainerror.NewValidationError("input validation error: %s", taxClassErr)
+		}
+
+		return nil
+	})
+	if updateErr != nil {
+		return nil, updateErr
+	}
+
+	productDTO, productOutputServiceErr := useCase.productOutputService.CreateAdminProductDTO(product)
+	if productOutputServiceErr != nil {
+		return nil, productOutputServiceErr
+	}
+
+	output := &UpdateProductUseCaseOutput{
+		Product: productDTO,
+	}
+
+	return output, nil
+}
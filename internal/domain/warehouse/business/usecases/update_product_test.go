@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/helper"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func Test_UpdateProductUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	product := &warehouse.Product{ID: "A1", Name: "Book", Price: money.New(999, "EUR"), Stock: 3, TaxClass: tax.TaxClassReduced}
+
+	productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+	productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(product, nil)
+	productRepositoryMock.EXPECT().Save(gomock.Any(), product).Return(nil)
+
+	useCase := NewUpdateProductUseCaseImpl(helper.NewProductOutputService(), newTestStockLedgerService(t, ctrl, productRepositoryMock))
+
+	output, err := useCase.Execute(t.Context(), &UpdateProductUseCaseInput{ProductID: "A1", Name: "Cookbook"})
+
+	require.NoError(t, err)
+	require.Equal(t, "Cookbook", output.Product.Name)
+	// the empty tax class replaces the reduced class
+	require.Equal(t, tax.TaxClassStandard, product.TaxClass)
+	require.Equal(t, money.New(999, "EUR"), product.Price)
+	require.Equal(t, 3, product.Stock)
+}
+
+func Test_UpdateProductUseCase_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		input *UpdateProductUseCaseInput
+	}{
+		"input parameter ProductID is empty": {
+			input: &UpdateProductUseCaseInput{},
+		},
+		"name is empty": {
+			input: &UpdateProductUseCaseInput{ProductID: "A1"},
+		},
+		`tax class "luxury" is unknown`: {
+			input: &UpdateProductUseCaseInput{ProductID: "A1", Name: "Cookbook", TaxClass: "luxury"},
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepositoryMock := warehouse.NewMockProductRepository(ctrl)
+			productRepositoryMock.EXPECT().Find(gomock.Any(), "A1").Return(&warehouse.Product{ID: "A1", Name: "Book"}, nil).AnyTimes()
+
+			useCase := NewUpdateProductUseCaseImpl(helper.NewProductOutputService(), newTestStockLedgerService(t, ctrl, productRepositoryMock))
+
+			output, err := useCase.Execute(t.Context(), testCase.input)
+
+			require.ErrorAs(t, err, new(*domainerror.ValidationError))
+			require.ErrorContains(t, err, errorString)
+			require.Nil(t, output)
+		})
+	}
+}
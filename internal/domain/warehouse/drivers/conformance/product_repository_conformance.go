@@ -0,0 +1,215 @@
+// Package conformance contains the behavior every warehouse.ProductRepository driver has to implement.
+package conformance
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+// NewProductRepository returns an empty repository for every test
+type NewProductRepository func(t *testing.T) warehouse.ProductRepository
+
+// RunProductRepositoryTests runs the conformance tests against the repositories of newRepository,
+// every driver calls it from its own test
+func RunProductRepositoryTests(t *testing.T, newRepository NewProductRepository) {
+	testCases := map[string]func(t *testing.T, repository warehouse.ProductRepository){
+		"save nil product":      testSaveNilProduct,
+		"save new product":      testSaveNewProduct,
+		"update product":        testUpdateProduct,
+		"stock policy":          testStockPolicy,
+		"find all empty":        testFindAllEmpty,
+		"find all sorted by id": testFindAllSortedByID,
+		"find returns copies":   testFindReturnsCopies,
+		"not found":             testNotFound,
+		"concurrent access":     testConcurrentAccess,
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testCase(t, newRepository(t))
+		})
+	}
+}
+
+func testSaveNilProduct(t *testing.T, repository warehouse.ProductRepository) {
+	require.Error(t, repository.Save(t.Context(), nil))
+}
+
+func testSaveNewProduct(t *testing.T, repository warehouse.ProductRepository) {
+	product := &warehouse.Product{
+		ID:       "A12345",
+		Name:     "Product 5",
+		Price:    money.New(1599, "EUR"),
+		Stock:    50,
+		TaxClass: tax.TaxClassReduced,
+	}
+
+	require.NoError(t, repository.Save(t.Context(), product))
+
+	foundProduct, err := repository.Find(t.Context(), "A12345")
+
+	require.NoError(t, err)
+	require.Equal(t, product, foundProduct)
+}
+
+func testUpdateProduct(t *testing.T, repository warehouse.ProductRepository) {
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Product{ID: "A12345", Name: "Product 5", Price: money.New(1599, "EUR"), Stock: 50}))
+
+	updatedProduct := &warehouse.Product{ID: "A12345", Name: "Product 5 (new)", Price: money.New(1499, "EUR"), Stock: 0, TaxClass: tax.TaxClassZero, Deactivated: true}
+
+	require.NoError(t, repository.Save(t.Context(), updatedProduct))
+
+	foundProduct, err := repository.Find(t.Context(), "A12345")
+
+	require.NoError(t, err)
+	require.Equal(t, updatedProduct, foundProduct)
+
+	products, err := repository.FindAll(t.Context())
+
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+}
+
+func testStockPolicy(t *testing.T, repository warehouse.ProductRepository) {
+	product := &warehouse.Product{
+		ID:    "A12344",
+		Name:  "Product 4",
+		Price: money.New(1499, "EUR"),
+		// the backordered units
+		Stock: -3,
+		StockPolicy: warehouse.StockPolicy{
+			Type:         warehouse.StockPolicyTypePreorder,
+			RestockDate:  time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+			MaxBackorder: 10,
+		},
+	}
+
+	require.NoError(t, repository.Save(t.Context(), product))
+
+	foundProduct, err := repository.Find(t.Context(), "A12344")
+
+	require.NoError(t, err)
+	require.Equal(t, product, foundProduct)
+
+	// removing the policy removes the restock date as well
+	product.Stock = 0
+	product.StockPolicy = warehouse.StockPolicy{}
+
+	require.NoError(t, repository.Save(t.Context(), product))
+
+	foundProduct, err = repository.Find(t.Context(), "A12344")
+
+	require.NoError(t, err)
+	require.Equal(t, product, foundProduct)
+}
+
+func testFindAllEmpty(t *testing.T, repository warehouse.ProductRepository) {
+	products, err := repository.FindAll(t.Context())
+
+	require.NoError(t, err)
+	require.NotNil(t, products)
+	require.Empty(t, products)
+}
+
+func testFindAllSortedByID(t *testing.T, repository warehouse.ProductRepository) {
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Product{ID: "A12346", Price: money.New(1000, "EUR")}))
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Product{ID: "A12345", Price: money.New(1000, "EUR")}))
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Product{ID: "A12347", Price: money.New(1000, "EUR")}))
+
+	products, err := repository.FindAll(t.Context())
+
+	require.NoError(t, err)
+	require.Len(t, products, 3)
+	require.Equal(t, "A12345", products[0].ID)
+	require.Equal(t, "A12346", products[1].ID)
+	require.Equal(t, "A12347", products[2].ID)
+}
+
+func testFindReturnsCopies(t *testing.T, repository warehouse.ProductRepository) {
+	product := &warehouse.Product{
+		ID:    "A12345",
+		Price: money.New(1000, "EUR"),
+		Stock: 10,
+	}
+
+	require.NoError(t, repository.Save(t.Context(), product))
+
+	// changes of the saved product are not stored without calling Save
+	product.Stock = 5
+
+	foundProduct, err := repository.Find(t.Context(), "A12345")
+
+	require.NoError(t, err)
+	require.Equal(t, 10, foundProduct.Stock)
+
+	// changes of a found product are not stored without calling Save
+	foundProduct.Stock = 0
+	products, err := repository.FindAll(t.Context())
+	require.NoError(t, err)
+	products[0].Price = money.New(1, "EUR")
+
+	foundProduct, err = repository.Find(t.Context(), "A12345")
+
+	require.NoError(t, err)
+	require.Equal(t, 10, foundProduct.Stock)
+	require.Equal(t, money.New(1000, "EUR"), foundProduct.Price)
+}
+
+func testNotFound(t *testing.T, repository warehouse.ProductRepository) {
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Product{ID: "A12345", Price: money.New(1000, "EUR")}))
+
+	foundProduct, err := repository.Find(t.Context(), "A12346")
+
+	require.ErrorAs(t, err, new(*domainerror.NotFoundError))
+	require.True(t, domainerror.IsNotFound(err, warehouse.ProductResource))
+	require.Nil(t, foundProduct)
+}
+
+func testConcurrentAccess(t *testing.T, repository warehouse.ProductRepository) {
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Product{ID: "A12345", Price: money.New(1000, "EUR"), Stock: 10}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+
+			products, err := repository.FindAll(t.Context())
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			for _, product := range products {
+				price, priceErr := product.Price.Multiply(2)
+				if !assert.NoError(t, priceErr) {
+					return
+				}
+				product.Price = price
+				assert.NoError(t, repository.Save(t.Context(), product))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+
+			product, err := repository.Find(t.Context(), "A12345")
+			if assert.NoError(t, err) {
+				product.Stock--
+			}
+		}()
+	}
+	wg.Wait()
+
+	product, err := repository.Find(t.Context(), "A12345")
+
+	require.NoError(t, err)
+	require.Equal(t, 10, product.Stock)
+}
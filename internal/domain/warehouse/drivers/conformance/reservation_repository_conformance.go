@@ -0,0 +1,209 @@
+package conformance
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+// NewReservationRepository returns an empty repository for every test
+type NewReservationRepository func(t *testing.T) warehouse.ReservationRepository
+
+// RunReservationRepositoryTests runs the conformance tests against the repositories of newRepository,
+// every driver calls it from its own test
+func RunReservationRepositoryTests(t *testing.T, newRepository NewReservationRepository) {
+	testCases := map[string]func(t *testing.T, repository warehouse.ReservationRepository){
+		"save nil reservation":       testSaveNilReservation,
+		"save and find":              testSaveAndFindReservation,
+		"replace reservation":        testReplaceReservation,
+		"find by product and holder": testFindReservationsByProductAndHolder,
+		"delete":                     testDeleteReservation,
+		"delete expired":             testDeleteExpiredReservations,
+		"find returns copies":        testFindReturnsReservationCopies,
+		"concurrent access":          testConcurrentReservationAccess,
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testCase(t, newRepository(t))
+		})
+	}
+}
+
+// reservationExpiresAt is truncated to milliseconds, because databases like MongoDB only store milliseconds
+var reservationExpiresAt = time.Date(2025, 3, 1, 12, 15, 0, 0, time.UTC)
+
+func testSaveNilReservation(t *testing.T, repository warehouse.ReservationRepository) {
+	require.Error(t, repository.Save(t.Context(), nil))
+}
+
+func testSaveAndFindReservation(t *testing.T, repository warehouse.ReservationRepository) {
+	reservation, err := repository.Find(t.Context(), "1337", "A12345")
+
+	require.True(t, domainerror.IsNotFound(err, warehouse.ReservationResource))
+	require.Nil(t, reservation)
+
+	savedReservation := &warehouse.Reservation{ProductID: "A12345", HolderID: "1337", Count: 2, ExpiresAt: reservationExpiresAt}
+
+	require.NoError(t, repository.Save(t.Context(), savedReservation))
+
+	reservation, err = repository.Find(t.Context(), "1337", "A12345")
+
+	require.NoError(t, err)
+	require.Equal(t, savedReservation, reservation)
+}
+
+func testReplaceReservation(t *testing.T, repository warehouse.ReservationRepository) {
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Reservation{ProductID: "A12345", HolderID: "1337", Count: 2, ExpiresAt: reservationExpiresAt}))
+
+	// there is at most one reservation per holder and product
+	replacedReservation := &warehouse.Reservation{ProductID: "A12345", HolderID: "1337", Count: 5, ExpiresAt: reservationExpiresAt.Add(time.Minute)}
+
+	require.NoError(t, repository.Save(t.Context(), replacedReservation))
+
+	reservations, err := repository.FindByHolderId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, []*warehouse.Reservation{replacedReservation}, reservations)
+}
+
+func testFindReservationsByProductAndHolder(t *testing.T, repository warehouse.ReservationRepository) {
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Reservation{ProductID: "A12345", HolderID: "1337", Count: 1, ExpiresAt: reservationExpiresAt}))
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Reservation{ProductID: "A12344", HolderID: "1337", Count: 2, ExpiresAt: reservationExpiresAt}))
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Reservation{ProductID: "A12345", HolderID: "1338", Count: 3, ExpiresAt: reservationExpiresAt}))
+
+	reservations, err := repository.FindByProductId(t.Context(), "A12345")
+
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 3}, reservationCounts(reservations))
+
+	reservations, err = repository.FindByHolderId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, reservationCounts(reservations))
+
+	reservations, err = repository.FindByHolderId(t.Context(), "1339")
+
+	require.NoError(t, err)
+	require.NotNil(t, reservations)
+	require.Empty(t, reservations)
+}
+
+func testDeleteReservation(t *testing.T, repository warehouse.ReservationRepository) {
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Reservation{ProductID: "A12345", HolderID: "1337", Count: 1, ExpiresAt: reservationExpiresAt}))
+
+	require.NoError(t, repository.Delete(t.Context(), "1337", "A12345"))
+
+	err := repository.Delete(t.Context(), "1337", "A12345")
+
+	require.True(t, domainerror.IsNotFound(err, warehouse.ReservationResource))
+
+	reservations, err := repository.FindByHolderId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Empty(t, reservations)
+}
+
+func testDeleteExpiredReservations(t *testing.T, repository warehouse.ReservationRepository) {
+	expiredReservation := &warehouse.Reservation{ProductID: "A12344", HolderID: "1337", Count: 2, ExpiresAt: reservationExpiresAt.Add(-time.Minute)}
+
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Reservation{ProductID: "A12345", HolderID: "1337", Count: 1, ExpiresAt: reservationExpiresAt.Add(time.Minute)}))
+	require.NoError(t, repository.Save(t.Context(), expiredReservation))
+
+	deleted, err := repository.DeleteExpired(t.Context(), reservationExpiresAt)
+
+	require.NoError(t, err)
+	require.Equal(t, []*warehouse.Reservation{expiredReservation}, deleted)
+
+	reservations, err := repository.FindByHolderId(t.Context(), "1337")
+
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, reservationCounts(reservations))
+}
+
+func testFindReturnsReservationCopies(t *testing.T, repository warehouse.ReservationRepository) {
+	reservation := &warehouse.Reservation{ProductID: "A12345", HolderID: "1337", Count: 2, ExpiresAt: reservationExpiresAt}
+
+	require.NoError(t, repository.Save(t.Context(), reservation))
+
+	// changes of the saved reservation are not stored without calling Save
+	reservation.Count = 5
+
+	foundReservation, err := repository.Find(t.Context(), "1337", "A12345")
+
+	require.NoError(t, err)
+	require.Equal(t, 2, foundReservation.GetCount())
+
+	// changes of a found reservation are not stored without calling Save
+	foundReservation.Count = 0
+	reservations, err := repository.FindByProductId(t.Context(), "A12345")
+	require.NoError(t, err)
+	reservations[0].ExpiresAt = time.Time{}
+	reservations, err = repository.FindByHolderId(t.Context(), "1337")
+	require.NoError(t, err)
+	reservations[0].Count = 0
+
+	foundReservation, err = repository.Find(t.Context(), "1337", "A12345")
+
+	require.NoError(t, err)
+	require.Equal(t, &warehouse.Reservation{ProductID: "A12345", HolderID: "1337", Count: 2, ExpiresAt: reservationExpiresAt}, foundReservation)
+}
+
+func testConcurrentReservationAccess(t *testing.T, repository warehouse.ReservationRepository) {
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Reservation{ProductID: "A12345", HolderID: "1337", Count: 2, ExpiresAt: reservationExpiresAt.Add(time.Minute)}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+
+			reservations, err := repository.FindByProductId(t.Context(), "A12345")
+			if assert.NoError(t, err) {
+				for _, reservation := range reservations {
+					reservation.Count++
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+
+			reservation, err := repository.Find(t.Context(), "1337", "A12345")
+			if assert.NoError(t, err) {
+				reservation.ExpiresAt = reservationExpiresAt.Add(time.Hour)
+				assert.NoError(t, repository.Save(t.Context(), reservation))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+
+			// the reservation expires after reservationExpiresAt, so it is never deleted
+			_, err := repository.DeleteExpired(t.Context(), reservationExpiresAt)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	reservation, err := repository.Find(t.Context(), "1337", "A12345")
+
+	require.NoError(t, err)
+	require.Equal(t, 2, reservation.GetCount())
+}
+
+// reservationCounts returns the counts in ascending order, the repositories do not sort the reservations
+func reservationCounts(reservations []*warehouse.Reservation) []int {
+	counts := make([]int, 0, len(reservations))
+	for _, reservation := range reservations {
+		counts = append(counts, reservation.GetCount())
+	}
+	sort.Ints(counts)
+
+	return counts
+}
@@ -0,0 +1,206 @@
+package conformance
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+)
+
+// NewStockMovementRepository returns an empty repository for every test
+type NewStockMovementRepository func(t *testing.T) warehouse.StockMovementRepository
+
+// RunStockMovementRepositoryTests runs the conformance tests against the repositories of newRepository,
+// every driver calls it from its own test
+func RunStockMovementRepositoryTests(t *testing.T, newRepository NewStockMovementRepository) {
+	testCases := map[string]func(t *testing.T, repository warehouse.StockMovementRepository){
+		"append nil movement":        testAppendNilMovement,
+		"append assigns id":          testAppendAssignsID,
+		"append existing id":         testAppendExistingID,
+		"find by product in range":   testFindByProductInRange,
+		"find keeps order of append": testFindKeepsOrderOfAppend,
+		"find returns copies":        testFindMovementsReturnsCopies,
+		"balance":                    testBalance,
+		"balance without movements":  testBalanceWithoutMovements,
+		"balance per location":       testBalancePerLocation,
+		"concurrent appends":         testConcurrentAppends,
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testCase(t, newRepository(t))
+		})
+	}
+}
+
+// testTime is truncated to milliseconds like the times of the recorded movements, because MongoDB only stores milliseconds
+var testTime = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testAppendNilMovement(t *testing.T, repository warehouse.StockMovementRepository) {
+	require.Error(t, repository.Append(t.Context(), nil))
+	require.Error(t, repository.Append(t.Context(), &warehouse.StockMovement{Type: warehouse.StockMovementTypeReceipt, Quantity: 1}))
+}
+
+func testAppendAssignsID(t *testing.T, repository warehouse.StockMovementRepository) {
+	movement := &warehouse.StockMovement{
+		ProductID:  "A12345",
+		Type:       warehouse.StockMovementTypeAdjustment,
+		Quantity:   -2,
+		Reason:     warehouse.StockAdjustmentReasonDamaged,
+		LocationID: "hamburg",
+		Reference:  "admin",
+		CreatedAt:  testTime,
+	}
+
+	require.NoError(t, repository.Append(t.Context(), movement))
+	require.NotEmpty(t, movement.ID)
+
+	movements, err := repository.FindByProductId(t.Context(), "A12345", testTime, testTime.Add(time.Millisecond))
+
+	require.NoError(t, err)
+	require.Len(t, movements, 1)
+	require.Equal(t, movement, movements[0])
+}
+
+func testAppendExistingID(t *testing.T, repository warehouse.StockMovementRepository) {
+	movement := &warehouse.StockMovement{ID: "1", ProductID: "A12345", Type: warehouse.StockMovementTypeReceipt, Quantity: 5, CreatedAt: testTime}
+
+	require.NoError(t, repository.Append(t.Context(), movement))
+
+	// a stored movement is never replaced
+	require.Error(t, repository.Append(t.Context(), &warehouse.StockMovement{ID: "1", ProductID: "A12345", Type: warehouse.StockMovementTypeReceipt, Quantity: 50, CreatedAt: testTime}))
+
+	balance, err := repository.Balance(t.Context(), "A12345", time.Time{})
+
+	require.NoError(t, err)
+	require.Equal(t, 5, balance.Stock)
+}
+
+func testFindByProductInRange(t *testing.T, repository warehouse.StockMovementRepository) {
+	for i, createdAt := range []time.Time{testTime.Add(-time.Hour), testTime, testTime.Add(time.Hour), testTime.Add(2 * time.Hour)} {
+		require.NoError(t, repository.Append(t.Context(), &warehouse.StockMovement{ProductID: "A12345", Type: warehouse.StockMovementTypeReceipt, Quantity: i + 1, CreatedAt: createdAt}))
+	}
+	require.NoError(t, repository.Append(t.Context(), &warehouse.StockMovement{ProductID: "A12346", Type: warehouse.StockMovementTypeReceipt, Quantity: 10, CreatedAt: testTime}))
+
+	// from is included and to is excluded
+	movements, err := repository.FindByProductId(t.Context(), "A12345", testTime, testTime.Add(2*time.Hour))
+
+	require.NoError(t, err)
+	require.Len(t, movements, 2)
+	require.Equal(t, 2, movements[0].Quantity)
+	require.Equal(t, 3, movements[1].Quantity)
+
+	movements, err = repository.FindByProductId(t.Context(), "A12347", testTime, testTime.Add(2*time.Hour))
+
+	require.NoError(t, err)
+	require.NotNil(t, movements)
+	require.Empty(t, movements)
+}
+
+func testFindKeepsOrderOfAppend(t *testing.T, repository warehouse.StockMovementRepository) {
+	require.NoError(t, repository.Append(t.Context(), &warehouse.StockMovement{ProductID: "A12345", Type: warehouse.StockMovementTypeReceipt, Quantity: 1, CreatedAt: testTime.Add(time.Minute)}))
+	require.NoError(t, repository.Append(t.Context(), &warehouse.StockMovement{ProductID: "A12345", Type: warehouse.StockMovementTypeReservation, Quantity: 1, CreatedAt: testTime}))
+	require.NoError(t, repository.Append(t.Context(), &warehouse.StockMovement{ProductID: "A12345", Type: warehouse.StockMovementTypeRelease, Quantity: -1, CreatedAt: testTime}))
+
+	movements, err := repository.FindByProductId(t.Context(), "A12345", testTime, testTime.Add(time.Hour))
+
+	require.NoError(t, err)
+	require.Len(t, movements, 3)
+	// sorted by time, movements with the same time in the order they were appended
+	require.Equal(t, warehouse.StockMovementTypeReservation, movements[0].Type)
+	require.Equal(t, warehouse.StockMovementTypeRelease, movements[1].Type)
+	require.Equal(t, warehouse.StockMovementTypeReceipt, movements[2].Type)
+}
+
+func testFindMovementsReturnsCopies(t *testing.T, repository warehouse.StockMovementRepository) {
+	movement := &warehouse.StockMovement{ProductID: "A12345", Type: warehouse.StockMovementTypeReceipt, Quantity: 5, CreatedAt: testTime}
+
+	require.NoError(t, repository.Append(t.Context(), movement))
+
+	// neither the appended nor a found movement change the ledger
+	movement.Quantity = 50
+	movements, err := repository.FindByProductId(t.Context(), "A12345", testTime, testTime.Add(time.Millisecond))
+	require.NoError(t, err)
+	movements[0].Quantity = 500
+
+	movements, err = repository.FindByProductId(t.Context(), "A12345", testTime, testTime.Add(time.Millisecond))
+
+	require.NoError(t, err)
+	require.Equal(t, 5, movements[0].Quantity)
+}
+
+func testBalance(t *testing.T, repository warehouse.StockMovementRepository) {
+	for _, movement := range []*warehouse.StockMovement{
+		{ProductID: "A12345", Type: warehouse.StockMovementTypeReceipt, Quantity: 10, CreatedAt: testTime},
+		{ProductID: "A12345", Type: warehouse.StockMovementTypeReservation, Quantity: 3, CreatedAt: testTime.Add(time.Minute)},
+		{ProductID: "A12345", Type: warehouse.StockMovementTypeSale, Quantity: -3, CreatedAt: testTime.Add(2 * time.Minute)},
+		{ProductID: "A12345", Type: warehouse.StockMovementTypeRelease, Quantity: -3, CreatedAt: testTime.Add(2 * time.Minute)},
+		{ProductID: "A12346", Type: warehouse.StockMovementTypeReceipt, Quantity: 100, CreatedAt: testTime},
+	} {
+		require.NoError(t, repository.Append(t.Context(), movement))
+	}
+
+	balance, err := repository.Balance(t.Context(), "A12345", time.Time{})
+
+	require.NoError(t, err)
+	require.Equal(t, &warehouse.StockBalance{Stock: 7, Reserved: 0, Movements: 4, Locations: map[string]int{warehouse.DefaultLocationID: 7}}, balance)
+
+	// the movements created at the given time are excluded
+	balance, err = repository.Balance(t.Context(), "A12345", testTime.Add(2*time.Minute))
+
+	require.NoError(t, err)
+	require.Equal(t, &warehouse.StockBalance{Stock: 10, Reserved: 3, Movements: 2, Locations: map[string]int{warehouse.DefaultLocationID: 10}}, balance)
+}
+
+func testBalancePerLocation(t *testing.T, repository warehouse.StockMovementRepository) {
+	for _, movement := range []*warehouse.StockMovement{
+		{ProductID: "A12345", Type: warehouse.StockMovementTypeReceipt, Quantity: 10, CreatedAt: testTime},
+		{ProductID: "A12345", Type: warehouse.StockMovementTypeReceipt, Quantity: 5, LocationID: "hamburg", CreatedAt: testTime},
+		{ProductID: "A12345", Type: warehouse.StockMovementTypeSale, Quantity: -2, LocationID: "hamburg", CreatedAt: testTime},
+		{ProductID: "A12345", Type: warehouse.StockMovementTypeSale, Quantity: -1, LocationID: warehouse.DefaultLocationID, CreatedAt: testTime},
+		{ProductID: "A12345", Type: warehouse.StockMovementTypeReservation, Quantity: 4, CreatedAt: testTime},
+	} {
+		require.NoError(t, repository.Append(t.Context(), movement))
+	}
+
+	balance, err := repository.Balance(t.Context(), "A12345", time.Time{})
+
+	require.NoError(t, err)
+	// the movements without location belong to the default location
+	require.Equal(t, &warehouse.StockBalance{Stock: 12, Reserved: 4, Movements: 5, Locations: map[string]int{warehouse.DefaultLocationID: 9, "hamburg": 3}}, balance)
+}
+
+func testBalanceWithoutMovements(t *testing.T, repository warehouse.StockMovementRepository) {
+	balance, err := repository.Balance(t.Context(), "A12345", time.Time{})
+
+	require.NoError(t, err)
+	require.Equal(t, &warehouse.StockBalance{}, balance)
+}
+
+func testConcurrentAppends(t *testing.T, repository warehouse.StockMovementRepository) {
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, repository.Append(t.Context(), &warehouse.StockMovement{ProductID: "A12345", Type: warehouse.StockMovementTypeReceipt, Quantity: 2, CreatedAt: testTime}))
+		}()
+		go func() {
+			defer wg.Done()
+
+			_, err := repository.Balance(t.Context(), "A12345", time.Time{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	balance, err := repository.Balance(t.Context(), "A12345", time.Time{})
+
+	require.NoError(t, err)
+	require.Equal(t, &warehouse.StockBalance{Stock: 40, Movements: 20, Locations: map[string]int{warehouse.DefaultLocationID: 40}}, balance)
+}
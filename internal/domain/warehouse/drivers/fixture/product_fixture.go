@@ -0,0 +1,222 @@
+// Package fixture reads the product records of fixture files for the seeding of the product catalog.
+package fixture
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatCSV  Format = "csv"
+)
+
+// csvColumns are the columns of the CSV header, the columns after the first requiredCSVColumns are optional
+var csvColumns = []string{"id", "name", "price", "currency", "stock", "taxClass", "stockPolicy", "restockDate", "maxBackorder"}
+
+const requiredCSVColumns = 5
+
+// productFile is the layout of the JSON and YAML files
+type productFile struct {
+	Products []*productRecord `yaml:"products"`
+}
+
+type productRecord struct {
+	ID       string `yaml:"id"`
+	Name     string `yaml:"name"`
+	Price    string `yaml:"price"`
+	Currency string `yaml:"currency"`
+	Stock    int    `yaml:"stock"`
+	TaxClass string `yaml:"taxClass"`
+	// StockPolicy, RestockDate and MaxBackorder allow selling the product beyond its stock
+	StockPolicy  string `yaml:"stockPolicy"`
+	RestockDate  string `yaml:"restockDate"`
+	MaxBackorder int    `yaml:"maxBackorder"`
+}
+
+// FormatFromPath returns the format by the file extension
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".csv":
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("file %s has an unknown extension (must be .json, .yaml, .yml or .csv)", path)
+	}
+}
+
+// LoadProductRecords reads the product records of a JSON, YAML or CSV file
+func LoadProductRecords(path string) ([]*dto.ProductRecord, error) {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open fixture file: %w", err)
+	}
+	defer file.Close()
+
+	records, err := ParseProductRecords(file, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture file %s: %w", path, err)
+	}
+
+	return records, nil
+}
+
+// ParseProductRecords reads the product records in the given format.
+// The records are only parsed, the validation is part of the seed products use case.
+func ParseProductRecords(reader io.Reader, format Format) ([]*dto.ProductRecord, error) {
+	switch format {
+	case FormatJSON, FormatYAML:
+		return parseYAML(reader)
+	case FormatCSV:
+		return parseCSV(reader)
+	default:
+		return nil, fmt.Errorf("format %q is unknown", format)
+	}
+}
+
+// parseYAML rejects unknown keys, so typos do not silently drop values.
+// JSON is parsed by the YAML decoder as well, because it is a subset of YAML.
+// This way the price may be written as number or as string in both formats.
+func parseYAML(reader io.Reader) ([]*dto.ProductRecord, error) {
+	decoder := yaml.NewDecoder(reader)
+	decoder.KnownFields(true)
+
+	var file productFile
+	err := decoder.Decode(&file)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	records := make([]*dto.ProductRecord, 0, len(file.Products))
+	for _, record := range file.Products {
+		if record == nil {
+			records = append(records, nil)
+			continue
+		}
+
+		records = append(records, &dto.ProductRecord{
+			ID:           record.ID,
+			Name:         record.Name,
+			Price:        record.Price,
+			Currency:     record.Currency,
+			Stock:        record.Stock,
+			TaxClass:     record.TaxClass,
+			StockPolicy:  record.StockPolicy,
+			RestockDate:  record.RestockDate,
+			MaxBackorder: record.MaxBackorder,
+		})
+	}
+
+	return records, nil
+}
+
+// parseCSV expects a header row, the columns may be in any order
+func parseCSV(reader io.Reader) ([]*dto.ProductRecord, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if errors.Is(err, io.EOF) {
+		return []*dto.ProductRecord{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if !isCSVColumn(column) {
+			return nil, fmt.Errorf("column %q is unknown (must be one of %s)", column, strings.Join(csvColumns, ", "))
+		} else if _, exists := columns[column]; exists {
+			return nil, fmt.Errorf("column %q is duplicated", column)
+		}
+		columns[column] = i
+	}
+
+	for _, column := range csvColumns[:requiredCSVColumns] {
+		if _, exists := columns[column]; !exists {
+			return nil, fmt.Errorf("column %q is missing", column)
+		}
+	}
+
+	records := make([]*dto.ProductRecord, 0)
+	for {
+		row, rowErr := csvReader.Read()
+		if errors.Is(rowErr, io.EOF) {
+			break
+		} else if rowErr != nil {
+			return nil, rowErr
+		}
+
+		value := func(column string) string {
+			index, exists := columns[column]
+			if !exists {
+				return ""
+			}
+
+			return strings.TrimSpace(row[index])
+		}
+
+		line, _ := csvReader.FieldPos(0)
+
+		stock, stockErr := strconv.Atoi(value("stock"))
+		if stockErr != nil {
+			return nil, fmt.Errorf("line %d: stock %q is not a number", line, value("stock"))
+		}
+
+		// an empty max backorder is 0 like a missing key of the other formats
+		maxBackorder := 0
+		if value("maxBackorder") != "" {
+			var maxBackorderErr error
+			maxBackorder, maxBackorderErr = strconv.Atoi(value("maxBackorder"))
+			if maxBackorderErr != nil {
+				return nil, fmt.Errorf("line %d: maxBackorder %q is not a number", line, value("maxBackorder"))
+			}
+		}
+
+		records = append(records, &dto.ProductRecord{
+			ID:           value("id"),
+			Name:         value("name"),
+			Price:        value("price"),
+			Currency:     value("currency"),
+			Stock:        stock,
+			TaxClass:     value("taxClass"),
+			StockPolicy:  value("stockPolicy"),
+			RestockDate:  value("restockDate"),
+			MaxBackorder: maxBackorder,
+		})
+	}
+
+	return records, nil
+}
+
+func isCSVColumn(column string) bool {
+	for _, csvColumn := range csvColumns {
+		if column == csvColumn {
+			return true
+		}
+	}
+
+	return false
+}
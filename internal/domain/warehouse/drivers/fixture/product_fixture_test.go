@@ -0,0 +1,168 @@
+package fixture
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/usecases/dto"
+)
+
+func Test_ParseProductRecords(t *testing.T) {
+	expectedRecords := []*dto.ProductRecord{
+		{ID: "A12341", Name: "Product 1", Price: "11.99", Currency: "EUR", Stock: 10},
+		{ID: "A12342", Name: "Product 2", Price: "12.90", Currency: "EUR", Stock: 20, TaxClass: "reduced"},
+		{ID: "A12344", Name: "Product 4", Price: "14.99", Currency: "EUR", StockPolicy: "backorder", RestockDate: "2026-12-01", MaxBackorder: 25},
+	}
+
+	testCases := map[string]struct {
+		format   Format
+		content  string
+		expected []*dto.ProductRecord
+	}{
+		"json": {
+			format:   FormatJSON,
+			content:  `{"products": [{"id": "A12341", "name": "Product 1", "price": "11.99", "currency": "EUR", "stock": 10}, {"id": "A12342", "name": "Product 2", "price": 12.90, "currency": "EUR", "stock": 20, "taxClass": "reduced"}, {"id": "A12344", "name": "Product 4", "price": "14.99", "currency": "EUR", "stock": 0, "stockPolicy": "backorder", "restockDate": "2026-12-01", "maxBackorder": 25}]}`,
+			expected: expectedRecords,
+		},
+		"yaml": {
+			format: FormatYAML,
+			content: `
+products:
+  - id: A12341
+    name: Product 1
+    price: "11.99"
+    currency: EUR
+    stock: 10
+  - id: A12342
+    name: Product 2
+    price: 12.90
+    currency: EUR
+    stock: 20
+    taxClass: reduced
+  - id: A12344
+    name: Product 4
+    price: "14.99"
+    currency: EUR
+    stock: 0
+    stockPolicy: backorder
+    restockDate: "2026-12-01"
+    maxBackorder: 25
+`,
+			expected: expectedRecords,
+		},
+		"csv": {
+			format:   FormatCSV,
+			content:  "id,name,price,currency,stock,taxClass,stockPolicy,restockDate,maxBackorder\nA12341,Product 1,11.99,EUR,10,,,,\nA12342, Product 2 ,12.90,EUR,20,reduced,,,\nA12344,Product 4,14.99,EUR,0,,backorder,2026-12-01,25\n",
+			expected: expectedRecords,
+		},
+		"csv with other column order and without taxClass": {
+			format:   FormatCSV,
+			content:  "name,id,currency,price,stock\nProduct 1,A12341,EUR,11.99,10\n",
+			expected: expectedRecords[:1],
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			records, err := ParseProductRecords(strings.NewReader(testCase.content), testCase.format)
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, records)
+		})
+	}
+}
+
+func Test_ParseProductRecords_Empty(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatYAML, FormatCSV} {
+		records, err := ParseProductRecords(strings.NewReader(""), format)
+
+		require.NoError(t, err)
+		require.Empty(t, records)
+	}
+}
+
+func Test_ParseProductRecords_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		format  Format
+		content string
+	}{
+		"field prize not found": {
+			format:  FormatYAML,
+			content: "products:\n  - id: A12341\n    prize: \"11.99\"\n",
+		},
+		"cannot unmarshal": {
+			format:  FormatJSON,
+			content: `{"products": [{"id": "A12341", "stock": "many"}]}`,
+		},
+		"column \"prize\" is unknown": {
+			format:  FormatCSV,
+			content: "id,name,prize,currency,stock\n",
+		},
+		"column \"stock\" is missing": {
+			format:  FormatCSV,
+			content: "id,name,price,currency\n",
+		},
+		"column \"id\" is duplicated": {
+			format:  FormatCSV,
+			content: "id,id,name,price,currency,stock\n",
+		},
+		"line 3: stock \"many\" is not a number": {
+			format:  FormatCSV,
+			content: "id,name,price,currency,stock\nA12341,Product 1,11.99,EUR,10\nA12342,Product 2,12.99,EUR,many\n",
+		},
+		"line 2: maxBackorder \"many\" is not a number": {
+			format:  FormatCSV,
+			content: "id,name,price,currency,stock,stockPolicy,maxBackorder\nA12344,Product 4,14.99,EUR,0,backorder,many\n",
+		},
+		"wrong number of fields": {
+			format:  FormatCSV,
+			content: "id,name,price,currency,stock\nA12341,Product 1\n",
+		},
+		"format \"xml\" is unknown": {
+			format: "xml",
+		},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			records, err := ParseProductRecords(strings.NewReader(testCase.content), testCase.format)
+
+			require.ErrorContains(t, err, errorString)
+			require.Nil(t, records)
+		})
+	}
+}
+
+func Test_LoadProductRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "products.yml")
+	require.NoError(t, os.WriteFile(path, []byte("products:\n  - id: A12341\n"), 0o600))
+
+	records, err := LoadProductRecords(path)
+
+	require.NoError(t, err)
+	require.Equal(t, []*dto.ProductRecord{{ID: "A12341"}}, records)
+}
+
+func Test_LoadProductRecords_ReturnsError(t *testing.T) {
+	records, err := LoadProductRecords(filepath.Join(t.TempDir(), "products.xml"))
+
+	require.ErrorContains(t, err, "unknown extension")
+	require.Nil(t, records)
+
+	records, err = LoadProductRecords(filepath.Join(t.TempDir(), "missing.csv"))
+
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.Nil(t, records)
+}
+
+func Test_LoadProductRecords_DemoFixture(t *testing.T) {
+	records, err := LoadProductRecords(filepath.Join("..", "..", "..", "..", "..", "fixtures", "products.yaml"))
+
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+	require.Equal(t, &dto.ProductRecord{ID: "A12342", Name: "Product 2", Price: "12.99", Currency: "EUR", Stock: 20, TaxClass: "reduced"}, records[1])
+}
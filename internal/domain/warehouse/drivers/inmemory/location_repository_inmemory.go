@@ -0,0 +1,76 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+var _ warehouse.LocationRepository = (*InMemoryLocationRepository)(nil)
+
+// InMemoryLocationRepository is the only driver of the locations, they are configured and saved on every start
+type InMemoryLocationRepository struct {
+	mutex     sync.RWMutex
+	locations map[string]*warehouse.Location
+}
+
+func NewInMemoryLocationRepository() warehouse.LocationRepository {
+	return &InMemoryLocationRepository{
+		locations: make(map[string]*warehouse.Location),
+	}
+}
+
+func (repository *InMemoryLocationRepository) Find(ctx context.Context, id string) (*warehouse.Location, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	location, locationExists := repository.locations[id]
+	if !locationExists {
+		return nil, &domainerror.NotFoundError{Resource: warehouse.LocationResource, ID: id}
+	}
+
+	locationCopy := *location
+
+	return &locationCopy, nil
+}
+
+// FindAll returns the locations sorted by their id
+func (repository *InMemoryLocationRepository) FindAll(ctx context.Context) ([]*warehouse.Location, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	locations := make([]*warehouse.Location, 0, len(repository.locations))
+	for _, location := range repository.locations {
+		locationCopy := *location
+		locations = append(locations, &locationCopy)
+	}
+
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].ID < locations[j].ID
+	})
+
+	return locations, nil
+}
+
+func (repository *InMemoryLocationRepository) Save(ctx context.Context, location *warehouse.Location) error {
+	if location == nil {
+		return fmt.Errorf("location is nil")
+	}
+
+	validateErr := location.Validate()
+	if validateErr != nil {
+		return validateErr
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	locationCopy := *location
+	repository.locations[location.ID] = &locationCopy
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+func Test_InMemoryLocationRepository(t *testing.T) {
+	repository := NewInMemoryLocationRepository()
+
+	location, err := repository.Find(t.Context(), warehouse.DefaultLocationID)
+
+	require.True(t, domainerror.IsNotFound(err, warehouse.LocationResource))
+	require.Nil(t, location)
+
+	require.Error(t, repository.Save(t.Context(), nil))
+	require.Error(t, repository.Save(t.Context(), &warehouse.Location{ID: "hamburg", Position: warehouse.Position{Latitude: 100}}))
+
+	hamburg := &warehouse.Location{ID: "hamburg", Name: "Hamburg", Position: warehouse.Position{Latitude: 53.551, Longitude: 9.994}}
+	require.NoError(t, repository.Save(t.Context(), hamburg))
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Location{ID: warehouse.DefaultLocationID, Name: "Berlin", Position: warehouse.Position{Latitude: 52.52, Longitude: 13.405}}))
+
+	// changes of the saved location are not stored without calling Save
+	hamburg.Name = "Altona"
+
+	location, err = repository.Find(t.Context(), "hamburg")
+
+	require.NoError(t, err)
+	require.Equal(t, "Hamburg", location.Name)
+
+	locations, err := repository.FindAll(t.Context())
+
+	require.NoError(t, err)
+	require.Len(t, locations, 2)
+	require.Equal(t, "hamburg", locations[0].ID)
+	require.Equal(t, warehouse.DefaultLocationID, locations[1].ID)
+}
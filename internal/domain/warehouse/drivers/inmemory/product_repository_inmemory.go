@@ -1,14 +1,21 @@
 package inmemory
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"sync"
 
 	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
 )
 
 var _ warehouse.ProductRepository = (*InMemoryProductRepository)(nil)
 
+// InMemoryProductRepository stores copies of the products,
+// so like with the mongodb driver a product only changes by calling Save
 type InMemoryProductRepository struct {
+	mutex    sync.RWMutex
 	products map[string]*warehouse.Product
 }
 
@@ -18,25 +25,50 @@ func NewInMemoryProductRepository() warehouse.ProductRepository {
 	}
 }
 
-func (repository *InMemoryProductRepository) Find(id string) (*warehouse.Product, error) {
+func (repository *InMemoryProductRepository) Find(ctx context.Context, id string) (*warehouse.Product, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
 	product, productExists := repository.products[id]
 	if !productExists {
-		return nil, fmt.Errorf("product not found")
+		return nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: id}
 	}
 
-	return product, nil
+	return copyProduct(product), nil
 }
 
-func (repository *InMemoryProductRepository) FindAll() []*warehouse.Product {
-	products := []*warehouse.Product{}
+// FindAll returns the products sorted by their id
+func (repository *InMemoryProductRepository) FindAll(ctx context.Context) ([]*warehouse.Product, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
 
+	products := make([]*warehouse.Product, 0, len(repository.products))
 	for _, product := range repository.products {
-		products = append(products, product)
+		products = append(products, copyProduct(product))
 	}
 
-	return products
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].ID < products[j].ID
+	})
+
+	return products, nil
 }
 
-func (repository *InMemoryProductRepository) Save(product *warehouse.Product) {
-	repository.products[product.ID] = product
+func (repository *InMemoryProductRepository) Save(ctx context.Context, product *warehouse.Product) error {
+	if product == nil {
+		return fmt.Errorf("product is nil")
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	repository.products[product.ID] = copyProduct(product)
+
+	return nil
+}
+
+func copyProduct(product *warehouse.Product) *warehouse.Product {
+	productCopy := *product
+
+	return &productCopy
 }
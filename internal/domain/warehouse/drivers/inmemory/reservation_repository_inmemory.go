@@ -0,0 +1,119 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+var _ warehouse.ReservationRepository = (*InMemoryReservationRepository)(nil)
+
+type reservationKey struct {
+	holderID  string
+	productID string
+}
+
+// InMemoryReservationRepository stores copies of the reservations,
+// so like with the mongodb driver a reservation only changes by calling Save
+type InMemoryReservationRepository struct {
+	mutex        sync.RWMutex
+	reservations map[reservationKey]*warehouse.Reservation
+}
+
+func NewInMemoryReservationRepository() warehouse.ReservationRepository {
+	return &InMemoryReservationRepository{
+		reservations: make(map[reservationKey]*warehouse.Reservation),
+	}
+}
+
+func (repository *InMemoryReservationRepository) Find(ctx context.Context, holderID string, productID string) (*warehouse.Reservation, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	reservation, reservationExists := repository.reservations[reservationKey{holderID: holderID, productID: productID}]
+	if !reservationExists {
+		return nil, &domainerror.NotFoundError{Resource: warehouse.ReservationResource}
+	}
+
+	return copyReservation(reservation), nil
+}
+
+func (repository *InMemoryReservationRepository) FindByProductId(ctx context.Context, productID string) ([]*warehouse.Reservation, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	reservations := make([]*warehouse.Reservation, 0)
+	for _, reservation := range repository.reservations {
+		if reservation.GetProductID() == productID {
+			reservations = append(reservations, copyReservation(reservation))
+		}
+	}
+
+	return reservations, nil
+}
+
+func (repository *InMemoryReservationRepository) FindByHolderId(ctx context.Context, holderID string) ([]*warehouse.Reservation, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	reservations := make([]*warehouse.Reservation, 0)
+	for _, reservation := range repository.reservations {
+		if reservation.GetHolderID() == holderID {
+			reservations = append(reservations, copyReservation(reservation))
+		}
+	}
+
+	return reservations, nil
+}
+
+func (repository *InMemoryReservationRepository) Save(ctx context.Context, reservation *warehouse.Reservation) error {
+	if reservation == nil {
+		return fmt.Errorf("reservation is nil")
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	repository.reservations[reservationKey{holderID: reservation.GetHolderID(), productID: reservation.GetProductID()}] = copyReservation(reservation)
+
+	return nil
+}
+
+func (repository *InMemoryReservationRepository) Delete(ctx context.Context, holderID string, productID string) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	key := reservationKey{holderID: holderID, productID: productID}
+	if _, reservationExists := repository.reservations[key]; !reservationExists {
+		return &domainerror.NotFoundError{Resource: warehouse.ReservationResource}
+	}
+
+	delete(repository.reservations, key)
+
+	return nil
+}
+
+func (repository *InMemoryReservationRepository) DeleteExpired(ctx context.Context, now time.Time) ([]*warehouse.Reservation, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	deleted := make([]*warehouse.Reservation, 0)
+	for key, reservation := range repository.reservations {
+		if !reservation.IsActive(now) {
+			delete(repository.reservations, key)
+			deleted = append(deleted, reservation)
+		}
+	}
+
+	return deleted, nil
+}
+
+func copyReservation(reservation *warehouse.Reservation) *warehouse.Reservation {
+	reservationCopy := *reservation
+
+	return &reservationCopy
+}
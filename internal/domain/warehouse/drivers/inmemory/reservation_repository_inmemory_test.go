@@ -0,0 +1,14 @@
+package inmemory
+
+import (
+	"testing"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/conformance"
+)
+
+func Test_InMemoryReservationRepository_Conformance(t *testing.T) {
+	conformance.RunReservationRepositoryTests(t, func(t *testing.T) warehouse.ReservationRepository {
+		return NewInMemoryReservationRepository()
+	})
+}
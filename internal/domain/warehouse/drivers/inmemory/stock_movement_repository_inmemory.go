@@ -0,0 +1,86 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+)
+
+var _ warehouse.StockMovementRepository = (*InMemoryStockMovementRepository)(nil)
+
+// InMemoryStockMovementRepository stores copies of the movements per product in the order they were appended
+type InMemoryStockMovementRepository struct {
+	mutex       sync.RWMutex
+	movements   map[string][]*warehouse.StockMovement
+	movementIDs map[string]bool
+}
+
+func NewInMemoryStockMovementRepository() warehouse.StockMovementRepository {
+	return &InMemoryStockMovementRepository{
+		movements:   make(map[string][]*warehouse.StockMovement),
+		movementIDs: make(map[string]bool),
+	}
+}
+
+func (repository *InMemoryStockMovementRepository) Append(ctx context.Context, movement *warehouse.StockMovement) error {
+	if movement == nil {
+		return fmt.Errorf("movement is nil")
+	} else if movement.ProductID == "" {
+		return fmt.Errorf("movement product id is empty")
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	if movement.ID == "" {
+		movement.ID = uuid.NewString()
+	} else if repository.movementIDs[movement.ID] {
+		return fmt.Errorf("movement %s already exists", movement.ID)
+	}
+
+	storedMovement := *movement
+	repository.movements[movement.ProductID] = append(repository.movements[movement.ProductID], &storedMovement)
+	repository.movementIDs[movement.ID] = true
+
+	return nil
+}
+
+func (repository *InMemoryStockMovementRepository) FindByProductId(ctx context.Context, productID string, from time.Time, to time.Time) ([]*warehouse.StockMovement, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	movements := make([]*warehouse.StockMovement, 0)
+	for _, movement := range repository.movements[productID] {
+		if !movement.CreatedAt.Before(from) && movement.CreatedAt.Before(to) {
+			foundMovement := *movement
+			movements = append(movements, &foundMovement)
+		}
+	}
+
+	// a movement can be appended with an earlier time than the previous one, the stable sort keeps the order of equal times
+	sort.SliceStable(movements, func(i, j int) bool {
+		return movements[i].CreatedAt.Before(movements[j].CreatedAt)
+	})
+
+	return movements, nil
+}
+
+func (repository *InMemoryStockMovementRepository) Balance(ctx context.Context, productID string, before time.Time) (*warehouse.StockBalance, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	balance := &warehouse.StockBalance{}
+	for _, movement := range repository.movements[productID] {
+		if before.IsZero() || movement.CreatedAt.Before(before) {
+			balance.Add(movement)
+		}
+	}
+
+	return balance, nil
+}
@@ -0,0 +1,14 @@
+package inmemory
+
+import (
+	"testing"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/conformance"
+)
+
+func Test_InMemoryStockMovementRepository_Conformance(t *testing.T) {
+	conformance.RunStockMovementRepositoryTests(t, func(t *testing.T) warehouse.StockMovementRepository {
+		return NewInMemoryStockMovementRepository()
+	})
+}
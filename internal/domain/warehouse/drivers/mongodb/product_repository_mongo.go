@@ -0,0 +1,91 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+const (
+	DatabaseName           = "ecommerce"
+	ProductsCollectionName = "products"
+)
+
+var _ warehouse.ProductRepository = (*MongoProductRepository)(nil)
+
+type MongoProductRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoProductRepository creates the indexes of the collection, so it needs a connection to the database
+func NewMongoProductRepository(ctx context.Context, collection *mongo.Collection) (warehouse.ProductRepository, error) {
+	if collection == nil {
+		return nil, fmt.Errorf("collection is nil")
+	}
+
+	// the id is unique, so concurrent upserts of a new product cannot create duplicates
+	_, indexErr := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "id", Value: 1}},
+		Options: options.Index().SetName("id_unique").SetUnique(true),
+	})
+	if indexErr != nil {
+		return nil, fmt.Errorf("failed to create the indexes of collection %s: %w", collection.Name(), indexErr)
+	}
+
+	return &MongoProductRepository{
+		collection: collection,
+	}, nil
+}
+
+func (repository *MongoProductRepository) Find(ctx context.Context, id string) (*warehouse.Product, error) {
+	result := repository.collection.FindOne(ctx, bson.M{"id": id})
+	if result.Err() != nil {
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: id}
+		}
+		return nil, result.Err()
+	}
+
+	var product warehouse.Product
+	decodeErr := result.Decode(&product)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	return &product, nil
+}
+
+// FindAll returns the products sorted by their id
+func (repository *MongoProductRepository) FindAll(ctx context.Context) ([]*warehouse.Product, error) {
+	cursor, findErr := repository.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "id", Value: 1}}))
+	if findErr != nil {
+		return nil, findErr
+	}
+
+	products := make([]*warehouse.Product, 0)
+	decodeErr := cursor.All(ctx, &products)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	return products, nil
+}
+
+func (repository *MongoProductRepository) Save(ctx context.Context, product *warehouse.Product) error {
+	if product == nil {
+		return fmt.Errorf("product is nil")
+	} else if product.ID == "" {
+		return fmt.Errorf("product id is empty")
+	}
+
+	_, replaceErr := repository.collection.ReplaceOne(ctx, bson.M{"id": product.ID}, product, options.Replace().SetUpsert(true))
+
+	return replaceErr
+}
@@ -0,0 +1,135 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/mongodb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/conformance"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+)
+
+func initTestcontainers(t *testing.T) (string, func()) {
+	ctx := context.Background()
+
+	mongodbContainer, err := mongodb.Run(ctx, "mongodb/mongodb-community-server:8.0-ubi8")
+	stop := func() {
+		if err := testcontainers.TerminateContainer(mongodbContainer); err != nil {
+			log.Printf("failed to terminate container: %s", err)
+		}
+	}
+
+	require.NoError(t, err)
+	require.NotNil(t, mongodbContainer)
+
+	endpoint, err := mongodbContainer.ConnectionString(ctx)
+	if err != nil {
+		log.Fatalf("failed to get connection string: %s", err)
+	}
+
+	return endpoint, stop
+}
+
+func Test_MongoProductRepository_NewMongoProductRepository_ReturnsError(t *testing.T) {
+	repository, err := NewMongoProductRepository(t.Context(), nil)
+
+	require.Error(t, err)
+	require.Nil(t, repository)
+}
+
+func Test_MongoProductRepository_Conformance(t *testing.T) {
+	endpoint, stop := initTestcontainers(t)
+	defer stop()
+
+	clientOpts := options.Client().ApplyURI(endpoint)
+	mongoClient, mongoClientErr := mongo.Connect(clientOpts)
+	require.NoError(t, mongoClientErr)
+	defer func() {
+		if mongoClientErr = mongoClient.Disconnect(context.TODO()); mongoClientErr != nil {
+			panic(mongoClientErr)
+		}
+	}()
+
+	conformance.RunProductRepositoryTests(t, func(t *testing.T) warehouse.ProductRepository {
+		// every test gets an empty collection
+		productsCollection := mongoClient.Database(DatabaseName).Collection(ProductsCollectionName)
+		require.NoError(t, productsCollection.Drop(context.Background()))
+
+		repository, err := NewMongoProductRepository(t.Context(), productsCollection)
+		require.NoError(t, err)
+
+		return repository
+	})
+}
+
+func Test_MongoProductRepository_Indexes(t *testing.T) {
+	endpoint, stop := initTestcontainers(t)
+	defer stop()
+
+	clientOpts := options.Client().ApplyURI(endpoint)
+	mongoClient, mongoClientErr := mongo.Connect(clientOpts)
+	require.NoError(t, mongoClientErr)
+	defer func() {
+		if mongoClientErr = mongoClient.Disconnect(context.TODO()); mongoClientErr != nil {
+			panic(mongoClientErr)
+		}
+	}()
+
+	productsCollection := mongoClient.Database(DatabaseName).Collection(ProductsCollectionName)
+	repository, err := NewMongoProductRepository(t.Context(), productsCollection)
+
+	require.NoError(t, err)
+
+	// creating the indexes again is a no-op
+	_, err = NewMongoProductRepository(t.Context(), productsCollection)
+
+	require.NoError(t, err)
+
+	require.NoError(t, repository.Save(t.Context(), &warehouse.Product{ID: "A12345", Price: money.New(1599, "EUR")}))
+	require.Error(t, repository.Save(t.Context(), &warehouse.Product{}))
+
+	// the unique index rejects a second document with the same id
+	_, err = productsCollection.InsertOne(t.Context(), &warehouse.Product{ID: "A12345"})
+
+	require.True(t, mongo.IsDuplicateKeyError(err))
+
+	count, err := productsCollection.CountDocuments(t.Context(), bson.M{})
+
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func Test_MongoProductRepository_FindAll_ReturnsError(t *testing.T) {
+	endpoint, stop := initTestcontainers(t)
+	defer stop()
+
+	clientOpts := options.Client().ApplyURI(endpoint)
+	mongoClient, mongoClientErr := mongo.Connect(clientOpts)
+	require.NoError(t, mongoClientErr)
+	defer func() {
+		if mongoClientErr = mongoClient.Disconnect(context.TODO()); mongoClientErr != nil {
+			panic(mongoClientErr)
+		}
+	}()
+
+	productsCollection := mongoClient.Database(DatabaseName).Collection(ProductsCollectionName)
+	repository, err := NewMongoProductRepository(t.Context(), productsCollection)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	products, err := repository.FindAll(ctx)
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.Nil(t, products)
+	require.Error(t, repository.Save(ctx, &warehouse.Product{ID: "A12345"}))
+}
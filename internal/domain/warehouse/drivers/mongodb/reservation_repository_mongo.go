@@ -0,0 +1,151 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+const ReservationsCollectionName = "reservations"
+
+var _ warehouse.ReservationRepository = (*MongoReservationRepository)(nil)
+
+type MongoReservationRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoReservationRepository creates the indexes of the collection, so it needs a connection to the database
+func NewMongoReservationRepository(ctx context.Context, collection *mongo.Collection) (warehouse.ReservationRepository, error) {
+	if collection == nil {
+		return nil, fmt.Errorf("collection is nil")
+	}
+
+	// the unique holder and product prevent a second reservation from concurrent upserts, the other indexes serve the queries
+	_, indexErr := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "holderid", Value: 1}, {Key: "productid", Value: 1}},
+			Options: options.Index().SetName("holderid_productid_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "productid", Value: 1}},
+			Options: options.Index().SetName("productid"),
+		},
+		{
+			Keys:    bson.D{{Key: "expiresat", Value: 1}},
+			Options: options.Index().SetName("expiresat"),
+		},
+	})
+	if indexErr != nil {
+		return nil, fmt.Errorf("failed to create the indexes of collection %s: %w", collection.Name(), indexErr)
+	}
+
+	return &MongoReservationRepository{
+		collection: collection,
+	}, nil
+}
+
+func (repository *MongoReservationRepository) Find(ctx context.Context, holderID string, productID string) (*warehouse.Reservation, error) {
+	result := repository.collection.FindOne(ctx, bson.M{"holderid": holderID, "productid": productID})
+	if result.Err() != nil {
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return nil, &domainerror.NotFoundError{Resource: warehouse.ReservationResource}
+		}
+		return nil, result.Err()
+	}
+
+	var reservation warehouse.Reservation
+	decodeErr := result.Decode(&reservation)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	return &reservation, nil
+}
+
+func (repository *MongoReservationRepository) FindByProductId(ctx context.Context, productID string) ([]*warehouse.Reservation, error) {
+	return repository.find(ctx, bson.M{"productid": productID})
+}
+
+func (repository *MongoReservationRepository) FindByHolderId(ctx context.Context, holderID string) ([]*warehouse.Reservation, error) {
+	return repository.find(ctx, bson.M{"holderid": holderID})
+}
+
+func (repository *MongoReservationRepository) Save(ctx context.Context, reservation *warehouse.Reservation) error {
+	if reservation == nil {
+		return fmt.Errorf("reservation is nil")
+	}
+
+	filter := bson.M{"holderid": reservation.GetHolderID(), "productid": reservation.GetProductID()}
+	_, replaceErr := repository.collection.ReplaceOne(ctx, filter, reservation, options.Replace().SetUpsert(true))
+
+	return replaceErr
+}
+
+func (repository *MongoReservationRepository) Delete(ctx context.Context, holderID string, productID string) error {
+	result, deleteErr := repository.collection.DeleteOne(ctx, bson.M{"holderid": holderID, "productid": productID})
+	if deleteErr != nil {
+		return deleteErr
+	}
+	if result.DeletedCount == 0 {
+		return &domainerror.NotFoundError{Resource: warehouse.ReservationResource}
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes the expired reservations one by one and returns the deleted documents,
+// so a reservation which is saved again meanwhile is neither deleted nor returned
+func (repository *MongoReservationRepository) DeleteExpired(ctx context.Context, now time.Time) ([]*warehouse.Reservation, error) {
+	expired, findErr := repository.find(ctx, bson.M{"expiresat": bson.M{"$lte": now}})
+	if findErr != nil {
+		return nil, findErr
+	}
+
+	deleted := make([]*warehouse.Reservation, 0, len(expired))
+	for _, reservation := range expired {
+		result := repository.collection.FindOneAndDelete(ctx, bson.M{
+			"holderid":  reservation.GetHolderID(),
+			"productid": reservation.GetProductID(),
+			"expiresat": bson.M{"$lte": now},
+		})
+		if result.Err() != nil {
+			if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+				continue
+			}
+			return deleted, result.Err()
+		}
+
+		var deletedReservation warehouse.Reservation
+		decodeErr := result.Decode(&deletedReservation)
+		if decodeErr != nil {
+			return deleted, decodeErr
+		}
+
+		deleted = append(deleted, &deletedReservation)
+	}
+
+	return deleted, nil
+}
+
+func (repository *MongoReservationRepository) find(ctx context.Context, filter bson.M) ([]*warehouse.Reservation, error) {
+	cursor, findErr := repository.collection.Find(ctx, filter)
+	if findErr != nil {
+		return nil, findErr
+	}
+
+	reservations := make([]*warehouse.Reservation, 0)
+	decodeErr := cursor.All(ctx, &reservations)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	return reservations, nil
+}
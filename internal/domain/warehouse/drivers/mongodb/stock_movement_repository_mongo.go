@@ -0,0 +1,132 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+)
+
+const StockMovementsCollectionName = "stock_movements"
+
+var _ warehouse.StockMovementRepository = (*MongoStockMovementRepository)(nil)
+
+type MongoStockMovementRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStockMovementRepository creates the indexes of the collection, so it needs a connection to the database
+func NewMongoStockMovementRepository(ctx context.Context, collection *mongo.Collection) (warehouse.StockMovementRepository, error) {
+	if collection == nil {
+		return nil, fmt.Errorf("collection is nil")
+	}
+
+	// the unique id prevents a second movement with the same id, the other index serves the queries per product
+	_, indexErr := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetName("id_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "productid", Value: 1}, {Key: "createdat", Value: 1}},
+			Options: options.Index().SetName("productid_createdat"),
+		},
+	})
+	if indexErr != nil {
+		return nil, fmt.Errorf("failed to create the indexes of collection %s: %w", collection.Name(), indexErr)
+	}
+
+	return &MongoStockMovementRepository{
+		collection: collection,
+	}, nil
+}
+
+func (repository *MongoStockMovementRepository) Append(ctx context.Context, movement *warehouse.StockMovement) error {
+	if movement == nil {
+		return fmt.Errorf("movement is nil")
+	} else if movement.ProductID == "" {
+		return fmt.Errorf("movement product id is empty")
+	}
+
+	storedMovement := *movement
+	if storedMovement.ID == "" {
+		storedMovement.ID = uuid.NewString()
+	}
+
+	_, insertErr := repository.collection.InsertOne(ctx, &storedMovement)
+	if insertErr != nil {
+		return insertErr
+	}
+
+	movement.ID = storedMovement.ID
+
+	return nil
+}
+
+// FindByProductId sorts the movements with the same time by their _id, which increases in the order they were appended
+func (repository *MongoStockMovementRepository) FindByProductId(ctx context.Context, productID string, from time.Time, to time.Time) ([]*warehouse.StockMovement, error) {
+	filter := bson.M{
+		"productid": productID,
+		"createdat": bson.M{"$gte": from, "$lt": to},
+	}
+
+	cursor, findErr := repository.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdat", Value: 1}, {Key: "_id", Value: 1}}))
+	if findErr != nil {
+		return nil, findErr
+	}
+
+	movements := make([]*warehouse.StockMovement, 0)
+	decodeErr := cursor.All(ctx, &movements)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	return movements, nil
+}
+
+// Balance sums up the quantities per type and location in the database, so the movements are not loaded
+func (repository *MongoStockMovementRepository) Balance(ctx context.Context, productID string, before time.Time) (*warehouse.StockBalance, error) {
+	match := bson.M{"productid": productID}
+	if !before.IsZero() {
+		match["createdat"] = bson.M{"$lt": before}
+	}
+
+	cursor, aggregateErr := repository.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "type", Value: "$type"}, {Key: "locationid", Value: "$locationid"}}},
+			{Key: "quantity", Value: bson.M{"$sum": "$quantity"}},
+			{Key: "movements", Value: bson.M{"$sum": 1}},
+		}}},
+	})
+	if aggregateErr != nil {
+		return nil, aggregateErr
+	}
+
+	var sums []struct {
+		Key struct {
+			Type string `bson:"type"`
+			// LocationID is missing in the movements stored before there were several locations
+			LocationID string `bson:"locationid"`
+		} `bson:"_id"`
+		Quantity  int `bson:"quantity"`
+		Movements int `bson:"movements"`
+	}
+	decodeErr := cursor.All(ctx, &sums)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	balance := &warehouse.StockBalance{}
+	for _, sum := range sums {
+		balance.AddSum(warehouse.StockMovementType(sum.Key.Type), sum.Key.LocationID, sum.Quantity, sum.Movements)
+	}
+
+	return balance, nil
+}
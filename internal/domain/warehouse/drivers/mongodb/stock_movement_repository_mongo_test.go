@@ -0,0 +1,45 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/conformance"
+)
+
+func Test_MongoStockMovementRepository_NewMongoStockMovementRepository_ReturnsError(t *testing.T) {
+	repository, err := NewMongoStockMovementRepository(t.Context(), nil)
+
+	require.Error(t, err)
+	require.Nil(t, repository)
+}
+
+func Test_MongoStockMovementRepository_Conformance(t *testing.T) {
+	endpoint, stop := initTestcontainers(t)
+	defer stop()
+
+	clientOpts := options.Client().ApplyURI(endpoint)
+	mongoClient, mongoClientErr := mongo.Connect(clientOpts)
+	require.NoError(t, mongoClientErr)
+	defer func() {
+		if mongoClientErr = mongoClient.Disconnect(context.TODO()); mongoClientErr != nil {
+			panic(mongoClientErr)
+		}
+	}()
+
+	conformance.RunStockMovementRepositoryTests(t, func(t *testing.T) warehouse.StockMovementRepository {
+		// every test gets an empty collection
+		stockMovementsCollection := mongoClient.Database(DatabaseName).Collection(StockMovementsCollectionName)
+		require.NoError(t, stockMovementsCollection.Drop(context.Background()))
+
+		repository, err := NewMongoStockMovementRepository(t.Context(), stockMovementsCollection)
+		require.NoError(t, err)
+
+		return repository
+	})
+}
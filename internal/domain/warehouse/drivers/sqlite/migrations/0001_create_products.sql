@@ -0,0 +1,10 @@
+CREATE TABLE products (
+    id             TEXT    PRIMARY KEY,
+    name           TEXT    NOT NULL,
+    -- the price in the minor unit of the currency, e.g. cents for EUR
+    price_amount   INTEGER NOT NULL,
+    price_currency TEXT    NOT NULL,
+    stock          INTEGER NOT NULL,
+    -- an empty tax class is the standard class
+    tax_class      TEXT    NOT NULL DEFAULT ''
+);
@@ -0,0 +1,2 @@
+-- deactivated products are not sold anymore, 0 is false and 1 is true
+ALTER TABLE products ADD COLUMN deactivated INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,15 @@
+-- the stock ledger, the rows are only inserted and never updated or deleted
+CREATE TABLE stock_movements (
+    id         TEXT    PRIMARY KEY,
+    product_id TEXT    NOT NULL,
+    type       TEXT    NOT NULL,
+    -- positive if units are added or reserved, negative if units are removed or released
+    quantity   INTEGER NOT NULL,
+    -- only set for adjustments
+    reason     TEXT    NOT NULL DEFAULT '',
+    reference  TEXT    NOT NULL DEFAULT '',
+    -- unix milliseconds
+    created_at INTEGER NOT NULL
+);
+
+CREATE INDEX stock_movements_product_id_created_at ON stock_movements (product_id, created_at);
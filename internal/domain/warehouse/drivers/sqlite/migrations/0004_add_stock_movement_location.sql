@@ -0,0 +1,2 @@
+-- the location whose stock is changed, the stock of the movements recorded before belongs to the default location
+ALTER TABLE stock_movements ADD COLUMN location_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- an empty stock policy only sells the stock, backorder and preorder sell up to max_backorder units more
+ALTER TABLE products ADD COLUMN stock_policy TEXT NOT NULL DEFAULT '';
+-- unix milliseconds, 0 if there is no restock date
+ALTER TABLE products ADD COLUMN restock_date INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN max_backorder INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,12 @@
+-- the reservations of the basket holders, at most one per holder and product
+CREATE TABLE reservations (
+    holder_id  TEXT    NOT NULL,
+    product_id TEXT    NOT NULL,
+    count      INTEGER NOT NULL,
+    -- unix milliseconds
+    expires_at INTEGER NOT NULL,
+    PRIMARY KEY (holder_id, product_id)
+);
+
+CREATE INDEX reservations_product_id ON reservations (product_id);
+CREATE INDEX reservations_expires_at ON reservations (expires_at);
@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"time"
+
+	tax "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/tax/business/entities"
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/sqlite"
+)
+
+// MigrationComponent prefixes the migrations of the warehouse in the schema_migrations table
+const MigrationComponent = "warehouse"
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Migrate creates or updates the tables of the warehouse
+func Migrate(ctx context.Context, db *sql.DB) error {
+	return sqlite.Migrate(ctx, db, MigrationComponent, migrations)
+}
+
+var _ warehouse.ProductRepository = (*SQLiteProductRepository)(nil)
+
+// SQLiteProductRepository stores the products in the products table created by Migrate
+type SQLiteProductRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteProductRepository(db *sql.DB) warehouse.ProductRepository {
+	return &SQLiteProductRepository{
+		db: db,
+	}
+}
+
+const selectProducts = "SELECT id, name, price_amount, price_currency, stock, tax_class, deactivated, stock_policy, restock_date, max_backorder FROM products"
+
+func (repository *SQLiteProductRepository) Find(ctx context.Context, id string) (*warehouse.Product, error) {
+	product, err := scanProduct(repository.db.QueryRowContext(ctx, selectProducts+" WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &domainerror.NotFoundError{Resource: warehouse.ProductResource, ID: id}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// FindAll returns the products sorted by their id
+func (repository *SQLiteProductRepository) FindAll(ctx context.Context) ([]*warehouse.Product, error) {
+	rows, err := repository.db.QueryContext(ctx, selectProducts+" ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]*warehouse.Product, 0)
+	for rows.Next() {
+		product, scanErr := scanProduct(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		products = append(products, product)
+	}
+
+	rowsErr := rows.Err()
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+
+	return products, nil
+}
+
+func (repository *SQLiteProductRepository) Save(ctx context.Context, product *warehouse.Product) error {
+	if product == nil {
+		return fmt.Errorf("product is nil")
+	} else if product.ID == "" {
+		return fmt.Errorf("product id is empty")
+	}
+
+	var restockDate int64
+	if !product.StockPolicy.RestockDate.IsZero() {
+		restockDate = product.StockPolicy.RestockDate.UnixMilli()
+	}
+
+	_, err := repository.db.ExecContext(ctx, `INSERT INTO products (id, name, price_amount, price_currency, stock, tax_class, deactivated, stock_policy, restock_date, max_backorder) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET name = excluded.name, price_amount = excluded.price_amount, price_currency = excluded.price_currency, stock = excluded.stock, tax_class = excluded.tax_class, deactivated = excluded.deactivated,
+			stock_policy = excluded.stock_policy, restock_date = excluded.restock_date, max_backorder = excluded.max_backorder`,
+		product.ID, product.Name, product.Price.GetAmount(), product.Price.GetCurrency(), product.Stock, string(product.TaxClass), product.Deactivated,
+		string(product.StockPolicy.Type), restockDate, product.StockPolicy.MaxBackorder)
+
+	return err
+}
+
+// scanner is either a *sql.Row or *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProduct(row scanner) (*warehouse.Product, error) {
+	var product warehouse.Product
+	var priceAmount int64
+	var priceCurrency string
+	var taxClass string
+	var stockPolicy string
+	var restockDate int64
+
+	err := row.Scan(&product.ID, &product.Name, &priceAmount, &priceCurrency, &product.Stock, &taxClass, &product.Deactivated, &stockPolicy, &restockDate, &product.StockPolicy.MaxBackorder)
+	if err != nil {
+		return nil, err
+	}
+	product.Price = money.New(priceAmount, priceCurrency)
+	product.TaxClass = tax.TaxClass(taxClass)
+	product.StockPolicy.Type = warehouse.StockPolicyType(stockPolicy)
+	if restockDate != 0 {
+		product.StockPolicy.RestockDate = time.UnixMilli(restockDate).UTC()
+	}
+
+	return &product, nil
+}
@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/conformance"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/money"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/sqlite"
+)
+
+func Test_SQLiteProductRepository_Conformance(t *testing.T) {
+	conformance.RunProductRepositoryTests(t, func(t *testing.T) warehouse.ProductRepository {
+		// every test gets an empty database
+		db, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, db.Close())
+		})
+
+		require.NoError(t, Migrate(t.Context(), db))
+
+		return NewSQLiteProductRepository(db)
+	})
+}
+
+func Test_SQLiteProductRepository_Save_ReturnsError(t *testing.T) {
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewSQLiteProductRepository(db)
+
+	// the table does not exist without the migrations
+	require.Error(t, repository.Save(t.Context(), &warehouse.Product{ID: "A12345", Price: money.New(1599, "EUR")}))
+
+	require.NoError(t, Migrate(t.Context(), db))
+
+	require.Error(t, repository.Save(t.Context(), &warehouse.Product{}))
+}
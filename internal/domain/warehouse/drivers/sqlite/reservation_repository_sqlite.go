@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+var _ warehouse.ReservationRepository = (*SQLiteReservationRepository)(nil)
+
+// SQLiteReservationRepository stores the reservations in the reservations table created by Migrate
+type SQLiteReservationRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteReservationRepository(db *sql.DB) warehouse.ReservationRepository {
+	return &SQLiteReservationRepository{
+		db: db,
+	}
+}
+
+func (repository *SQLiteReservationRepository) Find(ctx context.Context, holderID string, productID string) (*warehouse.Reservation, error) {
+	row := repository.db.QueryRowContext(ctx, "SELECT holder_id, product_id, count, expires_at FROM reservations WHERE holder_id = ? AND product_id = ?", holderID, productID)
+
+	reservation, err := scanReservation(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &domainerror.NotFoundError{Resource: warehouse.ReservationResource}
+		}
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+func (repository *SQLiteReservationRepository) FindByProductId(ctx context.Context, productID string) ([]*warehouse.Reservation, error) {
+	return repository.query(ctx, "SELECT holder_id, product_id, count, expires_at FROM reservations WHERE product_id = ?", productID)
+}
+
+func (repository *SQLiteReservationRepository) FindByHolderId(ctx context.Context, holderID string) ([]*warehouse.Reservation, error) {
+	return repository.query(ctx, "SELECT holder_id, product_id, count, expires_at FROM reservations WHERE holder_id = ?", holderID)
+}
+
+// Save replaces the reservation of the same holder and product, because the primary key allows only one row
+func (repository *SQLiteReservationRepository) Save(ctx context.Context, reservation *warehouse.Reservation) error {
+	if reservation == nil {
+		return fmt.Errorf("reservation is nil")
+	}
+
+	_, err := repository.db.ExecContext(ctx, `INSERT INTO reservations (holder_id, product_id, count, expires_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (holder_id, product_id) DO UPDATE SET count = excluded.count, expires_at = excluded.expires_at`,
+		reservation.GetHolderID(), reservation.GetProductID(), reservation.GetCount(), reservation.GetExpiresAt().UnixMilli())
+
+	return err
+}
+
+func (repository *SQLiteReservationRepository) Delete(ctx context.Context, holderID string, productID string) error {
+	result, err := repository.db.ExecContext(ctx, "DELETE FROM reservations WHERE holder_id = ? AND product_id = ?", holderID, productID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return &domainerror.NotFoundError{Resource: warehouse.ReservationResource}
+	}
+
+	return nil
+}
+
+// DeleteExpired returns the deleted rows from the same statement, so a reservation saved meanwhile is not returned
+func (repository *SQLiteReservationRepository) DeleteExpired(ctx context.Context, now time.Time) ([]*warehouse.Reservation, error) {
+	return repository.query(ctx, "DELETE FROM reservations WHERE expires_at <= ? RETURNING holder_id, product_id, count, expires_at", now.UnixMilli())
+}
+
+func (repository *SQLiteReservationRepository) query(ctx context.Context, query string, args ...any) ([]*warehouse.Reservation, error) {
+	rows, err := repository.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations := make([]*warehouse.Reservation, 0)
+	for rows.Next() {
+		reservation, scanErr := scanReservation(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		reservations = append(reservations, reservation)
+	}
+
+	rowsErr := rows.Err()
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+
+	return reservations, nil
+}
+
+func scanReservation(row interface{ Scan(dest ...any) error }) (*warehouse.Reservation, error) {
+	var reservation warehouse.Reservation
+	var expiresAt int64
+
+	err := row.Scan(&reservation.HolderID, &reservation.ProductID, &reservation.Count, &expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	reservation.ExpiresAt = time.UnixMilli(expiresAt).UTC()
+
+	return &reservation, nil
+}
@@ -0,0 +1,27 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/drivers/conformance"
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/sqlite"
+)
+
+func Test_SQLiteReservationRepository_Conformance(t *testing.T) {
+	conformance.RunReservationRepositoryTests(t, func(t *testing.T) warehouse.ReservationRepository {
+		// every test gets an empty database
+		db, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, db.Close())
+		})
+
+		require.NoError(t, Migrate(t.Context(), db))
+
+		return NewSQLiteReservationRepository(db)
+	})
+}
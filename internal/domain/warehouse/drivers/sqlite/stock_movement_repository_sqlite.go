@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	warehouse "github.com/arkadiusjonczek/clean-architecture-go/internal/domain/warehouse/business/entities"
+)
+
+var _ warehouse.StockMovementRepository = (*SQLiteStockMovementRepository)(nil)
+
+// SQLiteStockMovementRepository stores the movements in the stock_movements table created by Migrate
+type SQLiteStockMovementRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteStockMovementRepository(db *sql.DB) warehouse.StockMovementRepository {
+	return &SQLiteStockMovementRepository{
+		db: db,
+	}
+}
+
+// Append fails for an existing id, because the primary key prevents a second row
+func (repository *SQLiteStockMovementRepository) Append(ctx context.Context, movement *warehouse.StockMovement) error {
+	if movement == nil {
+		return fmt.Errorf("movement is nil")
+	} else if movement.ProductID == "" {
+		return fmt.Errorf("movement product id is empty")
+	}
+
+	id := movement.ID
+	if id == "" {
+		id = uuid.NewString()
+	}
+
+	_, err := repository.db.ExecContext(ctx, "INSERT INTO stock_movements (id, product_id, type, quantity, reason, location_id, reference, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		id, movement.ProductID, string(movement.Type), movement.Quantity, string(movement.Reason), movement.LocationID, movement.Reference, movement.CreatedAt.UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	movement.ID = id
+
+	return nil
+}
+
+// FindByProductId sorts the movements with the same time by their rowid, which is the order they were appended
+func (repository *SQLiteStockMovementRepository) FindByProductId(ctx context.Context, productID string, from time.Time, to time.Time) ([]*warehouse.StockMovement, error) {
+	rows, err := repository.db.QueryContext(ctx, `SELECT id, product_id, type, quantity, reason, location_id, reference, created_at FROM stock_movements
+		WHERE product_id = ? AND created_at >= ? AND created_at < ? ORDER BY created_at, rowid`,
+		productID, from.UnixMilli(), to.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := make([]*warehouse.StockMovement, 0)
+	for rows.Next() {
+		var movement warehouse.StockMovement
+		var movementType string
+		var reason string
+		var createdAt int64
+
+		scanErr := rows.Scan(&movement.ID, &movement.ProductID, &movementType, &movement.Quantity, &reason, &movement.LocationID, &movement.Reference, &createdAt)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		movement.Type = warehouse.StockMovementType(movementType)
+		movement.Reason = warehouse.StockAdjustmentReason(reason)
+		movement.CreatedAt = time.UnixMilli(createdAt).UTC()
+
+		movements = append(movements, &movement)
+	}
+
+	rowsErr := rows.Err()
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+
+	return movements, nil
+}
+
+func (repository *SQLiteStockMovementRepository) Balance(ctx context.Context, productID string, before time.Time) (*warehouse.StockBalance, error) {
+	query := "SELECT type, location_id, SUM(quantity), COUNT(*) FROM stock_movements WHERE product_id = ?"
+	args := []any{productID}
+	if !before.IsZero() {
+		query += " AND created_at < ?"
+		args = append(args, before.UnixMilli())
+	}
+
+	rows, err := repository.db.QueryContext(ctx, query+" GROUP BY type, location_id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balance := &warehouse.StockBalance{}
+	for rows.Next() {
+		var movementType string
+		var locationID string
+		var quantity int
+		var movements int
+
+		scanErr := rows.Scan(&movementType, &locationID, &quantity, &movements)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		balance.AddSum(warehouse.StockMovementType(movementType), locationID, quantity, movements)
+	}
+
+	rowsErr := rows.Err()
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+
+	return balance, nil
+}
@@ -0,0 +1,102 @@
+// Package domainerror contains the error types of the business layer.
+// The adapters map them to their protocol, e.g. to http status codes.
+package domainerror
+
+import (
+	"errors"
+	"fmt"
+)
+
+var _ error = (*ValidationError)(nil)
+
+// ValidationError is returned if the input of a use case is invalid
+type ValidationError struct {
+	Message string
+}
+
+func NewValidationError(format string, args ...any) *ValidationError {
+	return &ValidationError{
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (err *ValidationError) Error() string {
+	return err.Message
+}
+
+var _ error = (*NotFoundError)(nil)
+
+// NotFoundError is returned if a resource does not exist, ID is optional
+type NotFoundError struct {
+	Resource string
+	ID       string
+}
+
+func (err *NotFoundError) Error() string {
+	if err.ID == "" {
+		return fmt.Sprintf("%s not found", err.Resource)
+	}
+
+	return fmt.Sprintf("%s %s not found", err.Resource, err.ID)
+}
+
+// IsNotFound returns true if the error chain contains a NotFoundError of the resource
+func IsNotFound(err error, resource string) bool {
+	var notFoundErr *NotFoundError
+
+	return errors.As(err, &notFoundErr) && notFoundErr.Resource == resource
+}
+
+var _ error = (*OutOfStockError)(nil)
+
+// OutOfStockError is returned if less units of a product are available than requested
+type OutOfStockError struct {
+	ProductID string
+	Available int
+	Requested int
+}
+
+func (err *OutOfStockError) Error() string {
+	if err.Available <= 0 {
+		return fmt.Sprintf("product %s is out of stock", err.ProductID)
+	}
+
+	return fmt.Sprintf("product %s has insufficient stock: %d requested, %d available", err.ProductID, err.Requested, err.Available)
+}
+
+var _ error = (*ConflictError)(nil)
+
+// ConflictError is returned if a request conflicts with the current state, e.g. an order which cannot be cancelled anymore
+type ConflictError struct {
+	Message string
+}
+
+func NewConflictError(format string, args ...any) *ConflictError {
+	return &ConflictError{
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (err *ConflictError) Error() string {
+	return err.Message
+}
+
+var _ error = (*VersionConflictError)(nil)
+
+// VersionConflictError is returned if a resource was changed after it was read, Version is the outdated version
+type VersionConflictError struct {
+	Resource string
+	ID       string
+	Version  int64
+}
+
+func (err *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s %s was modified concurrently, version %d is outdated", err.Resource, err.ID, err.Version)
+}
+
+// IsVersionConflict returns true if the error chain contains a VersionConflictError
+func IsVersionConflict(err error) bool {
+	var versionConflictErr *VersionConflictError
+
+	return errors.As(err, &versionConflictErr)
+}
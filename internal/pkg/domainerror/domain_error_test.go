@@ -0,0 +1,43 @@
+package domainerror
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DomainError_Error(t *testing.T) {
+	testCases := map[string]struct {
+		err error
+	}{
+		"input parameter Count is invalid":                            {err: NewValidationError("input parameter %s is invalid", "Count")},
+		"basket not found":                                            {err: &NotFoundError{Resource: "basket"}},
+		"product A1 not found":                                        {err: &NotFoundError{Resource: "product", ID: "A1"}},
+		"product A1 is out of stock":                                  {err: &OutOfStockError{ProductID: "A1", Requested: 1}},
+		"product A1 has insufficient stock: 3 requested, 2 available": {err: &OutOfStockError{ProductID: "A1", Available: 2, Requested: 3}},
+		"order cannot be cancelled":                                   {err: NewConflictError("order cannot be %s", "cancelled")},
+		"basket 1 was modified concurrently, version 2 is outdated":   {err: &VersionConflictError{Resource: "basket", ID: "1", Version: 2}},
+	}
+
+	for message, testCase := range testCases {
+		t.Run(message, func(t *testing.T) {
+			require.EqualError(t, testCase.err, message)
+		})
+	}
+}
+
+func Test_IsNotFound(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &NotFoundError{Resource: "basket", ID: "1"})
+
+	require.True(t, IsNotFound(err, "basket"))
+	require.False(t, IsNotFound(err, "product"))
+	require.False(t, IsNotFound(fmt.Errorf("basket not found"), "basket"))
+}
+
+func Test_IsVersionConflict(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &VersionConflictError{Resource: "basket", ID: "1", Version: 2})
+
+	require.True(t, IsVersionConflict(err))
+	require.False(t, IsVersionConflict(NewConflictError("user 1 already has a basket")))
+}
@@ -0,0 +1,39 @@
+// Package etag converts the versions of the business layer to the entity tags of the ETag and If-Match headers.
+package etag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format returns the strong entity tag of the version, e.g. "3" including the quotes
+func Format(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ParseIfMatch returns the version of the If-Match header.
+// An empty header and * return nil, because they match every version.
+// Only a single strong entity tag created by Format is supported.
+func ParseIfMatch(header string) (*int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(header, "W/") {
+		return nil, fmt.Errorf("If-Match header %s is a weak entity tag", header)
+	}
+
+	unquoted, unquoteErr := strconv.Unquote(header)
+	if unquoteErr != nil || !strings.HasPrefix(header, `"`) {
+		return nil, fmt.Errorf("If-Match header %s is not a quoted entity tag", header)
+	}
+
+	version, parseErr := strconv.ParseInt(unquoted, 10, 64)
+	if parseErr != nil || version < 0 {
+		return nil, fmt.Errorf("If-Match header %s does not contain a version", header)
+	}
+
+	return &version, nil
+}
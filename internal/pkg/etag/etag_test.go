@@ -0,0 +1,56 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Format(t *testing.T) {
+	require.Equal(t, `"0"`, Format(0))
+	require.Equal(t, `"42"`, Format(42))
+}
+
+func Test_ParseIfMatch(t *testing.T) {
+	version := int64(42)
+
+	testCases := map[string]struct {
+		header  string
+		version *int64
+	}{
+		"empty":       {header: "", version: nil},
+		"any":         {header: "*", version: nil},
+		"version":     {header: `"42"`, version: &version},
+		"whitespaces": {header: ` "42" `, version: &version},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parsedVersion, err := ParseIfMatch(testCase.header)
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.version, parsedVersion)
+		})
+	}
+}
+
+func Test_ParseIfMatch_ReturnsError(t *testing.T) {
+	testCases := map[string]struct {
+		header string
+	}{
+		`If-Match header W/"42" is a weak entity tag`:         {header: `W/"42"`},
+		"If-Match header 42 is not a quoted entity tag":       {header: "42"},
+		`If-Match header "a" does not contain a version`:      {header: `"a"`},
+		`If-Match header "-1" does not contain a version`:     {header: `"-1"`},
+		`If-Match header "1", "2" is not a quoted entity tag`: {header: `"1", "2"`},
+	}
+
+	for errorString, testCase := range testCases {
+		t.Run(errorString, func(t *testing.T) {
+			version, err := ParseIfMatch(testCase.header)
+
+			require.EqualError(t, err, errorString)
+			require.Nil(t, version)
+		})
+	}
+}
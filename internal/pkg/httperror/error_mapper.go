@@ -0,0 +1,104 @@
+// Package httperror maps the errors of the business layer to http responses with a consistent JSON body.
+package httperror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+const (
+	CodeValidation = "validation_error"
+	CodeNotFound   = "not_found"
+	CodeConflict   = "conflict"
+	// CodeVersionConflict is returned if a resource was modified concurrently, the request can be repeated
+	CodeVersionConflict    = "version_conflict"
+	CodePreconditionFailed = "precondition_failed"
+	CodeOutOfStock         = "out_of_stock"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeTimeout            = "timeout"
+	CodeInternal           = "internal_error"
+)
+
+// ErrorResponse is the body of every error response
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Map returns the status code and the body for the error, unknown errors are internal server errors
+func Map(err error) (int, *ErrorResponse) {
+	var validationErr *domainerror.ValidationError
+	var notFoundErr *domainerror.NotFoundError
+	var conflictErr *domainerror.ConflictError
+	var versionConflictErr *domainerror.VersionConflictError
+	var outOfStockErr *domainerror.OutOfStockError
+
+	switch {
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest, newErrorResponse(CodeValidation, err)
+	case errors.As(err, &notFoundErr):
+		return http.StatusNotFound, newErrorResponse(CodeNotFound, err)
+	case errors.As(err, &versionConflictErr):
+		return http.StatusConflict, newErrorResponse(CodeVersionConflict, err)
+	case errors.As(err, &conflictErr):
+		return http.StatusConflict, newErrorResponse(CodeConflict, err)
+	case errors.As(err, &outOfStockErr):
+		return http.StatusUnprocessableEntity, newErrorResponse(CodeOutOfStock, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, newErrorResponse(CodeTimeout, err)
+	default:
+		return http.StatusInternalServerError, newErrorResponse(CodeInternal, err)
+	}
+}
+
+// Write writes the mapped error response
+func Write(c *gin.Context, err error) {
+	statusCode, response := Map(err)
+
+	c.JSON(statusCode, response)
+}
+
+// WriteBadRequest is used for requests which cannot be parsed, before any use case is executed
+func WriteBadRequest(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, newErrorResponse(CodeValidation, err))
+}
+
+// WriteConditional writes the error of a request with an If-Match header,
+// its version conflict is a 412, because the client has to read the resource again before repeating the request
+func WriteConditional(c *gin.Context, err error, conditional bool) {
+	if conditional && domainerror.IsVersionConflict(err) {
+		c.JSON(http.StatusPreconditionFailed, newErrorResponse(CodePreconditionFailed, err))
+		return
+	}
+
+	Write(c, err)
+}
+
+// AbortUnauthorized stops the handler chain with a 401
+func AbortUnauthorized(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, &ErrorResponse{
+		Code:    CodeUnauthorized,
+		Message: message,
+	})
+}
+
+// AbortForbidden stops the handler chain with a 403, the user is authenticated but lacks the required role
+func AbortForbidden(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, &ErrorResponse{
+		Code:    CodeForbidden,
+		Message: message,
+	})
+}
+
+func newErrorResponse(code string, err error) *ErrorResponse {
+	return &ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+}
@@ -0,0 +1,113 @@
+package httperror
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkadiusjonczek/clean-architecture-go/internal/pkg/domainerror"
+)
+
+func Test_Map(t *testing.T) {
+	testCases := map[string]struct {
+		err        error
+		statusCode int
+		code       string
+	}{
+		"validation error": {
+			err:        fmt.Errorf("wrapped: %w", domainerror.NewValidationError("input parameter Count is invalid")),
+			statusCode: http.StatusBadRequest,
+			code:       CodeValidation,
+		},
+		"not found": {
+			err:        &domainerror.NotFoundError{Resource: "product", ID: "A1"},
+			statusCode: http.StatusNotFound,
+			code:       CodeNotFound,
+		},
+		"conflict": {
+			err:        domainerror.NewConflictError("order cannot be cancelled"),
+			statusCode: http.StatusConflict,
+			code:       CodeConflict,
+		},
+		"version conflict": {
+			err:        fmt.Errorf("wrapped: %w", &domainerror.VersionConflictError{Resource: "basket", ID: "1", Version: 2}),
+			statusCode: http.StatusConflict,
+			code:       CodeVersionConflict,
+		},
+		"out of stock": {
+			err:        &domainerror.OutOfStockError{ProductID: "A1", Requested: 1},
+			statusCode: http.StatusUnprocessableEntity,
+			code:       CodeOutOfStock,
+		},
+		"timeout": {
+			err:        fmt.Errorf("find product: %w", context.DeadlineExceeded),
+			statusCode: http.StatusGatewayTimeout,
+			code:       CodeTimeout,
+		},
+		"unknown error": {
+			err:        fmt.Errorf("database is down"),
+			statusCode: http.StatusInternalServerError,
+			code:       CodeInternal,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			statusCode, response := Map(testCase.err)
+
+			require.Equal(t, testCase.statusCode, statusCode)
+			require.Equal(t, testCase.code, response.Code)
+			require.Equal(t, testCase.err.Error(), response.Message)
+		})
+	}
+}
+
+func Test_WriteConditional(t *testing.T) {
+	versionConflictErr := &domainerror.VersionConflictError{Resource: "basket", ID: "1", Version: 2}
+
+	testCases := map[string]struct {
+		err         error
+		conditional bool
+		statusCode  int
+		code        string
+	}{
+		"conditional version conflict": {
+			err:         versionConflictErr,
+			conditional: true,
+			statusCode:  http.StatusPreconditionFailed,
+			code:        CodePreconditionFailed,
+		},
+		"unconditional version conflict": {
+			err:         versionConflictErr,
+			conditional: false,
+			statusCode:  http.StatusConflict,
+			code:        CodeVersionConflict,
+		},
+		"conditional other error": {
+			err:         &domainerror.NotFoundError{Resource: "product", ID: "A1"},
+			conditional: true,
+			statusCode:  http.StatusNotFound,
+			code:        CodeNotFound,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+
+			WriteConditional(c, testCase.err, testCase.conditional)
+
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			require.Equal(t, testCase.statusCode, recorder.Code)
+			require.Equal(t, testCase.code, response.Code)
+		})
+	}
+}
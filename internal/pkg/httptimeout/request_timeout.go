@@ -0,0 +1,25 @@
+// Package httptimeout limits how long the use cases and drivers may work on a request.
+package httptimeout
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultRequestTimeout = 10 * time.Second
+)
+
+// NewRequestTimeout returns a middleware which sets the deadline of the request context,
+// the request context is also cancelled if the client disconnects
+func NewRequestTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,41 @@
+package httptimeout
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewRequestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var deadline time.Time
+	var hasDeadline bool
+	var ctxErr error
+
+	router := gin.New()
+	router.Use(NewRequestTimeout(10 * time.Millisecond))
+	router.GET("/", func(c *gin.Context) {
+		deadline, hasDeadline = c.Request.Context().Deadline()
+
+		<-c.Request.Context().Done()
+		ctxErr = c.Request.Context().Err()
+
+		c.Status(http.StatusNoContent)
+	})
+
+	start := time.Now()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+	require.True(t, hasDeadline)
+	require.WithinDuration(t, start.Add(10*time.Millisecond), deadline, 100*time.Millisecond)
+	require.ErrorIs(t, ctxErr, context.DeadlineExceeded)
+}
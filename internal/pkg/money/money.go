@@ -0,0 +1,261 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+const (
+	defaultMinorUnitDigits = 2
+)
+
+// minorUnitDigits contains the currencies whose minor unit differs from the default of 2 digits (ISO 4217)
+var minorUnitDigits = map[string]int{
+	"BHD": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"VND": 0,
+}
+
+// Money is a value object storing an exact amount in the minor unit of its currency, e.g. cents for EUR
+type Money struct {
+	Amount   int64  `bson:"amount" json:"amount"`
+	Currency string `bson:"currency" json:"currency"`
+}
+
+var _ error = (*CurrencyMismatchError)(nil)
+
+type CurrencyMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (err *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("currency mismatch: expected %s, got %s", err.Expected, err.Actual)
+}
+
+// New creates money from an amount in minor units
+func New(amount int64, currency string) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+// Zero returns zero money of the given currency
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// Parse creates money from an exact decimal string like "11.99".
+// More fractional digits than the currency supports are rounded half away from zero.
+func Parse(value string, currency string) (Money, error) {
+	if currency == "" {
+		return Money{}, fmt.Errorf("currency is empty")
+	}
+
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Money{}, fmt.Errorf("invalid amount: %q", value)
+	}
+
+	rat.Mul(rat, new(big.Rat).SetInt64(pow10(MinorUnitDigits(currency))))
+
+	amount, err := roundRat(rat)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return New(amount, currency), nil
+}
+
+// FromMajor creates money from a floating point amount in major units, e.g. 11.99 EUR.
+// It is meant for migrating float based data only, because the float itself may already be inexact.
+func FromMajor(value float64, currency string) (Money, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Money{}, fmt.Errorf("invalid amount: %f", value)
+	}
+
+	return Parse(fmt.Sprintf("%.*f", MinorUnitDigits(currency)+2, value), currency)
+}
+
+// MinorUnitDigits returns the number of fractional digits of the currency
+func MinorUnitDigits(currency string) int {
+	digits, exists := minorUnitDigits[strings.ToUpper(currency)]
+	if !exists {
+		return defaultMinorUnitDigits
+	}
+
+	return digits
+}
+
+func (money Money) GetAmount() int64 {
+	return money.Amount
+}
+
+func (money Money) GetCurrency() string {
+	return money.Currency
+}
+
+func (money Money) IsZero() bool {
+	return money.Amount == 0
+}
+
+func (money Money) IsNegative() bool {
+	return money.Amount < 0
+}
+
+func (money Money) IsPositive() bool {
+	return money.Amount > 0
+}
+
+// Equal compares amount and currency
+func (money Money) Equal(other Money) bool {
+	return money.Amount == other.Amount && money.Currency == other.Currency
+}
+
+// Compare returns -1, 0 or +1 like cmp.Compare, but only for the same currency
+func (money Money) Compare(other Money) (int, error) {
+	if money.Currency != other.Currency {
+		return 0, &CurrencyMismatchError{Expected: money.Currency, Actual: other.Currency}
+	}
+
+	switch {
+	case money.Amount < other.Amount:
+		return -1, nil
+	case money.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (money Money) Add(other Money) (Money, error) {
+	if money.Currency != other.Currency {
+		return Money{}, &CurrencyMismatchError{Expected: money.Currency, Actual: other.Currency}
+	}
+
+	return New(money.Amount+other.Amount, money.Currency), nil
+}
+
+func (money Money) Subtract(other Money) (Money, error) {
+	if money.Currency != other.Currency {
+		return Money{}, &CurrencyMismatchError{Expected: money.Currency, Actual: other.Currency}
+	}
+
+	return New(money.Amount-other.Amount, money.Currency), nil
+}
+
+// Multiply multiplies the amount with a quantity, e.g. the price of a basket line,
+// and returns an error like MultiplyRatio if the amount overflows int64
+func (money Money) Multiply(quantity int64) (Money, error) {
+	amount := new(big.Int).Mul(big.NewInt(money.Amount), big.NewInt(quantity))
+	if !amount.IsInt64() {
+		return Money{}, fmt.Errorf("amount overflows int64")
+	}
+
+	return New(amount.Int64(), money.Currency), nil
+}
+
+// MultiplyRatio multiplies the amount with numerator/denominator and rounds half away from zero,
+// e.g. MultiplyRatio(19, 100) for 19 percent
+func (money Money) MultiplyRatio(numerator int64, denominator int64) (Money, error) {
+	if denominator == 0 {
+		return Money{}, fmt.Errorf("denominator is zero")
+	}
+
+	rat := new(big.Rat).SetFrac(big.NewInt(money.Amount), big.NewInt(1))
+	rat.Mul(rat, big.NewRat(numerator, denominator))
+
+	amount, err := roundRat(rat)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return New(amount, money.Currency), nil
+}
+
+// Negate returns the money with the opposite sign
+func (money Money) Negate() Money {
+	return New(-money.Amount, money.Currency)
+}
+
+// FormatAmount returns the exact decimal amount without currency, e.g. "11.99"
+func (money Money) FormatAmount() string {
+	digits := MinorUnitDigits(money.Currency)
+	if digits == 0 {
+		return fmt.Sprintf("%d", money.Amount)
+	}
+
+	sign := ""
+	amount := money.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	divisor := pow10(digits)
+
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/divisor, digits, amount%divisor)
+}
+
+// String returns the amount with currency, e.g. "11.99 EUR"
+func (money Money) String() string {
+	return money.FormatAmount() + " " + money.Currency
+}
+
+// Sum adds up money of the same currency
+func Sum(currency string, values ...Money) (Money, error) {
+	total := Zero(currency)
+
+	for _, value := range values {
+		var err error
+		total, err = total.Add(value)
+		if err != nil {
+			return Money{}, err
+		}
+	}
+
+	return total, nil
+}
+
+func pow10(digits int) int64 {
+	result := int64(1)
+	for i := 0; i < digits; i++ {
+		result *= 10
+	}
+
+	return result
+}
+
+// roundRat rounds half away from zero to an int64
+func roundRat(rat *big.Rat) (int64, error) {
+	numerator := new(big.Int).Set(rat.Num())
+	denominator := rat.Denom()
+
+	negative := numerator.Sign() < 0
+	numerator.Abs(numerator)
+
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if new(big.Int).Mul(remainder, big.NewInt(2)).Cmp(denominator) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	if negative {
+		quotient.Neg(quotient)
+	}
+
+	if !quotient.IsInt64() {
+		return 0, fmt.Errorf("amount overflows int64")
+	}
+
+	return quotient.Int64(), nil
+}
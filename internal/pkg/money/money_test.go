@@ -0,0 +1,162 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func Test_Money_Parse(t *testing.T) {
+	testCases := map[string]struct {
+		value    string
+		currency string
+		expected Money
+	}{
+		"two digits":          {value: "11.99", currency: "EUR", expected: New(1199, "EUR")},
+		"no fraction":         {value: "12", currency: "EUR", expected: New(1200, "EUR")},
+		"round half up":       {value: "0.005", currency: "EUR", expected: New(1, "EUR")},
+		"round negative half": {value: "-0.005", currency: "EUR", expected: New(-1, "EUR")},
+		"zero digits":         {value: "1500", currency: "JPY", expected: New(1500, "JPY")},
+		"three digits":        {value: "1.234", currency: "KWD", expected: New(1234, "KWD")},
+		"round zero digits":   {value: "10.5", currency: "JPY", expected: New(11, "JPY")},
+		"surrounding spaces":  {value: " 3.50 ", currency: "USD", expected: New(350, "USD")},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Parse(testCase.value, testCase.currency)
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func Test_Money_Parse_ReturnsError(t *testing.T) {
+	_, err := Parse("abc", "EUR")
+	require.Error(t, err)
+
+	_, err = Parse("1.00", "")
+	require.ErrorContains(t, err, "currency is empty")
+}
+
+func Test_Money_FromMajor(t *testing.T) {
+	// 0.1 + 0.2 is 0.30000000000000004 as float64
+	actual, err := FromMajor(0.1+0.2, "EUR")
+
+	require.NoError(t, err)
+	require.Equal(t, New(30, "EUR"), actual)
+}
+
+func Test_Money_Arithmetic(t *testing.T) {
+	price := New(1199, "EUR")
+
+	sum, err := price.Add(New(1, "EUR"))
+	require.NoError(t, err)
+	require.Equal(t, New(1200, "EUR"), sum)
+
+	difference, err := price.Subtract(New(1200, "EUR"))
+	require.NoError(t, err)
+	require.Equal(t, New(-1, "EUR"), difference)
+	require.True(t, difference.IsNegative())
+
+	product, err := price.Multiply(3)
+	require.NoError(t, err)
+	require.Equal(t, New(3597, "EUR"), product)
+
+	// 19% of 11.99 is 2.2781
+	tax, err := price.MultiplyRatio(19, 100)
+	require.NoError(t, err)
+	require.Equal(t, New(228, "EUR"), tax)
+
+	_, err = price.MultiplyRatio(1, 0)
+	require.Error(t, err)
+
+	_, err = price.Add(New(1, "USD"))
+	require.ErrorAs(t, err, new(*CurrencyMismatchError))
+
+	comparison, err := price.Compare(New(1200, "EUR"))
+	require.NoError(t, err)
+	require.Equal(t, -1, comparison)
+
+	total, err := Sum("EUR", New(1, "EUR"), New(2, "EUR"), New(3, "EUR"))
+	require.NoError(t, err)
+	require.Equal(t, New(6, "EUR"), total)
+}
+
+func Test_Money_Multiply_Overflow(t *testing.T) {
+	testCases := map[string]struct {
+		money    Money
+		quantity int64
+		expected Money
+		overflow bool
+	}{
+		"max amount": {
+			money:    New(math.MaxInt64, "EUR"),
+			quantity: 1,
+			expected: New(math.MaxInt64, "EUR"),
+		},
+		"min amount": {
+			money:    New(math.MinInt64/2, "EUR"),
+			quantity: 2,
+			expected: New(math.MinInt64, "EUR"),
+		},
+		"above max amount": {
+			money:    New(math.MaxInt64/2+1, "EUR"),
+			quantity: 2,
+			overflow: true,
+		},
+		"below min amount": {
+			money:    New(math.MinInt64, "EUR"),
+			quantity: -1,
+			overflow: true,
+		},
+		"large quantity": {
+			money:    New(1199, "EUR"),
+			quantity: math.MaxInt64 / 1000,
+			overflow: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			product, err := testCase.money.Multiply(testCase.quantity)
+
+			if testCase.overflow {
+				require.ErrorContains(t, err, "amount overflows int64")
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, product)
+		})
+	}
+}
+
+func Test_Money_Format(t *testing.T) {
+	require.Equal(t, "11.99", New(1199, "EUR").FormatAmount())
+	require.Equal(t, "0.05", New(5, "EUR").FormatAmount())
+	require.Equal(t, "-0.05", New(-5, "EUR").FormatAmount())
+	require.Equal(t, "1500", New(1500, "JPY").FormatAmount())
+	require.Equal(t, "1.234", New(1234, "KWD").FormatAmount())
+	require.Equal(t, "11.99 EUR", New(1199, "EUR").String())
+}
+
+func Test_Money_BSONRoundTrip(t *testing.T) {
+	type document struct {
+		Price Money
+	}
+
+	expected := document{Price: New(1199, "EUR")}
+
+	data, err := bson.Marshal(expected)
+	require.NoError(t, err)
+
+	var actual document
+	err = bson.Unmarshal(data, &actual)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+)
+
+// migrationsDir is the directory of the *.sql files in the file system of a driver
+const migrationsDir = "migrations"
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	id         TEXT PRIMARY KEY,
+	applied_at TEXT NOT NULL
+)`
+
+// Migrate applies the migrations/*.sql files of fsys in the order of their names, e.g. 0001_create_baskets.sql.
+// Every file is applied once in its own transaction and recorded in the schema_migrations table as component/name,
+// so the drivers of several domains can share one database.
+// Applied files must never be changed, a schema change is a new file.
+func Migrate(ctx context.Context, db *sql.DB, component string, fsys fs.FS) error {
+	if db == nil {
+		return fmt.Errorf("db is nil")
+	} else if component == "" {
+		return fmt.Errorf("component is empty")
+	}
+
+	files, err := fs.Glob(fsys, path.Join(migrationsDir, "*.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	_, err = db.ExecContext(ctx, createMigrationsTable)
+	if err != nil {
+		return fmt.Errorf("failed to create the migrations table: %w", err)
+	}
+
+	for _, file := range files {
+		id := component + "/" + path.Base(file)
+
+		content, readErr := fs.ReadFile(fsys, file)
+		if readErr != nil {
+			return readErr
+		}
+
+		migrateErr := applyMigration(ctx, db, id, string(content))
+		if migrateErr != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", id, migrateErr)
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, id string, statements string) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			err = errors.Join(err, rollbackErr)
+		}
+	}()
+
+	var applied int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations WHERE id = ?", id).Scan(&applied)
+	if err != nil {
+		return err
+	}
+	if applied > 0 {
+		return tx.Rollback()
+	}
+
+	_, err = tx.ExecContext(ctx, statements)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (id, applied_at) VALUES (?, ?)", id, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
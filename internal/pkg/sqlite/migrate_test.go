@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Open_ReturnsError(t *testing.T) {
+	db, err := Open("")
+
+	require.Error(t, err)
+	require.Nil(t, db)
+
+	db, err = Open(filepath.Join(t.TempDir(), "missing", "test.db"))
+
+	require.Error(t, err)
+	require.Nil(t, db)
+}
+
+func Test_Migrate(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"migrations/0002_add_price.sql":     {Data: []byte("ALTER TABLE items ADD COLUMN price INTEGER NOT NULL DEFAULT 0;")},
+		"migrations/0001_create_items.sql":  {Data: []byte("CREATE TABLE items (id TEXT PRIMARY KEY);\nCREATE INDEX items_id ON items (id);")},
+		"migrations/README.md":              {Data: []byte("not a migration")},
+		"other/0001_create_other_table.sql": {Data: []byte("CREATE TABLE other (id TEXT);")},
+	}
+
+	require.NoError(t, Migrate(t.Context(), db, "items", fsys))
+
+	// applying the migrations again is a no-op
+	require.NoError(t, Migrate(t.Context(), db, "items", fsys))
+
+	_, err = db.ExecContext(t.Context(), "INSERT INTO items (id, price) VALUES ('A12345', 1199)")
+
+	require.NoError(t, err)
+
+	rows, err := db.QueryContext(t.Context(), "SELECT id FROM schema_migrations ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+
+	require.Equal(t, []string{"items/0001_create_items.sql", "items/0002_add_price.sql"}, ids)
+}
+
+func Test_Migrate_RollsBackFailedMigration(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"migrations/0001_create_items.sql": {Data: []byte("CREATE TABLE items (id TEXT PRIMARY KEY);\nCREATE TABLE items (id TEXT);")},
+	}
+
+	err = Migrate(t.Context(), db, "items", fsys)
+
+	require.ErrorContains(t, err, "failed to apply migration items/0001_create_items.sql")
+
+	// the first statement of the failed migration is rolled back as well
+	_, err = db.ExecContext(t.Context(), "SELECT id FROM items")
+
+	require.ErrorContains(t, err, "no such table")
+}
+
+func Test_Migrate_ReturnsError(t *testing.T) {
+	require.Error(t, Migrate(t.Context(), nil, "items", fstest.MapFS{}))
+
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.Error(t, Migrate(t.Context(), db, "", fstest.MapFS{}))
+}
@@ -0,0 +1,43 @@
+// Package sqlite opens the embedded SQLite database of the sqlite drivers and migrates their schemas.
+// It uses a pure Go SQLite, so the server is still built without cgo.
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	// registers the database/sql driver "sqlite"
+	_ "modernc.org/sqlite"
+)
+
+// busyTimeoutMilliseconds is how long a connection waits for the lock of another connection
+const busyTimeoutMilliseconds = 5000
+
+// Open opens or creates the database file.
+// SQLite allows only one writer at a time, so the pool has a single connection and the writes never fail with "database is locked".
+func Open(path string) (*sql.DB, error) {
+	if path == "" {
+		return nil, fmt.Errorf("path is empty")
+	}
+
+	pragmas := url.Values{}
+	pragmas.Add("_pragma", "foreign_keys(1)")
+	pragmas.Add("_pragma", "journal_mode(WAL)")
+	pragmas.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", busyTimeoutMilliseconds))
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+pragmas.Encode())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	// sql.Open does not connect, so a wrong path would only fail on the first query
+	err = db.Ping()
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to open database %s: %w", path, err)
+	}
+
+	return db, nil
+}